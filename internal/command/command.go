@@ -0,0 +1,28 @@
+package command
+
+import (
+	"fmt"
+	"os"
+)
+
+type command func(arguments []string) error
+
+var commands map[string]command = map[string]command{
+	"import-students": importStudents,
+	"sync-directory":  synchronizeDirectory,
+}
+
+func Run(arguments []string) {
+	name := arguments[0]
+	run, exists := commands[name]
+	if !exists {
+		fmt.Fprintf(os.Stderr, "unknown command %q\n", name)
+		os.Exit(2)
+	}
+
+	errRun := run(arguments[1:])
+	if errRun != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", name, errRun)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,49 @@
+package command
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"os"
+
+	"github.com/sorucoder/samuel/internal/samuel"
+)
+
+var errImportStudentsUsage error = errors.New("usage: import-students -administrator <identity> [-commit] <file.csv>")
+
+func importStudents(arguments []string) error {
+	flags := flag.NewFlagSet("import-students", flag.ContinueOnError)
+	administratorIdentity := flags.String("administrator", "", "identity of the administrator performing the import")
+	commit := flags.Bool("commit", false, "commit all valid rows instead of performing a dry run")
+	errParse := flags.Parse(arguments)
+	if errParse != nil {
+		return errParse
+	}
+	if *administratorIdentity == "" || flags.NArg() != 1 {
+		return errImportStudentsUsage
+	}
+
+	context := context.Background()
+
+	actor, errGetActor := samuel.GetAdministratorByIdentity(context, *administratorIdentity)
+	if errGetActor != nil {
+		return errGetActor
+	}
+
+	file, errOpen := os.Open(flags.Arg(0))
+	if errOpen != nil {
+		return errOpen
+	}
+	defer file.Close()
+
+	studentImport, errImportStudents := samuel.ImportStudents(context, actor, file, *commit)
+	if errImportStudents != nil {
+		return errImportStudents
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(studentImport)
+}
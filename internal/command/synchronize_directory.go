@@ -0,0 +1,43 @@
+package command
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"os"
+
+	"github.com/sorucoder/samuel/internal/samuel"
+)
+
+var errSynchronizeDirectoryUsage error = errors.New("usage: sync-directory -administrator <identity> [-apply]")
+
+func synchronizeDirectory(arguments []string) error {
+	flags := flag.NewFlagSet("sync-directory", flag.ContinueOnError)
+	administratorIdentity := flags.String("administrator", "", "identity of the administrator performing the synchronization")
+	apply := flags.Bool("apply", false, "apply the changes instead of printing a dry-run diff")
+	errParse := flags.Parse(arguments)
+	if errParse != nil {
+		return errParse
+	}
+	if *administratorIdentity == "" || flags.NArg() != 0 {
+		return errSynchronizeDirectoryUsage
+	}
+
+	context := context.Background()
+
+	actor, errGetActor := samuel.GetAdministratorByIdentity(context, *administratorIdentity)
+	if errGetActor != nil {
+		return errGetActor
+	}
+
+	directorySynchronization, errSynchronize := samuel.SynchronizeDirectory(context, actor, *apply)
+	if errSynchronize != nil {
+		return errSynchronize
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(directorySynchronization)
+}
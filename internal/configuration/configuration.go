@@ -12,6 +12,7 @@ var (
 	Application *viper.Viper
 	Database    *viper.Viper
 	LDAP        *viper.Viper
+	OIDC        *viper.Viper
 	Email       *viper.Viper
 )
 
@@ -22,6 +23,16 @@ func Initialize() {
 	Application.AutomaticEnv()
 	Application.SetDefault("port", 5000)
 	Application.SetDefault("connections", 3)
+	Application.SetDefault("invitationLifetime", "168h")
+	Application.SetDefault("twoFactorIssuer", "S.A.M.U.E.L.")
+	Application.SetDefault("trustedProxies", []string{})
+	Application.SetDefault("loginWindow", "15m")
+	Application.SetDefault("loginBackoff", "1s")
+	Application.SetDefault("loginMaximumBackoff", "15m")
+	Application.SetDefault("loginIdentityThreshold", 3)
+	Application.SetDefault("loginAddressThreshold", 10)
+	Application.SetDefault("loginLockoutThreshold", 10)
+	Application.SetDefault("loginLockoutDuration", "30m")
 
 	Database = viper.New()
 	Database.SetEnvPrefix("samuel_database")
@@ -35,6 +46,21 @@ func Initialize() {
 	LDAP.SetEnvKeyReplacer(envKeyReplacer)
 	LDAP.AutomaticEnv()
 	LDAP.SetDefault("port", 389)
+	LDAP.SetDefault("syncInterval", "0s")
+	LDAP.SetDefault("syncMaximumRemovalShare", 0.1)
+	LDAP.SetDefault("provisionRoles", []string{})
+	LDAP.SetDefault("provisionRules", []string{})
+	LDAP.SetDefault("roleGroups", []string{})
+
+	OIDC = viper.New()
+	OIDC.SetEnvPrefix("samuel_oidc")
+	OIDC.SetEnvKeyReplacer(envKeyReplacer)
+	OIDC.AutomaticEnv()
+	OIDC.SetDefault("scopes", []string{"openid", "email", "profile"})
+	OIDC.SetDefault("identityClaim", "sub")
+	OIDC.SetDefault("providers", []string{"oidc"})
+	OIDC.SetDefault("timeout", "10s")
+	OIDC.SetDefault("stateLifetime", "10m")
 
 	Email = viper.New()
 	Email.SetEnvPrefix("samuel_email")
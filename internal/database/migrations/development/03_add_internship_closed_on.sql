@@ -0,0 +1,8 @@
+-- +migrate Up
+ALTER TABLE `internships`
+    ADD COLUMN `closed_on`
+        DATETIME;
+
+-- +migrate Down
+ALTER TABLE `internships`
+    DROP COLUMN `closed_on`;
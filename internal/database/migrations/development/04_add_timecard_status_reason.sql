@@ -0,0 +1,8 @@
+-- +migrate Up
+ALTER TABLE `timecards`
+    ADD COLUMN `status_reason`
+        TEXT;
+
+-- +migrate Down
+ALTER TABLE `timecards`
+    DROP COLUMN `status_reason`;
@@ -0,0 +1,8 @@
+-- +migrate Up
+ALTER TABLE `supervisor_reports`
+    ADD COLUMN `phone_call_resolved_on`
+        DATETIME;
+
+-- +migrate Down
+ALTER TABLE `supervisor_reports`
+    DROP COLUMN `phone_call_resolved_on`;
@@ -0,0 +1,13 @@
+-- +migrate Up
+ALTER TABLE `program_evaluation_questions`
+    ADD COLUMN `position`
+        TINYINT UNSIGNED
+        NOT NULL
+        DEFAULT 0,
+    ADD COLUMN `retired_on`
+        DATETIME;
+
+-- +migrate Down
+ALTER TABLE `program_evaluation_questions`
+    DROP COLUMN `retired_on`,
+    DROP COLUMN `position`;
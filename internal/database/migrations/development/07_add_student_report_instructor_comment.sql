@@ -0,0 +1,11 @@
+-- +migrate Up
+ALTER TABLE `student_reports`
+    ADD COLUMN `instructor_comment`
+        TEXT,
+    ADD COLUMN `commented_on`
+        DATETIME;
+
+-- +migrate Down
+ALTER TABLE `student_reports`
+    DROP COLUMN `commented_on`,
+    DROP COLUMN `instructor_comment`;
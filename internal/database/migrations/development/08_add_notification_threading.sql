@@ -0,0 +1,19 @@
+-- +migrate Up
+ALTER TABLE `notifications`
+    ADD COLUMN `thread_uuid`
+        CHAR(36),
+    ADD COLUMN `reply_to_uuid`
+        CHAR(36),
+    ADD COLUMN `deleted_on`
+        DATETIME,
+    ADD CONSTRAINT `foreign_key_notifications_reply_to_uuid`
+        FOREIGN KEY (`reply_to_uuid`)
+        REFERENCES `notifications`(`uuid`)
+        ON DELETE SET NULL;
+
+-- +migrate Down
+ALTER TABLE `notifications`
+    DROP FOREIGN KEY `foreign_key_notifications_reply_to_uuid`,
+    DROP COLUMN `deleted_on`,
+    DROP COLUMN `reply_to_uuid`,
+    DROP COLUMN `thread_uuid`;
@@ -0,0 +1,8 @@
+-- +migrate Up
+ALTER TABLE `companies`
+    ADD COLUMN `archived_on`
+        DATETIME;
+
+-- +migrate Down
+ALTER TABLE `companies`
+    DROP COLUMN `archived_on`;
@@ -0,0 +1,15 @@
+-- +migrate Up
+ALTER TABLE `campuses`
+    ADD COLUMN `retired_on`
+        DATETIME;
+
+ALTER TABLE `programs`
+    ADD COLUMN `retired_on`
+        DATETIME;
+
+-- +migrate Down
+ALTER TABLE `programs`
+    DROP COLUMN `retired_on`;
+
+ALTER TABLE `campuses`
+    DROP COLUMN `retired_on`;
@@ -0,0 +1,38 @@
+-- +migrate Up
+ALTER TABLE `users`
+    ADD COLUMN `activated_on`
+        DATETIME;
+
+UPDATE `users`
+SET `activated_on` = `created_on`;
+
+CREATE TABLE `supervisor_invitations` (
+    `token`
+        CHAR(36)
+        NOT NULL
+        UNIQUE
+        DEFAULT (UUID()),
+    `supervisor_uuid`
+        CHAR(36)
+        NOT NULL
+        UNIQUE,
+    `sent_on`
+        DATETIME
+        NOT NULL
+        DEFAULT (NOW()),
+    `expires_on`
+        DATETIME
+        NOT NULL,
+    `accepted_on`
+        DATETIME,
+    PRIMARY KEY (`token`),
+    FOREIGN KEY (`supervisor_uuid`)
+        REFERENCES `supervisors`(`user_uuid`)
+        ON DELETE CASCADE
+);
+
+-- +migrate Down
+DROP TABLE `supervisor_invitations`;
+
+ALTER TABLE `users`
+    DROP COLUMN `activated_on`;
@@ -0,0 +1,8 @@
+-- +migrate Up
+ALTER TABLE `users`
+    ADD COLUMN `directory_removed_on`
+        DATETIME;
+
+-- +migrate Down
+ALTER TABLE `users`
+    DROP COLUMN `directory_removed_on`;
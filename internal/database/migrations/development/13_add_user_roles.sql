@@ -0,0 +1,33 @@
+-- +migrate Up
+CREATE TABLE `user_roles` (
+    `user_uuid`
+        CHAR(36)
+        NOT NULL,
+    `role_id`
+        VARCHAR(32)
+        NOT NULL,
+    PRIMARY KEY (`user_uuid`, `role_id`),
+    FOREIGN KEY (`user_uuid`)
+        REFERENCES `users`(`uuid`)
+        ON DELETE CASCADE,
+    FOREIGN KEY (`role_id`)
+        REFERENCES `roles`(`id`)
+);
+
+INSERT INTO `user_roles` (`user_uuid`, `role_id`)
+SELECT `uuid`, `role_id`
+FROM `users`;
+
+ALTER TABLE `sessions`
+    ADD COLUMN `active_role_id`
+        VARCHAR(32),
+    ADD CONSTRAINT `foreign_key_sessions_active_role_id`
+        FOREIGN KEY (`active_role_id`)
+        REFERENCES `roles`(`id`);
+
+-- +migrate Down
+ALTER TABLE `sessions`
+    DROP FOREIGN KEY `foreign_key_sessions_active_role_id`,
+    DROP COLUMN `active_role_id`;
+
+DROP TABLE `user_roles`;
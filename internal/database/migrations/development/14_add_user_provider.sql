@@ -0,0 +1,30 @@
+-- +migrate Up
+ALTER TABLE `users`
+    ADD COLUMN `provider`
+        VARCHAR(32)
+        NOT NULL
+        DEFAULT 'ldap';
+
+UPDATE `users`
+SET `provider` = 'password'
+WHERE `role_id` = 'supervisor';
+
+ALTER TABLE `users`
+    DROP CHECK `check_only_supervisors_use_password_hash`,
+    ADD CONSTRAINT `check_only_password_provider_uses_password_hash`
+        CHECK (
+            (`provider` = 'password' AND `password_hash` IS NOT NULL) OR
+            (`provider` != 'password' AND `password_hash` IS NULL)
+        );
+
+-- +migrate Down
+ALTER TABLE `users`
+    DROP CHECK `check_only_password_provider_uses_password_hash`,
+    ADD CONSTRAINT `check_only_supervisors_use_password_hash`
+        CHECK (
+            (`role_id` = 'supervisor' AND `password_hash` IS NOT NULL) OR
+            (`role_id` != 'supervisor' AND `password_hash` IS NULL)
+        );
+
+ALTER TABLE `users`
+    DROP COLUMN `provider`;
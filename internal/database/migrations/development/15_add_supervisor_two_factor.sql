@@ -0,0 +1,89 @@
+-- +migrate Up
+ALTER TABLE `companies`
+    ADD COLUMN `two_factor_required`
+        BOOLEAN
+        NOT NULL
+        DEFAULT FALSE;
+
+CREATE TABLE `supervisor_two_factors` (
+    `supervisor_uuid`
+        CHAR(36)
+        NOT NULL,
+    -- Sealed with AES-GCM under the application twoFactorKey; never stored in plaintext.
+    `secret`
+        VARCHAR(128)
+        NOT NULL,
+    `created_on`
+        DATETIME
+        NOT NULL
+        DEFAULT (NOW()),
+    `confirmed_on`
+        DATETIME,
+    `last_used_step`
+        BIGINT,
+    PRIMARY KEY (`supervisor_uuid`),
+    FOREIGN KEY (`supervisor_uuid`)
+        REFERENCES `supervisors`(`user_uuid`)
+        ON DELETE CASCADE
+);
+
+CREATE TABLE `supervisor_recovery_codes` (
+    `supervisor_uuid`
+        CHAR(36)
+        NOT NULL,
+    `code_hash`
+        CHAR(64)
+        NOT NULL,
+    `used_on`
+        DATETIME,
+    PRIMARY KEY (`supervisor_uuid`, `code_hash`),
+    FOREIGN KEY (`supervisor_uuid`)
+        REFERENCES `supervisors`(`user_uuid`)
+        ON DELETE CASCADE
+);
+
+CREATE TABLE `two_factor_challenges` (
+    `token`
+        CHAR(36)
+        NOT NULL
+        UNIQUE
+        DEFAULT (UUID()),
+    `supervisor_uuid`
+        CHAR(36)
+        NOT NULL
+        UNIQUE,
+    `enrollment`
+        BOOLEAN
+        NOT NULL
+        DEFAULT FALSE,
+    `attempts`
+        INT
+        NOT NULL
+        DEFAULT 0,
+    `expires_on`
+        DATETIME
+        NOT NULL
+        DEFAULT (DATE_ADD(NOW(), INTERVAL 5 MINUTE)),
+    PRIMARY KEY (`token`),
+    FOREIGN KEY (`supervisor_uuid`)
+        REFERENCES `supervisors`(`user_uuid`)
+        ON DELETE CASCADE
+);
+
+CREATE EVENT `event_delete_expired_two_factor_challenges`
+    ON SCHEDULE EVERY 10 MINUTE
+DO
+    DELETE FROM `two_factor_challenges`
+    WHERE `expires_on` < NOW();
+
+-- +migrate Down
+DROP EVENT `event_delete_expired_two_factor_challenges`;
+
+DROP TABLE `two_factor_challenges`;
+
+DROP TABLE `supervisor_recovery_codes`;
+
+DROP TABLE `supervisor_two_factors`;
+
+ALTER TABLE `companies`
+    DROP COLUMN `two_factor_required`;
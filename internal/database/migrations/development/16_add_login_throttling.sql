@@ -0,0 +1,36 @@
+-- +migrate Up
+ALTER TABLE `users`
+    ADD COLUMN `locked_until`
+        DATETIME;
+
+CREATE TABLE `failed_logins` (
+    `id`
+        SERIAL,
+    `identity`
+        VARCHAR(254)
+        NOT NULL,
+    `address`
+        VARCHAR(45)
+        NOT NULL,
+    `attempted_on`
+        DATETIME
+        NOT NULL
+        DEFAULT (NOW()),
+    PRIMARY KEY (`id`),
+    INDEX `index_failed_logins_identity` (`identity`, `attempted_on`),
+    INDEX `index_failed_logins_address` (`address`, `attempted_on`)
+);
+
+CREATE EVENT `event_delete_stale_failed_logins`
+    ON SCHEDULE EVERY 1 HOUR
+DO
+    DELETE FROM `failed_logins`
+    WHERE `attempted_on` < DATE_SUB(NOW(), INTERVAL 1 DAY);
+
+-- +migrate Down
+DROP EVENT `event_delete_stale_failed_logins`;
+
+DROP TABLE `failed_logins`;
+
+ALTER TABLE `users`
+    DROP COLUMN `locked_until`;
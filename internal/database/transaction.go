@@ -7,7 +7,8 @@ import (
 )
 
 type Transaction struct {
-	raw *sqlx.Tx
+	raw       *sqlx.Tx
+	onCommits []func()
 }
 
 func Begin(context context.Context) (*Transaction, error) {
@@ -35,8 +36,21 @@ func (transaction *Transaction) Execute(context context.Context, query string, a
 	return newResult(rawResult), errExecute
 }
 
+func (transaction *Transaction) OnCommit(callback func()) {
+	transaction.onCommits = append(transaction.onCommits, callback)
+}
+
 func (transaction *Transaction) Commit() error {
-	return transaction.raw.Commit()
+	errCommit := transaction.raw.Commit()
+	if errCommit != nil {
+		return errCommit
+	}
+
+	for _, onCommit := range transaction.onCommits {
+		onCommit()
+	}
+
+	return nil
 }
 
 func (transaction *Transaction) Rollback() error {
@@ -13,6 +13,8 @@ var templatesFS embed.FS
 
 var (
 	PasswordChangeRequestTemplate *Template = newTemplate("Password Change Request", "password_change_request.go.html")
+	PhoneCallRequestTemplate      *Template = newTemplate("Phone Call Request", "phone_call_request.go.html")
+	SupervisorOnboardingTemplate  *Template = newTemplate("Supervisor Account Created", "supervisor_onboarding.go.html")
 )
 
 type Template struct {
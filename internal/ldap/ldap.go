@@ -4,12 +4,22 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"sync"
 
 	"github.com/go-ldap/ldap/v3"
 	"github.com/sorucoder/samuel/internal/configuration"
 )
 
+const (
+	searchPageSize     uint32 = 500
+	identityFilterSize int    = 100
+)
+
+var (
+	entryAttributes []string = []string{"uid", "givenName", "sn", "mail", "telephoneNumber", "department", "street", "l", "st", "postalCode", "memberOf"}
+)
+
 var (
 	address      string
 	baseDN       string
@@ -20,6 +30,20 @@ var (
 	mutex sync.Mutex
 )
 
+type Entry struct {
+	Identity        string
+	GivenName       string
+	Surname         string
+	Mail            string
+	TelephoneNumber string
+	Department      string
+	Street          string
+	Locality        string
+	State           string
+	PostalCode      string
+	Groups          []string
+}
+
 type connection struct {
 	raw *ldap.Conn
 }
@@ -116,6 +140,95 @@ func (connection *connection) searchUserDN(context context.Context, identity str
 	}
 }
 
+func (connection *connection) searchEntries(context context.Context, filter string) ([]*Entry, error) {
+	request := ldap.NewSearchRequest(
+		baseDN,
+		ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0, false,
+		filter,
+		entryAttributes,
+		nil,
+	)
+
+	chResult := make(chan *ldap.SearchResult)
+	chErrSearch := make(chan error)
+
+	go func() {
+		result, errSearch := connection.raw.SearchWithPaging(request, searchPageSize)
+		if errSearch != nil {
+			chErrSearch <- errSearch
+			return
+		}
+		chResult <- result
+	}()
+
+	select {
+	case <-context.Done():
+		return nil, context.Err()
+	case errSearch := <-chErrSearch:
+		return nil, errSearch
+	case result := <-chResult:
+		entries := make([]*Entry, 0, len(result.Entries))
+		for _, rawEntry := range result.Entries {
+			entries = append(entries, &Entry{
+				Identity:        rawEntry.GetAttributeValue("uid"),
+				GivenName:       rawEntry.GetAttributeValue("givenName"),
+				Surname:         rawEntry.GetAttributeValue("sn"),
+				Mail:            rawEntry.GetAttributeValue("mail"),
+				TelephoneNumber: rawEntry.GetAttributeValue("telephoneNumber"),
+				Department:      rawEntry.GetAttributeValue("department"),
+				Street:          rawEntry.GetAttributeValue("street"),
+				Locality:        rawEntry.GetAttributeValue("l"),
+				State:           rawEntry.GetAttributeValue("st"),
+				PostalCode:      rawEntry.GetAttributeValue("postalCode"),
+				Groups:          rawEntry.GetAttributeValues("memberOf"),
+			})
+		}
+		return entries, nil
+	}
+}
+
+func (connection *connection) searchIdentities(context context.Context, identities []string) ([]string, error) {
+	var filterBuilder strings.Builder
+	filterBuilder.WriteString("(&(objectClass=organizationalPerson)(|")
+	for _, identity := range identities {
+		filterBuilder.WriteString(fmt.Sprintf("(uid=%s)", ldap.EscapeFilter(identity)))
+	}
+	filterBuilder.WriteString("))")
+
+	request := ldap.NewSearchRequest(
+		baseDN,
+		ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0, false,
+		filterBuilder.String(),
+		[]string{"uid"},
+		nil,
+	)
+
+	chResult := make(chan *ldap.SearchResult)
+	chErrSearch := make(chan error)
+
+	go func() {
+		result, errSearch := connection.raw.SearchWithPaging(request, searchPageSize)
+		if errSearch != nil {
+			chErrSearch <- errSearch
+			return
+		}
+		chResult <- result
+	}()
+
+	select {
+	case <-context.Done():
+		return nil, context.Err()
+	case errSearch := <-chErrSearch:
+		return nil, errSearch
+	case result := <-chResult:
+		found := make([]string, 0, len(result.Entries))
+		for _, rawEntry := range result.Entries {
+			found = append(found, rawEntry.GetAttributeValue("uid"))
+		}
+		return found, nil
+	}
+}
+
 func (connection *connection) close() error {
 	mutex.Lock()
 	defer mutex.Unlock()
@@ -167,3 +280,77 @@ func Authenticate(context context.Context, identity string, password string) err
 
 	return nil
 }
+
+func Exists(context context.Context, identity string) (bool, error) {
+	connection, errDive := dive()
+	if errDive != nil {
+		return false, errDive
+	}
+	defer connection.close()
+
+	_, errSearch := connection.searchUserDN(context, identity)
+	if errSearch != nil {
+		if errors.Is(errSearch, ErrUserNotFound) {
+			return false, nil
+		}
+		return false, errSearch
+	}
+
+	return true, nil
+}
+
+// ExistingIdentities reports which of identities exist in the directory, keyed
+// by lowercased identity. Identities are looked up in batches over a single
+// connection rather than one search each.
+func ExistingIdentities(context context.Context, identities []string) (map[string]bool, error) {
+	existing := make(map[string]bool, len(identities))
+	if len(identities) == 0 {
+		return existing, nil
+	}
+
+	connection, errDive := dive()
+	if errDive != nil {
+		return nil, errDive
+	}
+	defer connection.close()
+
+	for start := 0; start < len(identities); start += identityFilterSize {
+		found, errSearch := connection.searchIdentities(context, identities[start:min(start+identityFilterSize, len(identities))])
+		if errSearch != nil {
+			return nil, errSearch
+		}
+		for _, identity := range found {
+			existing[strings.ToLower(identity)] = true
+		}
+	}
+
+	return existing, nil
+}
+
+func Search(context context.Context) ([]*Entry, error) {
+	connection, errDive := dive()
+	if errDive != nil {
+		return nil, errDive
+	}
+	defer connection.close()
+
+	return connection.searchEntries(context, "(&(objectClass=organizationalPerson)(uid=*))")
+}
+
+func Lookup(context context.Context, identity string) (*Entry, error) {
+	connection, errDive := dive()
+	if errDive != nil {
+		return nil, errDive
+	}
+	defer connection.close()
+
+	entries, errSearch := connection.searchEntries(context, fmt.Sprintf("(&(objectClass=organizationalPerson)(uid=%s))", ldap.EscapeFilter(identity)))
+	if errSearch != nil {
+		return nil, errSearch
+	}
+	if len(entries) != 1 {
+		return nil, ErrUserNotFound
+	}
+
+	return entries[0], nil
+}
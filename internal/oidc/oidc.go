@@ -0,0 +1,249 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sorucoder/samuel/internal/configuration"
+)
+
+const (
+	clockSkew time.Duration = time.Minute
+)
+
+var (
+	issuer        string
+	clientID      string
+	clientSecret  string
+	redirectURL   string
+	scopes        []string
+	identityClaim string
+
+	client *http.Client
+
+	metadata *providerMetadata
+	keys     map[string]any
+	mutex    sync.Mutex
+)
+
+type providerMetadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+var (
+	ErrNotConfigured    error = errors.New("oidc not configured")
+	ErrDiscoveryInvalid error = errors.New("oidc discovery invalid")
+	ErrTokenExchange    error = errors.New("oidc token exchange failed")
+	ErrTokenInvalid     error = errors.New("oidc token invalid")
+	ErrNonceMismatch    error = errors.New("oidc nonce mismatch")
+	ErrIdentityMissing  error = errors.New("oidc identity claim missing")
+	ErrEmailUnverified  error = errors.New("oidc email unverified")
+)
+
+func Initialize() {
+	issuer = strings.TrimSuffix(configuration.OIDC.GetString("issuer"), "/")
+
+	clientID = configuration.OIDC.GetString("clientID")
+
+	clientSecret = configuration.OIDC.GetString("clientSecret")
+
+	redirectURL = configuration.OIDC.GetString("redirectURL")
+
+	scopes = configuration.OIDC.GetStringSlice("scopes")
+
+	identityClaim = configuration.OIDC.GetString("identityClaim")
+
+	client = &http.Client{
+		Timeout: configuration.OIDC.GetDuration("timeout"),
+	}
+
+	mutex.Lock()
+	metadata = nil
+	keys = nil
+	mutex.Unlock()
+}
+
+func Enabled() bool {
+	return issuer != "" && clientID != "" && redirectURL != ""
+}
+
+func getJSON(context context.Context, endpoint string, value any) error {
+	request, errNewRequest := http.NewRequestWithContext(context, http.MethodGet, endpoint, nil)
+	if errNewRequest != nil {
+		return errNewRequest
+	}
+	request.Header.Set("Accept", "application/json")
+
+	response, errDo := client.Do(request)
+	if errDo != nil {
+		return errDo
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s returned %s", endpoint, response.Status)
+	}
+
+	return json.NewDecoder(response.Body).Decode(value)
+}
+
+func discover(context context.Context) (*providerMetadata, error) {
+	mutex.Lock()
+	cached := metadata
+	mutex.Unlock()
+	if cached != nil {
+		return cached, nil
+	}
+
+	discovered := new(providerMetadata)
+	errGet := getJSON(context, issuer+"/.well-known/openid-configuration", discovered)
+	if errGet != nil {
+		return nil, errors.Join(ErrDiscoveryInvalid, errGet)
+	}
+	if strings.TrimSuffix(discovered.Issuer, "/") != issuer || discovered.AuthorizationEndpoint == "" || discovered.TokenEndpoint == "" || discovered.JWKSURI == "" {
+		return nil, ErrDiscoveryInvalid
+	}
+
+	mutex.Lock()
+	metadata = discovered
+	mutex.Unlock()
+
+	return discovered, nil
+}
+
+func NewToken() (string, error) {
+	token := make([]byte, 32)
+	_, errRead := rand.Read(token)
+	if errRead != nil {
+		return "", errRead
+	}
+
+	return base64.RawURLEncoding.EncodeToString(token), nil
+}
+
+func challenge(verifier string) string {
+	digest := sha256.Sum256([]byte(verifier))
+
+	return base64.RawURLEncoding.EncodeToString(digest[:])
+}
+
+func AuthorizationURL(context context.Context, state string, nonce string, verifier string) (string, error) {
+	if !Enabled() {
+		return "", ErrNotConfigured
+	}
+
+	discovered, errDiscover := discover(context)
+	if errDiscover != nil {
+		return "", errDiscover
+	}
+
+	authorizationURL, errParse := url.Parse(discovered.AuthorizationEndpoint)
+	if errParse != nil {
+		return "", errors.Join(ErrDiscoveryInvalid, errParse)
+	}
+
+	query := authorizationURL.Query()
+	query.Set("response_type", "code")
+	query.Set("client_id", clientID)
+	query.Set("redirect_uri", redirectURL)
+	query.Set("scope", strings.Join(scopes, " "))
+	query.Set("state", state)
+	query.Set("nonce", nonce)
+	query.Set("code_challenge", challenge(verifier))
+	query.Set("code_challenge_method", "S256")
+	authorizationURL.RawQuery = query.Encode()
+
+	return authorizationURL.String(), nil
+}
+
+func exchange(context context.Context, tokenEndpoint string, code string, verifier string) (string, error) {
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", redirectURL)
+	form.Set("client_id", clientID)
+	form.Set("code_verifier", verifier)
+	if clientSecret != "" {
+		form.Set("client_secret", clientSecret)
+	}
+
+	request, errNewRequest := http.NewRequestWithContext(context, http.MethodPost, tokenEndpoint, strings.NewReader(form.Encode()))
+	if errNewRequest != nil {
+		return "", errNewRequest
+	}
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	request.Header.Set("Accept", "application/json")
+
+	response, errDo := client.Do(request)
+	if errDo != nil {
+		return "", errors.Join(ErrTokenExchange, errDo)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%w: token endpoint returned %s", ErrTokenExchange, response.Status)
+	}
+
+	var tokenResponse struct {
+		IDToken string `json:"id_token"`
+	}
+	errDecode := json.NewDecoder(response.Body).Decode(&tokenResponse)
+	if errDecode != nil {
+		return "", errors.Join(ErrTokenExchange, errDecode)
+	}
+	if tokenResponse.IDToken == "" {
+		return "", fmt.Errorf("%w: missing id_token", ErrTokenExchange)
+	}
+
+	return tokenResponse.IDToken, nil
+}
+
+func Exchange(context context.Context, code string, verifier string, nonce string) (string, error) {
+	if !Enabled() {
+		return "", ErrNotConfigured
+	}
+
+	discovered, errDiscover := discover(context)
+	if errDiscover != nil {
+		return "", errDiscover
+	}
+
+	rawIDToken, errExchange := exchange(context, discovered.TokenEndpoint, code, verifier)
+	if errExchange != nil {
+		return "", errExchange
+	}
+
+	claims, errVerify := verify(context, discovered, rawIDToken)
+	if errVerify != nil {
+		return "", errVerify
+	}
+
+	if tokenNonce, _ := claims["nonce"].(string); tokenNonce != nonce {
+		return "", ErrNonceMismatch
+	}
+
+	identity, _ := claims[identityClaim].(string)
+	if identity == "" {
+		return "", ErrIdentityMissing
+	}
+	if identityClaim == "email" {
+		if emailVerified, _ := claims["email_verified"].(bool); !emailVerified {
+			return "", ErrEmailUnverified
+		}
+	}
+
+	return identity, nil
+}
@@ -0,0 +1,263 @@
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	testClientID string = "samuel"
+	testNonce    string = "nonce"
+)
+
+type mockIssuer struct {
+	server     *httptest.Server
+	rsaKey     *rsa.PrivateKey
+	ecKey      *ecdsa.PrivateKey
+	idToken    string
+	keyFetches int
+}
+
+func newMockIssuer(t *testing.T) *mockIssuer {
+	t.Helper()
+
+	rsaKey, errGenerateRSAKey := rsa.GenerateKey(rand.Reader, 2048)
+	if errGenerateRSAKey != nil {
+		t.Fatal(errGenerateRSAKey)
+	}
+
+	ecKey, errGenerateECKey := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if errGenerateECKey != nil {
+		t.Fatal(errGenerateECKey)
+	}
+
+	mock := &mockIssuer{rsaKey: rsaKey, ecKey: ecKey}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(writer http.ResponseWriter, request *http.Request) {
+		json.NewEncoder(writer).Encode(map[string]string{
+			"issuer":                 mock.server.URL,
+			"authorization_endpoint": mock.server.URL + "/authorize",
+			"token_endpoint":         mock.server.URL + "/token",
+			"jwks_uri":               mock.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(writer http.ResponseWriter, request *http.Request) {
+		mock.keyFetches++
+		json.NewEncoder(writer).Encode(map[string]any{
+			"keys": []map[string]string{
+				{
+					"kty": "RSA",
+					"kid": "rsa",
+					"use": "sig",
+					"n":   encodeBigInteger(rsaKey.N),
+					"e":   encodeBigInteger(big.NewInt(int64(rsaKey.E))),
+				},
+				{
+					"kty": "EC",
+					"kid": "ec",
+					"use": "sig",
+					"crv": "P-256",
+					"x":   encodeBigInteger(ecKey.X),
+					"y":   encodeBigInteger(ecKey.Y),
+				},
+			},
+		})
+	})
+	mux.HandleFunc("/token", func(writer http.ResponseWriter, request *http.Request) {
+		if request.PostFormValue("code") != "code" || request.PostFormValue("code_verifier") != "verifier" {
+			writer.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		json.NewEncoder(writer).Encode(map[string]string{"id_token": mock.idToken})
+	})
+	mock.server = httptest.NewServer(mux)
+	t.Cleanup(mock.server.Close)
+
+	issuer = mock.server.URL
+	clientID = testClientID
+	clientSecret = ""
+	redirectURL = "http://localhost/callback"
+	scopes = []string{"openid"}
+	identityClaim = "sub"
+	client = mock.server.Client()
+	metadata = nil
+	keys = nil
+
+	return mock
+}
+
+func encodeBigInteger(value *big.Int) string {
+	return base64.RawURLEncoding.EncodeToString(value.Bytes())
+}
+
+func encodeJSON(t *testing.T, value any) string {
+	t.Helper()
+
+	raw, errMarshal := json.Marshal(value)
+	if errMarshal != nil {
+		t.Fatal(errMarshal)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func (mock *mockIssuer) claims() map[string]any {
+	return map[string]any{
+		"iss":   mock.server.URL,
+		"aud":   testClientID,
+		"sub":   "jdoe",
+		"nonce": testNonce,
+		"exp":   time.Now().Add(time.Hour).Unix(),
+		"iat":   time.Now().Unix(),
+	}
+}
+
+func (mock *mockIssuer) sign(t *testing.T, algorithm string, keyID string, claims map[string]any) string {
+	t.Helper()
+
+	signingInput := encodeJSON(t, map[string]string{"alg": algorithm, "kid": keyID}) + "." + encodeJSON(t, claims)
+
+	hash := algorithmHashes[algorithm]
+	hasher := hash.New()
+	hasher.Write([]byte(signingInput))
+	digest := hasher.Sum(nil)
+
+	var signature []byte
+	switch keyID {
+	case "ec":
+		r, s, errSign := ecdsa.Sign(rand.Reader, mock.ecKey, digest)
+		if errSign != nil {
+			t.Fatal(errSign)
+		}
+		signature = make([]byte, 64)
+		r.FillBytes(signature[:32])
+		s.FillBytes(signature[32:])
+	default:
+		var errSign error
+		signature, errSign = rsa.SignPKCS1v15(rand.Reader, mock.rsaKey, crypto.SHA256, digest)
+		if errSign != nil {
+			t.Fatal(errSign)
+		}
+	}
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func TestExchange(t *testing.T) {
+	tests := []struct {
+		name      string
+		algorithm string
+		keyID     string
+		nonce     string
+		modify    func(claims map[string]any)
+		tamper    bool
+		err       error
+	}{
+		{name: "rsa", algorithm: "RS256", keyID: "rsa"},
+		{name: "ecdsa", algorithm: "ES256", keyID: "ec"},
+		{name: "wrong issuer", algorithm: "RS256", keyID: "rsa", modify: func(claims map[string]any) { claims["iss"] = "https://attacker.example" }, err: ErrTokenInvalid},
+		{name: "wrong audience", algorithm: "RS256", keyID: "rsa", modify: func(claims map[string]any) { claims["aud"] = []string{"another-client"} }, err: ErrTokenInvalid},
+		{name: "expired", algorithm: "RS256", keyID: "rsa", modify: func(claims map[string]any) { claims["exp"] = time.Now().Add(-time.Hour).Unix() }, err: ErrTokenInvalid},
+		{name: "wrong nonce", algorithm: "RS256", keyID: "rsa", nonce: "other", err: ErrNonceMismatch},
+		{name: "unknown key", algorithm: "RS256", keyID: "missing", err: ErrTokenInvalid},
+		{name: "ecdsa algorithm with rsa key", algorithm: "ES256", keyID: "rsa", err: ErrTokenInvalid},
+		{name: "rsa algorithm with ecdsa key", algorithm: "RS256", keyID: "ec", err: ErrTokenInvalid},
+		{name: "ecdsa algorithm with wrong curve", algorithm: "ES384", keyID: "ec", err: ErrTokenInvalid},
+		{name: "bad signature", algorithm: "RS256", keyID: "rsa", tamper: true, err: ErrTokenInvalid},
+		{name: "missing identity", algorithm: "RS256", keyID: "rsa", modify: func(claims map[string]any) { delete(claims, "sub") }, err: ErrIdentityMissing},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mock := newMockIssuer(t)
+
+			claims := mock.claims()
+			if test.modify != nil {
+				test.modify(claims)
+			}
+			mock.idToken = mock.sign(t, test.algorithm, test.keyID, claims)
+			if test.tamper {
+				claims["sub"] = "administrator"
+				segments := strings.Split(mock.idToken, ".")
+				segments[1] = encodeJSON(t, claims)
+				mock.idToken = strings.Join(segments, ".")
+			}
+
+			nonce := testNonce
+			if test.nonce != "" {
+				nonce = test.nonce
+			}
+
+			identity, errExchange := Exchange(context.Background(), "code", "verifier", nonce)
+			if test.err != nil {
+				if !errors.Is(errExchange, test.err) {
+					t.Fatalf("expected %v, got identity %q and error %v", test.err, identity, errExchange)
+				}
+				return
+			}
+			if errExchange != nil {
+				t.Fatal(errExchange)
+			}
+			if identity != "jdoe" {
+				t.Fatalf("expected identity %q, got %q", "jdoe", identity)
+			}
+		})
+	}
+}
+
+func TestExchangeRequiresVerifiedEmail(t *testing.T) {
+	mock := newMockIssuer(t)
+	identityClaim = "email"
+
+	claims := mock.claims()
+	claims["email"] = "jdoe@example.edu"
+	claims["email_verified"] = false
+	mock.idToken = mock.sign(t, "RS256", "rsa", claims)
+
+	_, errExchange := Exchange(context.Background(), "code", "verifier", testNonce)
+	if !errors.Is(errExchange, ErrEmailUnverified) {
+		t.Fatalf("expected %v, got %v", ErrEmailUnverified, errExchange)
+	}
+
+	claims["email_verified"] = true
+	mock.idToken = mock.sign(t, "RS256", "rsa", claims)
+
+	identity, errExchange := Exchange(context.Background(), "code", "verifier", testNonce)
+	if errExchange != nil {
+		t.Fatal(errExchange)
+	}
+	if identity != "jdoe@example.edu" {
+		t.Fatalf("expected identity %q, got %q", "jdoe@example.edu", identity)
+	}
+}
+
+func TestExchangeCachesKeys(t *testing.T) {
+	mock := newMockIssuer(t)
+
+	for range 3 {
+		mock.idToken = mock.sign(t, "RS256", "rsa", mock.claims())
+
+		_, errExchange := Exchange(context.Background(), "code", "verifier", testNonce)
+		if errExchange != nil {
+			t.Fatal(errExchange)
+		}
+	}
+
+	if mock.keyFetches != 1 {
+		t.Fatalf("expected one key set fetch, got %d", mock.keyFetches)
+	}
+}
@@ -0,0 +1,281 @@
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+)
+
+type jsonWebKey struct {
+	KeyType string `json:"kty"`
+	KeyID   string `json:"kid"`
+	Use     string `json:"use"`
+	N       string `json:"n"`
+	E       string `json:"e"`
+	Curve   string `json:"crv"`
+	X       string `json:"x"`
+	Y       string `json:"y"`
+}
+
+type tokenHeader struct {
+	Algorithm string `json:"alg"`
+	KeyID     string `json:"kid"`
+}
+
+var (
+	algorithmHashes map[string]crypto.Hash = map[string]crypto.Hash{
+		"RS256": crypto.SHA256,
+		"RS384": crypto.SHA384,
+		"RS512": crypto.SHA512,
+		"ES256": crypto.SHA256,
+		"ES384": crypto.SHA384,
+		"ES512": crypto.SHA512,
+	}
+	curves map[string]elliptic.Curve = map[string]elliptic.Curve{
+		"P-256": elliptic.P256(),
+		"P-384": elliptic.P384(),
+		"P-521": elliptic.P521(),
+	}
+	algorithmCurves map[string]elliptic.Curve = map[string]elliptic.Curve{
+		"ES256": elliptic.P256(),
+		"ES384": elliptic.P384(),
+		"ES512": elliptic.P521(),
+	}
+)
+
+func decodeSegment(segment string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(segment, "="))
+}
+
+func decodeBigInteger(segment string) (*big.Int, error) {
+	raw, errDecode := decodeSegment(segment)
+	if errDecode != nil {
+		return nil, errDecode
+	}
+
+	return new(big.Int).SetBytes(raw), nil
+}
+
+func (key *jsonWebKey) publicKey() (any, error) {
+	switch key.KeyType {
+	case "RSA":
+		modulus, errDecodeModulus := decodeBigInteger(key.N)
+		if errDecodeModulus != nil {
+			return nil, errDecodeModulus
+		}
+
+		exponent, errDecodeExponent := decodeBigInteger(key.E)
+		if errDecodeExponent != nil {
+			return nil, errDecodeExponent
+		}
+
+		return &rsa.PublicKey{N: modulus, E: int(exponent.Int64())}, nil
+	case "EC":
+		curve, exists := curves[key.Curve]
+		if !exists {
+			return nil, fmt.Errorf("unsupported curve %q", key.Curve)
+		}
+
+		x, errDecodeX := decodeBigInteger(key.X)
+		if errDecodeX != nil {
+			return nil, errDecodeX
+		}
+
+		y, errDecodeY := decodeBigInteger(key.Y)
+		if errDecodeY != nil {
+			return nil, errDecodeY
+		}
+
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", key.KeyType)
+	}
+}
+
+func fetchKeys(context context.Context, jwksURI string) (map[string]any, error) {
+	var keySet struct {
+		Keys []*jsonWebKey `json:"keys"`
+	}
+	errGet := getJSON(context, jwksURI, &keySet)
+	if errGet != nil {
+		return nil, errGet
+	}
+
+	fetched := make(map[string]any, len(keySet.Keys))
+	for _, key := range keySet.Keys {
+		if key.Use != "" && key.Use != "sig" {
+			continue
+		}
+
+		publicKey, errPublicKey := key.publicKey()
+		if errPublicKey != nil {
+			continue
+		}
+
+		fetched[key.KeyID] = publicKey
+	}
+
+	return fetched, nil
+}
+
+func lookupKey(context context.Context, jwksURI string, keyID string) (any, error) {
+	mutex.Lock()
+	key, exists := keys[keyID]
+	mutex.Unlock()
+	if exists {
+		return key, nil
+	}
+
+	fetched, errFetch := fetchKeys(context, jwksURI)
+	if errFetch != nil {
+		return nil, errFetch
+	}
+
+	mutex.Lock()
+	keys = fetched
+	mutex.Unlock()
+
+	key, exists = fetched[keyID]
+	if !exists {
+		return nil, fmt.Errorf("%w: unknown key %q", ErrTokenInvalid, keyID)
+	}
+
+	return key, nil
+}
+
+func verifySignature(algorithm string, key any, signingInput string, signature []byte) error {
+	hash, supported := algorithmHashes[algorithm]
+	if !supported {
+		return fmt.Errorf("%w: unsupported algorithm %q", ErrTokenInvalid, algorithm)
+	}
+
+	hasher := hash.New()
+	hasher.Write([]byte(signingInput))
+	digest := hasher.Sum(nil)
+
+	switch assertedKey := key.(type) {
+	case *rsa.PublicKey:
+		if !strings.HasPrefix(algorithm, "RS") {
+			return fmt.Errorf("%w: algorithm %q does not match key", ErrTokenInvalid, algorithm)
+		}
+
+		errVerify := rsa.VerifyPKCS1v15(assertedKey, hash, digest, signature)
+		if errVerify != nil {
+			return errors.Join(ErrTokenInvalid, errVerify)
+		}
+	case *ecdsa.PublicKey:
+		if algorithmCurves[algorithm] != assertedKey.Curve {
+			return fmt.Errorf("%w: algorithm %q does not match key", ErrTokenInvalid, algorithm)
+		}
+
+		size := (assertedKey.Curve.Params().BitSize + 7) / 8
+		if len(signature) != 2*size {
+			return fmt.Errorf("%w: malformed signature", ErrTokenInvalid)
+		}
+
+		r := new(big.Int).SetBytes(signature[:size])
+		s := new(big.Int).SetBytes(signature[size:])
+		if !ecdsa.Verify(assertedKey, digest, r, s) {
+			return fmt.Errorf("%w: signature mismatch", ErrTokenInvalid)
+		}
+	default:
+		return fmt.Errorf("%w: unsupported key", ErrTokenInvalid)
+	}
+
+	return nil
+}
+
+func audienceContains(audience any, expected string) bool {
+	switch assertedAudience := audience.(type) {
+	case string:
+		return assertedAudience == expected
+	case []any:
+		for _, member := range assertedAudience {
+			if member == expected {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func numericDate(value any) (time.Time, bool) {
+	seconds, valid := value.(float64)
+	if !valid {
+		return time.Time{}, false
+	}
+
+	return time.Unix(int64(seconds), 0), true
+}
+
+func verify(context context.Context, discovered *providerMetadata, rawIDToken string) (map[string]any, error) {
+	segments := strings.Split(rawIDToken, ".")
+	if len(segments) != 3 {
+		return nil, fmt.Errorf("%w: malformed token", ErrTokenInvalid)
+	}
+
+	rawHeader, errDecodeHeader := decodeSegment(segments[0])
+	if errDecodeHeader != nil {
+		return nil, errors.Join(ErrTokenInvalid, errDecodeHeader)
+	}
+
+	var header tokenHeader
+	errUnmarshalHeader := json.Unmarshal(rawHeader, &header)
+	if errUnmarshalHeader != nil {
+		return nil, errors.Join(ErrTokenInvalid, errUnmarshalHeader)
+	}
+
+	signature, errDecodeSignature := decodeSegment(segments[2])
+	if errDecodeSignature != nil {
+		return nil, errors.Join(ErrTokenInvalid, errDecodeSignature)
+	}
+
+	key, errLookupKey := lookupKey(context, discovered.JWKSURI, header.KeyID)
+	if errLookupKey != nil {
+		return nil, errLookupKey
+	}
+
+	errVerifySignature := verifySignature(header.Algorithm, key, segments[0]+"."+segments[1], signature)
+	if errVerifySignature != nil {
+		return nil, errVerifySignature
+	}
+
+	rawClaims, errDecodeClaims := decodeSegment(segments[1])
+	if errDecodeClaims != nil {
+		return nil, errors.Join(ErrTokenInvalid, errDecodeClaims)
+	}
+
+	claims := make(map[string]any)
+	errUnmarshalClaims := json.Unmarshal(rawClaims, &claims)
+	if errUnmarshalClaims != nil {
+		return nil, errors.Join(ErrTokenInvalid, errUnmarshalClaims)
+	}
+
+	if tokenIssuer, _ := claims["iss"].(string); strings.TrimSuffix(tokenIssuer, "/") != issuer {
+		return nil, fmt.Errorf("%w: issuer mismatch", ErrTokenInvalid)
+	}
+	if !audienceContains(claims["aud"], clientID) {
+		return nil, fmt.Errorf("%w: audience mismatch", ErrTokenInvalid)
+	}
+
+	now := time.Now()
+	expiresOn, valid := numericDate(claims["exp"])
+	if !valid || now.After(expiresOn.Add(clockSkew)) {
+		return nil, fmt.Errorf("%w: token expired", ErrTokenInvalid)
+	}
+	if notBefore, exists := numericDate(claims["nbf"]); exists && now.Add(clockSkew).Before(notBefore) {
+		return nil, fmt.Errorf("%w: token not yet valid", ErrTokenInvalid)
+	}
+
+	return claims, nil
+}
@@ -0,0 +1,34 @@
+package payloads
+
+import "github.com/sorucoder/samuel/internal/samuel"
+
+type ListCampuses struct {
+	Retired bool `form:"retired"`
+}
+
+type CreateCampus struct {
+	ID string `json:"id"`
+	SaveCampus
+}
+
+type SaveCampus struct {
+	Name    string  `json:"name"`
+	Address string  `json:"address"`
+	Unit    *string `json:"unit"`
+	City    string  `json:"city"`
+	State   string  `json:"state"`
+	ZIP     string  `json:"zip"`
+	Phone   string  `json:"phone"`
+}
+
+func (payload *SaveCampus) Content() *samuel.AddressContent {
+	return &samuel.AddressContent{
+		Name:    payload.Name,
+		Address: payload.Address,
+		Unit:    payload.Unit,
+		City:    payload.City,
+		State:   payload.State,
+		ZIP:     payload.ZIP,
+		Phone:   payload.Phone,
+	}
+}
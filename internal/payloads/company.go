@@ -0,0 +1,32 @@
+package payloads
+
+import "github.com/sorucoder/samuel/internal/samuel"
+
+type ListCompanies struct {
+	Page     int    `form:"page" binding:"min=0"`
+	Count    int    `form:"count" binding:"min=1"`
+	Search   string `form:"search"`
+	Archived bool   `form:"archived"`
+}
+
+type SaveCompany struct {
+	Name    string  `json:"name"`
+	Address string  `json:"address"`
+	Unit    *string `json:"unit"`
+	City    string  `json:"city"`
+	State   string  `json:"state"`
+	ZIP     string  `json:"zip"`
+	Phone   string  `json:"phone"`
+}
+
+func (payload *SaveCompany) Content() *samuel.AddressContent {
+	return &samuel.AddressContent{
+		Name:    payload.Name,
+		Address: payload.Address,
+		Unit:    payload.Unit,
+		City:    payload.City,
+		State:   payload.State,
+		ZIP:     payload.ZIP,
+		Phone:   payload.Phone,
+	}
+}
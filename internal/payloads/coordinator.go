@@ -0,0 +1,7 @@
+package payloads
+
+import "github.com/google/uuid"
+
+type AssignCoordinator struct {
+	InstructorUUID uuid.UUID `json:"instructorUUID" binding:"required"`
+}
@@ -0,0 +1,31 @@
+package payloads
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type Date struct {
+	time.Time
+}
+
+func (date *Date) UnmarshalJSON(data []byte) error {
+	var raw string
+	errUnmarshal := json.Unmarshal(data, &raw)
+	if errUnmarshal != nil {
+		return errUnmarshal
+	}
+
+	return date.UnmarshalParam(raw)
+}
+
+func (date *Date) UnmarshalParam(param string) error {
+	parsed, errParse := time.ParseInLocation(time.DateOnly, param, time.Local)
+	if errParse != nil {
+		return errParse
+	}
+
+	date.Time = parsed
+
+	return nil
+}
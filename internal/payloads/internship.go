@@ -0,0 +1,20 @@
+package payloads
+
+import "github.com/google/uuid"
+
+type CreateInternship struct {
+	StudentUUID    uuid.UUID `json:"studentUUID" binding:"required"`
+	InstructorUUID uuid.UUID `json:"instructorUUID" binding:"required"`
+	SupervisorUUID uuid.UUID `json:"supervisorUUID" binding:"required"`
+	StartOn        *Date     `json:"startOn" binding:"required"`
+	EndOn          *Date     `json:"endOn" binding:"required"`
+}
+
+type ListInternships struct {
+	Page  int `form:"page" binding:"min=0"`
+	Count int `form:"count" binding:"min=1"`
+}
+
+type ReassignInternship struct {
+	InstructorUUID uuid.UUID `json:"instructorUUID" binding:"required"`
+}
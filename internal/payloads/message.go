@@ -0,0 +1,10 @@
+package payloads
+
+type SendMessage struct {
+	Message string `json:"message" binding:"required"`
+}
+
+type ViewMessageLog struct {
+	Page  int `form:"page" binding:"min=0"`
+	Count int `form:"count" binding:"min=1"`
+}
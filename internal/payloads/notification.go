@@ -0,0 +1,14 @@
+package payloads
+
+import "github.com/google/uuid"
+
+type ListNotifications struct {
+	Page   int  `form:"page" binding:"min=0"`
+	Count  int  `form:"count" binding:"min=1"`
+	Unseen bool `form:"unseen"`
+}
+
+type MarkNotificationsSeen struct {
+	UUIDs []uuid.UUID `json:"uuids" binding:"required_without=All"`
+	All   bool        `json:"all"`
+}
@@ -0,0 +1,6 @@
+package payloads
+
+type CompleteOIDCLogin struct {
+	Code  string `form:"code" binding:"required"`
+	State string `form:"state" binding:"required"`
+}
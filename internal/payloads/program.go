@@ -0,0 +1,14 @@
+package payloads
+
+type ListPrograms struct {
+	Retired bool `form:"retired"`
+}
+
+type CreateProgram struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type UpdateProgram struct {
+	Name string `json:"name"`
+}
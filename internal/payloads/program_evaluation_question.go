@@ -0,0 +1,17 @@
+package payloads
+
+type ListProgramEvaluationQuestions struct {
+	Retired bool `form:"retired"`
+}
+
+type CreateProgramEvaluationQuestion struct {
+	Question string `json:"question" binding:"required"`
+}
+
+type UpdateProgramEvaluationQuestion struct {
+	Question string `json:"question" binding:"required"`
+}
+
+type ReorderProgramEvaluationQuestions struct {
+	Numbers []uint8 `json:"numbers" binding:"required"`
+}
@@ -0,0 +1,32 @@
+package payloads
+
+import "github.com/sorucoder/samuel/internal/samuel"
+
+type ListStudentEvaluations struct {
+	Page  int `form:"page" binding:"min=0"`
+	Count int `form:"count" binding:"min=1"`
+}
+
+type SubmitStudentEvaluation struct {
+	CompanyInformationResponse           *string `json:"companyInformationResponse"`
+	MajorResponsibilitiesResponse        *string `json:"majorResponsibilitiesResponse"`
+	AccomplishmentResponse               *string `json:"accomplishmentResponse"`
+	AcademicTrainingBenefitsResponse     *string `json:"academicTrainingBenefitsResponse"`
+	AcademicTrainingImprovementsResponse *string `json:"academicTrainingImprovementsResponse"`
+	SkillDevelopmentResponse             *string `json:"skillDevelopmentResponse"`
+	AttitudeChangeResponse               *string `json:"attitudeChangeResponse"`
+	CommentsResponse                     *string `json:"commentsResponse"`
+}
+
+func (payload *SubmitStudentEvaluation) Content() *samuel.StudentEvaluationContent {
+	return &samuel.StudentEvaluationContent{
+		CompanyInformationResponse:           payload.CompanyInformationResponse,
+		MajorResponsibilitiesResponse:        payload.MajorResponsibilitiesResponse,
+		AccomplishmentResponse:               payload.AccomplishmentResponse,
+		AcademicTrainingBenefitsResponse:     payload.AcademicTrainingBenefitsResponse,
+		AcademicTrainingImprovementsResponse: payload.AcademicTrainingImprovementsResponse,
+		SkillDevelopmentResponse:             payload.SkillDevelopmentResponse,
+		AttitudeChangeResponse:               payload.AttitudeChangeResponse,
+		CommentsResponse:                     payload.CommentsResponse,
+	}
+}
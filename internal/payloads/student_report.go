@@ -0,0 +1,34 @@
+package payloads
+
+import "github.com/sorucoder/samuel/internal/samuel"
+
+type ListStudentReports struct {
+	Page  int `form:"page" binding:"min=0"`
+	Count int `form:"count" binding:"min=1"`
+}
+
+type SubmitStudentReport struct {
+	MajorObjectivesResponse           *string `json:"majorObjectivesResponse"`
+	AdditionalAccomplishmentsResponse *string `json:"additionalAccomplishmentsResponse"`
+	UnassignedTasksResponse           *string `json:"unassignedTasksResponse"`
+	WellHandledActivityResponse       *string `json:"wellHandledActivityResponse"`
+	HelpfulnessAndIssuesResponse      *string `json:"helpfulnessAndIssuesResponse"`
+	ProblemSolvingResponse            *string `json:"problemSolvingResponse"`
+	LearningResponse                  *string `json:"learningResponse"`
+}
+
+func (payload *SubmitStudentReport) Content() *samuel.StudentReportContent {
+	return &samuel.StudentReportContent{
+		MajorObjectivesResponse:           payload.MajorObjectivesResponse,
+		AdditionalAccomplishmentsResponse: payload.AdditionalAccomplishmentsResponse,
+		UnassignedTasksResponse:           payload.UnassignedTasksResponse,
+		WellHandledActivityResponse:       payload.WellHandledActivityResponse,
+		HelpfulnessAndIssuesResponse:      payload.HelpfulnessAndIssuesResponse,
+		ProblemSolvingResponse:            payload.ProblemSolvingResponse,
+		LearningResponse:                  payload.LearningResponse,
+	}
+}
+
+type CommentStudentReport struct {
+	Comment string `json:"comment" binding:"required"`
+}
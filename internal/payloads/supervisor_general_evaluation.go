@@ -0,0 +1,45 @@
+package payloads
+
+import "github.com/sorucoder/samuel/internal/samuel"
+
+type SubmitSupervisorGeneralEvaluation struct {
+	KnowledgeRating             *string           `json:"knowledgeRating"`
+	QualityRating               *string           `json:"qualityRating"`
+	EfficiencyRating            *string           `json:"efficiencyRating"`
+	CommunicationRating         *string           `json:"communicationRating"`
+	AptitudeRating              *string           `json:"aptitudeRating"`
+	InitiativeRating            *string           `json:"initiativeRating"`
+	AttitudeRating              *string           `json:"attitudeRating"`
+	AttendanceRating            *string           `json:"attendanceRating"`
+	ProfessionalismRating       *string           `json:"professionalismRating"`
+	OverallRating               *string           `json:"overallRating"`
+	StrengthsResponse           *string           `json:"strengthsResponse"`
+	WeaknessesResponse          *string           `json:"weaknessesResponse"`
+	AcademicSuggestionsResponse *string           `json:"academicSuggestionsResponse"`
+	ValueResponse               *string           `json:"valueResponse"`
+	RecommendsEmployment        *bool             `json:"recommendsEmployment" binding:"required"`
+	RecommendationResponse      *string           `json:"recommendationResponse"`
+	ProgramResponses            map[uint8]*string `json:"programResponses"`
+}
+
+func (payload *SubmitSupervisorGeneralEvaluation) Content() *samuel.SupervisorGeneralEvaluationContent {
+	return &samuel.SupervisorGeneralEvaluationContent{
+		KnowledgeRating:             (*samuel.Rating)(payload.KnowledgeRating),
+		QualityRating:               (*samuel.Rating)(payload.QualityRating),
+		EfficiencyRating:            (*samuel.Rating)(payload.EfficiencyRating),
+		CommunicationRating:         (*samuel.Rating)(payload.CommunicationRating),
+		AptitudeRating:              (*samuel.Rating)(payload.AptitudeRating),
+		InitiativeRating:            (*samuel.Rating)(payload.InitiativeRating),
+		AttitudeRating:              (*samuel.Rating)(payload.AttitudeRating),
+		AttendanceRating:            (*samuel.Rating)(payload.AttendanceRating),
+		ProfessionalismRating:       (*samuel.Rating)(payload.ProfessionalismRating),
+		OverallRating:               (*samuel.Rating)(payload.OverallRating),
+		StrengthsResponse:           payload.StrengthsResponse,
+		WeaknessesResponse:          payload.WeaknessesResponse,
+		AcademicSuggestionsResponse: payload.AcademicSuggestionsResponse,
+		ValueResponse:               payload.ValueResponse,
+		RecommendsEmployment:        *payload.RecommendsEmployment,
+		RecommendationResponse:      payload.RecommendationResponse,
+		ProgramResponses:            payload.ProgramResponses,
+	}
+}
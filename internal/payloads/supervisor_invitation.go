@@ -0,0 +1,11 @@
+package payloads
+
+type ListSupervisorInvitations struct {
+	Page   int    `form:"page" binding:"min=0"`
+	Count  int    `form:"count" binding:"min=1"`
+	Status string `form:"status" binding:"omitempty,oneof=pending expired accepted"`
+}
+
+type AcceptSupervisorInvitation struct {
+	NewPassword string `json:"newPassword" binding:"required"`
+}
@@ -0,0 +1,76 @@
+package payloads
+
+import "github.com/sorucoder/samuel/internal/samuel"
+
+type ListSupervisorReports struct {
+	Page  int `form:"page" binding:"min=0"`
+	Count int `form:"count" binding:"min=1"`
+}
+
+type SubmitSupervisorReport struct {
+	KnowledgeRating         *string `json:"knowledgeRating"`
+	KnowledgeResponse       *string `json:"knowledgeResponse"`
+	QualityRating           *string `json:"qualityRating"`
+	PrioritizationRating    *string `json:"prioritizationRating"`
+	QualityResponse         *string `json:"qualityResponse"`
+	EfficiencyRating        *string `json:"efficiencyRating"`
+	MultitaskingRating      *string `json:"multitaskingRating"`
+	EfficiencyResponse      *string `json:"efficiencyResponse"`
+	CommunicationRating     *string `json:"communicationRating"`
+	ListeningRating         *string `json:"listeningRating"`
+	CommunicationResponse   *string `json:"communicationResponse"`
+	AptitudeRating          *string `json:"aptitudeRating"`
+	InquisitivenessRating   *string `json:"inquisitivenessRating"`
+	AptitudeResponse        *string `json:"aptitudeResponse"`
+	InitiativeRating        *string `json:"initiativeRating"`
+	InitiativeResponse      *string `json:"initiativeResponse"`
+	CooperationRating       *string `json:"cooperationRating"`
+	AttitudeRating          *string `json:"attitudeRating"`
+	CooperationResponse     *string `json:"cooperationResponse"`
+	AttendanceRating        *string `json:"attendanceRating"`
+	NotificationRating      *string `json:"notificationRating"`
+	AttendanceResponse      *string `json:"attendanceResponse"`
+	ProfessionalismRating   *string `json:"professionalismRating"`
+	AppearanceRating        *string `json:"appearanceRating"`
+	ProfessionalismResponse *string `json:"professionalismResponse"`
+	OverallRating           *string `json:"overallRating"`
+	OverallResponse         *string `json:"overallResponse"`
+	AccomplishmentResponse  *string `json:"accomplishmentResponse"`
+	RequestsPhoneCall       bool    `json:"requestsPhoneCall"`
+	VisibleToStudent        bool    `json:"visibleToStudent"`
+}
+
+func (payload *SubmitSupervisorReport) Content() *samuel.SupervisorReportContent {
+	return &samuel.SupervisorReportContent{
+		KnowledgeRating:         (*samuel.Rating)(payload.KnowledgeRating),
+		KnowledgeResponse:       payload.KnowledgeResponse,
+		QualityRating:           (*samuel.Rating)(payload.QualityRating),
+		PrioritizationRating:    (*samuel.Rating)(payload.PrioritizationRating),
+		QualityResponse:         payload.QualityResponse,
+		EfficiencyRating:        (*samuel.Rating)(payload.EfficiencyRating),
+		MultitaskingRating:      (*samuel.Rating)(payload.MultitaskingRating),
+		EfficiencyResponse:      payload.EfficiencyResponse,
+		CommunicationRating:     (*samuel.Rating)(payload.CommunicationRating),
+		ListeningRating:         (*samuel.Rating)(payload.ListeningRating),
+		CommunicationResponse:   payload.CommunicationResponse,
+		AptitudeRating:          (*samuel.Rating)(payload.AptitudeRating),
+		InquisitivenessRating:   (*samuel.Rating)(payload.InquisitivenessRating),
+		AptitudeResponse:        payload.AptitudeResponse,
+		InitiativeRating:        (*samuel.Rating)(payload.InitiativeRating),
+		InitiativeResponse:      payload.InitiativeResponse,
+		CooperationRating:       (*samuel.Rating)(payload.CooperationRating),
+		AttitudeRating:          (*samuel.Rating)(payload.AttitudeRating),
+		CooperationResponse:     payload.CooperationResponse,
+		AttendanceRating:        (*samuel.Rating)(payload.AttendanceRating),
+		NotificationRating:      (*samuel.Rating)(payload.NotificationRating),
+		AttendanceResponse:      payload.AttendanceResponse,
+		ProfessionalismRating:   (*samuel.Rating)(payload.ProfessionalismRating),
+		AppearanceRating:        (*samuel.Rating)(payload.AppearanceRating),
+		ProfessionalismResponse: payload.ProfessionalismResponse,
+		OverallRating:           (*samuel.Rating)(payload.OverallRating),
+		OverallResponse:         payload.OverallResponse,
+		AccomplishmentResponse:  payload.AccomplishmentResponse,
+		RequestsPhoneCall:       payload.RequestsPhoneCall,
+		VisibleToStudent:        payload.VisibleToStudent,
+	}
+}
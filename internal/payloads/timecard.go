@@ -0,0 +1,32 @@
+package payloads
+
+type ListTimecards struct {
+	Page  int `form:"page" binding:"min=0"`
+	Count int `form:"count" binding:"min=1"`
+}
+
+type DraftTimecard struct {
+	SundayHours    float64 `json:"sundayHours"`
+	MondayHours    float64 `json:"mondayHours"`
+	TuesdayHours   float64 `json:"tuesdayHours"`
+	WednesdayHours float64 `json:"wednesdayHours"`
+	ThursdayHours  float64 `json:"thursdayHours"`
+	FridayHours    float64 `json:"fridayHours"`
+	SaturdayHours  float64 `json:"saturdayHours"`
+}
+
+func (payload *DraftTimecard) Hours() [7]float64 {
+	return [7]float64{
+		payload.SundayHours,
+		payload.MondayHours,
+		payload.TuesdayHours,
+		payload.WednesdayHours,
+		payload.ThursdayHours,
+		payload.FridayHours,
+		payload.SaturdayHours,
+	}
+}
+
+type DenyTimecard struct {
+	Reason string `json:"reason" binding:"required"`
+}
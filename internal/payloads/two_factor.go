@@ -0,0 +1,9 @@
+package payloads
+
+type VerifyTwoFactorCode struct {
+	Code string `json:"code" binding:"required"`
+}
+
+type RequireCompanyTwoFactor struct {
+	Required bool `json:"required"`
+}
@@ -0,0 +1,47 @@
+package payloads
+
+import (
+	"github.com/google/uuid"
+	"github.com/sorucoder/samuel/internal/samuel"
+)
+
+type ProvisionUser struct {
+	Identity    string    `json:"identity"`
+	Provider    string    `json:"provider"`
+	RoleID      string    `json:"roleID" binding:"required"`
+	FirstName   string    `json:"firstName"`
+	LastName    string    `json:"lastName"`
+	Email       string    `json:"email"`
+	Phone       string    `json:"phone"`
+	Title       string    `json:"title"`
+	CompanyUUID uuid.UUID `json:"companyUUID"`
+	CampusID    string    `json:"campusID"`
+	ProgramID   string    `json:"programID"`
+	Address     string    `json:"address"`
+	Unit        *string   `json:"unit"`
+	City        string    `json:"city"`
+	State       string    `json:"state"`
+	ZIP         string    `json:"zip"`
+}
+
+func (payload *ProvisionUser) Content() *samuel.UserProfileContent {
+	return &samuel.UserProfileContent{
+		FirstName:   payload.FirstName,
+		LastName:    payload.LastName,
+		Email:       payload.Email,
+		Phone:       payload.Phone,
+		Title:       payload.Title,
+		CompanyUUID: payload.CompanyUUID,
+		CampusID:    payload.CampusID,
+		ProgramID:   payload.ProgramID,
+		Address:     payload.Address,
+		Unit:        payload.Unit,
+		City:        payload.City,
+		State:       payload.State,
+		ZIP:         payload.ZIP,
+	}
+}
+
+type ImportStudents struct {
+	Commit bool `form:"commit"`
+}
@@ -0,0 +1,70 @@
+package samuel
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+)
+
+const (
+	addressNameMaximumLength int = 64
+	addressCityMaximumLength int = 64
+)
+
+var (
+	addressStates []string = []string{
+		"AL", "AK", "AZ", "AR", "CA", "CO", "CT", "DE", "FL", "GA",
+		"HI", "ID", "IL", "IN", "IA", "KS", "KY", "LA", "ME", "MD",
+		"MA", "MI", "MN", "MS", "MO", "MT", "NE", "NV", "NH", "NJ",
+		"NM", "NY", "NC", "ND", "OH", "OK", "OR", "PA", "RI", "SC",
+		"SD", "TN", "TX", "UT", "VT", "VA", "WA", "WV", "WI", "WY",
+	}
+	addressZIPPattern   *regexp.Regexp = regexp.MustCompile(`^[0-9]{5}(?:-[0-9]{4})?$`)
+	addressPhonePattern *regexp.Regexp = regexp.MustCompile(`^[0-9]{10}$`)
+)
+
+type AddressContent struct {
+	Name    string
+	Address string
+	Unit    *string
+	City    string
+	State   string
+	ZIP     string
+	Phone   string
+}
+
+func (content *AddressContent) normalize() {
+	content.Name = strings.TrimSpace(content.Name)
+	content.Address = strings.TrimSpace(content.Address)
+	if content.Unit != nil {
+		unit := strings.TrimSpace(*content.Unit)
+		if unit == "" {
+			content.Unit = nil
+		} else {
+			content.Unit = &unit
+		}
+	}
+	content.City = strings.TrimSpace(content.City)
+	content.State = strings.ToUpper(strings.TrimSpace(content.State))
+	content.ZIP = strings.TrimSpace(content.ZIP)
+	content.Phone = strings.TrimSpace(content.Phone)
+}
+
+func (content *AddressContent) validate(validation *ValidationError) {
+	content.normalize()
+
+	validateRequiredText(validation, "name", content.Name, addressNameMaximumLength)
+	if content.Address == "" {
+		validation.add("address", "must not be empty")
+	}
+	validateRequiredText(validation, "city", content.City, addressCityMaximumLength)
+	if !slices.Contains(addressStates, content.State) {
+		validation.add("state", "must be a two letter state abbreviation")
+	}
+	if !addressZIPPattern.MatchString(content.ZIP) {
+		validation.add("zip", "must be a five or nine digit zip code")
+	}
+	if !addressPhonePattern.MatchString(content.Phone) {
+		validation.add("phone", "must be ten digits")
+	}
+}
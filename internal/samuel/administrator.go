@@ -17,6 +17,15 @@ type administratorModel struct {
 	Phone     string    `db:"phone"`
 }
 
+func insertAdministratorModel(context context.Context, transaction *database.Transaction, administratorUserUUID uuid.UUID, administratorProfile *UserProfileContent) (*administratorModel, error) {
+	_, errInsert := transaction.Execute(context, "INSERT INTO `administrators` (`user_uuid`, `first_name`, `last_name`, `email`, `phone`) VALUE (?, ?, ?, ?, ?)", administratorUserUUID, administratorProfile.FirstName, administratorProfile.LastName, administratorProfile.Email, administratorProfile.Phone)
+	if errInsert != nil {
+		return nil, errInsert
+	}
+
+	return getAdministratorModelByUserUUID(context, transaction, administratorUserUUID)
+}
+
 func getAdministratorModelByUserUUID(context context.Context, transaction *database.Transaction, administratorUserUUID uuid.UUID) (*administratorModel, error) {
 	model := new(administratorModel)
 
@@ -58,7 +67,7 @@ func GetAdministratorByUser(context context.Context, user *User) (*Administrator
 	if !user.valid {
 		panic(ErrUserInvalid)
 	}
-	if !user.model.is("administrator") {
+	if !user.holds("administrator") {
 		panic(ErrUserNotAdministrator)
 	}
 
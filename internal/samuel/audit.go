@@ -11,14 +11,16 @@ import (
 	"github.com/sorucoder/samuel/internal/database"
 )
 
+const detachedAuditTimeout time.Duration = 30 * time.Second
+
 type auditModel struct {
-	ID          uint64    `db:"id"`
-	Description string    `db:"description"`
-	UserUUID    uuid.UUID `db:"user_uuid"`
-	Timestamp   time.Time `db:"timestamp"`
+	ID          uint64        `db:"id"`
+	Description string        `db:"description"`
+	UserUUID    uuid.NullUUID `db:"user_uuid"`
+	Timestamp   time.Time     `db:"timestamp"`
 }
 
-func insertAuditModel(context context.Context, transaction *database.Transaction, auditDescription string, auditUserUUID uuid.UUID) (*auditModel, error) {
+func insertAuditModel(context context.Context, transaction *database.Transaction, auditDescription string, auditUserUUID uuid.NullUUID) (*auditModel, error) {
 	result, errInsert := transaction.Execute(context, "INSERT INTO `audit` (`description`, `user_uuid`) VALUE (?, ?)", auditDescription, auditUserUUID)
 	if errInsert != nil {
 		return nil, errInsert
@@ -88,7 +90,7 @@ var (
 )
 
 func recordAudit(context context.Context, transaction *database.Transaction, auditDescription string, actor *User) error {
-	_, errInsertModel := insertAuditModel(context, transaction, auditDescription, actor.model.UUID)
+	_, errInsertModel := insertAuditModel(context, transaction, auditDescription, uuid.NullUUID{UUID: actor.model.UUID, Valid: true})
 	if errInsertModel != nil {
 		return errInsertModel
 	}
@@ -96,6 +98,49 @@ func recordAudit(context context.Context, transaction *database.Transaction, aud
 	return nil
 }
 
+func recordAnonymousAudit(context context.Context, transaction *database.Transaction, auditDescription string) error {
+	_, errInsertModel := insertAuditModel(context, transaction, auditDescription, uuid.NullUUID{})
+	if errInsertModel != nil {
+		return errInsertModel
+	}
+
+	return nil
+}
+
+// recordDetachedAudit records an audit in a transaction of its own, for work
+// that finishes after the request that started it has returned.
+func recordDetachedAudit(auditDescription string, actor *User) error {
+	context, cancel := context.WithTimeout(context.Background(), detachedAuditTimeout)
+	defer cancel()
+
+	errPing := database.Ping(context)
+	if errPing != nil {
+		return errPing
+	}
+
+	transaction, errBegin := database.Begin(context)
+	if errBegin != nil {
+		return errBegin
+	}
+
+	var errRecord error
+	if actor != nil {
+		errRecord = recordAudit(context, transaction, auditDescription, actor)
+	} else {
+		errRecord = recordAnonymousAudit(context, transaction, auditDescription)
+	}
+	if errRecord != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return errors.Join(errRecord, errRollback)
+		}
+
+		return errRecord
+	}
+
+	return transaction.Commit()
+}
+
 func getAuditBatchByDate(context context.Context, transaction *database.Transaction, auditDate time.Time, page int, count int, sort string, descending bool) (*Batch[*Audit], error) {
 	audits := make([]*Audit, 0, count)
 
@@ -0,0 +1,84 @@
+package samuel
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/sorucoder/samuel/internal/ldap"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type Credentials struct {
+	Identity     string
+	Password     string
+	PasswordHash []byte
+}
+
+type Authenticator interface {
+	Authenticate(context context.Context, credentials *Credentials) error
+	UsesPasswordHash() bool
+}
+
+type ldapAuthenticator struct{}
+
+func (ldapAuthenticator) Authenticate(context context.Context, credentials *Credentials) error {
+	return ldap.Authenticate(context, credentials.Identity, credentials.Password)
+}
+
+func (ldapAuthenticator) UsesPasswordHash() bool {
+	return false
+}
+
+type passwordAuthenticator struct{}
+
+func (passwordAuthenticator) Authenticate(context context.Context, credentials *Credentials) error {
+	return bcrypt.CompareHashAndPassword(credentials.PasswordHash, []byte(credentials.Password))
+}
+
+func (passwordAuthenticator) UsesPasswordHash() bool {
+	return true
+}
+
+type externalAuthenticator struct{}
+
+func (externalAuthenticator) Authenticate(context context.Context, credentials *Credentials) error {
+	return ErrAuthenticatorExternal
+}
+
+func (externalAuthenticator) UsesPasswordHash() bool {
+	return false
+}
+
+var (
+	authenticators map[string]Authenticator = map[string]Authenticator{
+		"ldap":     ldapAuthenticator{},
+		"password": passwordAuthenticator{},
+		"oidc":     externalAuthenticator{},
+	}
+	authenticatorsMutex sync.RWMutex
+)
+
+var (
+	ErrAuthenticatorUnknown  error = errors.New("authenticator unknown")
+	ErrAuthenticatorExternal error = errors.New("authenticator external")
+)
+
+func RegisterAuthenticator(provider string, authenticator Authenticator) {
+	authenticatorsMutex.Lock()
+	defer authenticatorsMutex.Unlock()
+
+	authenticators[provider] = authenticator
+}
+
+func getAuthenticator(provider string) (Authenticator, error) {
+	authenticatorsMutex.RLock()
+	defer authenticatorsMutex.RUnlock()
+
+	authenticator, exists := authenticators[provider]
+	if !exists {
+		return nil, ErrAuthenticatorUnknown
+	}
+
+	return authenticator, nil
+}
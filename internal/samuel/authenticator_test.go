@@ -0,0 +1,107 @@
+package samuel
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+var errFakeCredentials error = errors.New("fake credentials invalid")
+
+type fakeAuthenticator struct {
+	passwords        map[string]string
+	usesPasswordHash bool
+	calls            int
+}
+
+func (authenticator *fakeAuthenticator) Authenticate(context context.Context, credentials *Credentials) error {
+	authenticator.calls++
+	if password, exists := authenticator.passwords[credentials.Identity]; !exists || password != credentials.Password {
+		return errFakeCredentials
+	}
+
+	return nil
+}
+
+func (authenticator *fakeAuthenticator) UsesPasswordHash() bool {
+	return authenticator.usesPasswordHash
+}
+
+func newFakeUser(provider string, roleIDs ...string) *User {
+	user := &User{
+		model: &userModel{Identity: "jdoe", Provider: provider},
+		valid: true,
+	}
+	for _, roleID := range roleIDs {
+		user.roles = append(user.roles, &Role{model: &roleModel{ID: roleID}, valid: true})
+	}
+	if len(user.roles) > 0 {
+		user.role = user.roles[0]
+	}
+
+	return user
+}
+
+func TestUserAuthenticatesWithRegisteredProvider(t *testing.T) {
+	authenticator := &fakeAuthenticator{passwords: map[string]string{"jdoe": "correct horse"}}
+	RegisterAuthenticator("fake", authenticator)
+
+	user := newFakeUser("fake", "student")
+
+	errAuthenticate := user.authenticate(context.Background(), "correct horse")
+	if errAuthenticate != nil {
+		t.Fatalf("expected success, got %v", errAuthenticate)
+	}
+
+	errAuthenticate = user.authenticate(context.Background(), "battery staple")
+	if !errors.Is(errAuthenticate, errFakeCredentials) {
+		t.Fatalf("expected %v, got %v", errFakeCredentials, errAuthenticate)
+	}
+
+	if authenticator.calls != 2 {
+		t.Fatalf("expected the fake provider to be called twice, got %d", authenticator.calls)
+	}
+}
+
+func TestUserWithUnknownProviderCannotAuthenticate(t *testing.T) {
+	user := newFakeUser("unregistered", "student")
+
+	errAuthenticate := user.authenticate(context.Background(), "anything")
+	if !errors.Is(errAuthenticate, ErrAuthenticatorUnknown) {
+		t.Fatalf("expected %v, got %v", ErrAuthenticatorUnknown, errAuthenticate)
+	}
+	if user.usesPasswordHash() {
+		t.Fatal("expected an unknown provider not to use a password hash")
+	}
+}
+
+func TestPasswordHashHandlingFollowsProvider(t *testing.T) {
+	RegisterAuthenticator("fake-hashed", &fakeAuthenticator{usesPasswordHash: true})
+	RegisterAuthenticator("fake-external", &fakeAuthenticator{})
+
+	tests := []struct {
+		provider string
+		expected bool
+	}{
+		{provider: "password", expected: true},
+		{provider: "ldap", expected: false},
+		{provider: "oidc", expected: false},
+		{provider: "fake-hashed", expected: true},
+		{provider: "fake-external", expected: false},
+	}
+
+	for _, test := range tests {
+		if usesPasswordHash := newFakeUser(test.provider, "supervisor").usesPasswordHash(); usesPasswordHash != test.expected {
+			t.Errorf("provider %q: expected usesPasswordHash %v, got %v", test.provider, test.expected, usesPasswordHash)
+		}
+	}
+}
+
+func TestSecondFactorSkipsProvidersWithoutPasswordHash(t *testing.T) {
+	RegisterAuthenticator("fake-external", &fakeAuthenticator{})
+
+	challenge, errChallenge := newFakeUser("fake-external", "supervisor").challengeSecondFactor(context.Background(), nil)
+	if errChallenge != nil || challenge != nil {
+		t.Fatalf("expected no challenge, got %v and %v", challenge, errChallenge)
+	}
+}
@@ -5,19 +5,36 @@ import (
 	"database/sql"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"regexp"
+	"strings"
 
 	"github.com/sorucoder/samuel/internal/database"
 )
 
+var (
+	campusIDPattern *regexp.Regexp = regexp.MustCompile(`^[a-z]{3}$`)
+)
+
 type campusModel struct {
-	ID      string         `db:"id"`
-	Name    string         `db:"name"`
-	Address string         `db:"address"`
-	Unit    sql.NullString `db:"unit"`
-	City    string         `db:"city"`
-	State   string         `db:"state"`
-	ZIP     string         `db:"zip"`
-	Phone   string         `db:"phone"`
+	ID        string         `db:"id"`
+	Name      string         `db:"name"`
+	Address   string         `db:"address"`
+	Unit      sql.NullString `db:"unit"`
+	City      string         `db:"city"`
+	State     string         `db:"state"`
+	ZIP       string         `db:"zip"`
+	Phone     string         `db:"phone"`
+	RetiredOn sql.NullTime   `db:"retired_on"`
+}
+
+func insertCampusModel(context context.Context, transaction *database.Transaction, campusID string, campusContent *AddressContent) (*campusModel, error) {
+	_, errInsert := transaction.Execute(context, "INSERT INTO `campuses` (`id`, `name`, `address`, `unit`, `city`, `state`, `zip`, `phone`) VALUE (?, ?, ?, ?, ?, ?, ?, ?)", campusID, campusContent.Name, campusContent.Address, campusContent.Unit, campusContent.City, campusContent.State, campusContent.ZIP, campusContent.Phone)
+	if errInsert != nil {
+		return nil, errInsert
+	}
+
+	return getCampusModelByID(context, transaction, campusID)
 }
 
 func getCampusModelByID(context context.Context, transaction *database.Transaction, campusID string) (*campusModel, error) {
@@ -31,6 +48,67 @@ func getCampusModelByID(context context.Context, transaction *database.Transacti
 	return model, nil
 }
 
+func countCampusModelsByID(context context.Context, transaction *database.Transaction, campusID string) (int64, error) {
+	var count int64
+
+	errGet := transaction.Get(context, &count, "SELECT COUNT(*) FROM `campuses` WHERE `id` = ?", campusID)
+	if errGet != nil {
+		return 0, errGet
+	}
+
+	return count, nil
+}
+
+func selectCampusModels(context context.Context, transaction *database.Transaction, includeRetired bool) ([]*campusModel, error) {
+	var queryBuilder strings.Builder
+	queryBuilder.WriteString("SELECT * FROM `campuses`")
+	if !includeRetired {
+		queryBuilder.WriteString(" WHERE `retired_on` IS NULL")
+	}
+	queryBuilder.WriteString(" ORDER BY `name`")
+
+	models := make([]*campusModel, 0)
+
+	errSelect := transaction.Select(context, &models, queryBuilder.String())
+	if errSelect != nil {
+		return nil, errSelect
+	}
+
+	return models, nil
+}
+
+func (model *campusModel) retired() bool {
+	return model.RetiredOn.Valid
+}
+
+func (model *campusModel) update(context context.Context, transaction *database.Transaction, newCampusContent *AddressContent) error {
+	_, errUpdate := transaction.Execute(context, "UPDATE `campuses` SET `name` = ?, `address` = ?, `unit` = ?, `city` = ?, `state` = ?, `zip` = ?, `phone` = ? WHERE `id` = ?", newCampusContent.Name, newCampusContent.Address, newCampusContent.Unit, newCampusContent.City, newCampusContent.State, newCampusContent.ZIP, newCampusContent.Phone, model.ID)
+	if errUpdate != nil {
+		return errUpdate
+	}
+
+	errGet := transaction.Get(context, model, "SELECT * FROM `campuses` WHERE `id` = ?", model.ID)
+	if errGet != nil {
+		return errGet
+	}
+
+	return nil
+}
+
+func (model *campusModel) updateRetiredOn(context context.Context, transaction *database.Transaction) error {
+	_, errUpdate := transaction.Execute(context, "UPDATE `campuses` SET `retired_on` = NOW() WHERE `id` = ?", model.ID)
+	if errUpdate != nil {
+		return errUpdate
+	}
+
+	errGetRetiredOn := transaction.Get(context, &model.RetiredOn, "SELECT `retired_on` FROM `campuses` WHERE `id` = ?", model.ID)
+	if errGetRetiredOn != nil {
+		return errGetRetiredOn
+	}
+
+	return nil
+}
+
 type Campus struct {
 	model *campusModel
 	valid bool
@@ -38,8 +116,57 @@ type Campus struct {
 
 var (
 	ErrCampusInvalid error = errors.New("campus invalid")
+	ErrCampusExists  error = errors.New("campus exists")
+	ErrCampusRetired error = errors.New("campus retired")
 )
 
+func newCampus(context context.Context, transaction *database.Transaction, campusID string, content *AddressContent) (*Campus, error) {
+	campusID = strings.TrimSpace(campusID)
+
+	validation := newValidationError()
+	if !campusIDPattern.MatchString(campusID) {
+		validation.add("id", "must be three lowercase letters")
+	}
+	content.validate(validation)
+	if !validation.empty() {
+		return nil, validation
+	}
+
+	campusCount, errCountModels := countCampusModelsByID(context, transaction, campusID)
+	if errCountModels != nil {
+		return nil, errCountModels
+	}
+	if campusCount > 0 {
+		return nil, ErrCampusExists
+	}
+
+	campus := new(Campus)
+
+	var errInsertModel error
+	campus.model, errInsertModel = insertCampusModel(context, transaction, campusID, content)
+	if errInsertModel != nil {
+		return nil, errInsertModel
+	}
+
+	campus.valid = true
+
+	return campus, nil
+}
+
+func getCampusByID(context context.Context, transaction *database.Transaction, campusID string) (*Campus, error) {
+	campus := new(Campus)
+
+	var errGetModel error
+	campus.model, errGetModel = getCampusModelByID(context, transaction, campusID)
+	if errGetModel != nil {
+		return nil, errGetModel
+	}
+
+	campus.valid = true
+
+	return campus, nil
+}
+
 func getCampusByInstructor(context context.Context, transaction *database.Transaction, instructor *Instructor) (*Campus, error) {
 	campus := new(Campus)
 
@@ -68,12 +195,257 @@ func getCampusByStudent(context context.Context, transaction *database.Transacti
 	return campus, nil
 }
 
+func (campus *Campus) update(context context.Context, transaction *database.Transaction, content *AddressContent) error {
+	if campus.model.retired() {
+		return ErrCampusRetired
+	}
+
+	validation := newValidationError()
+	content.validate(validation)
+	if !validation.empty() {
+		return validation
+	}
+
+	errUpdateModel := campus.model.update(context, transaction, content)
+	if errUpdateModel != nil {
+		return errUpdateModel
+	}
+
+	return nil
+}
+
+func (campus *Campus) retire(context context.Context, transaction *database.Transaction) error {
+	if campus.model.retired() {
+		return ErrCampusRetired
+	}
+
+	activeStudentCount, errCountActiveStudents := countActiveStudentModelsByCampusID(context, transaction, campus.model.ID)
+	if errCountActiveStudents != nil {
+		return errCountActiveStudents
+	}
+
+	openInternshipCount, errCountOpenInternships := countOpenInternshipModelsByCampusID(context, transaction, campus.model.ID)
+	if errCountOpenInternships != nil {
+		return errCountOpenInternships
+	}
+
+	references := newReferenceError("campus")
+	references.add("active students", activeStudentCount)
+	references.add("open internships", openInternshipCount)
+	if !references.empty() {
+		return references
+	}
+
+	errUpdateModel := campus.model.updateRetiredOn(context, transaction)
+	if errUpdateModel != nil {
+		return errUpdateModel
+	}
+
+	return nil
+}
+
+func GetCampuses(context context.Context, includeRetired bool) ([]*Campus, error) {
+	errPing := database.Ping(context)
+	if errPing != nil {
+		return nil, errPing
+	}
+
+	transaction, errBegin := database.Begin(context)
+	if errBegin != nil {
+		return nil, errBegin
+	}
+
+	models, errSelectModels := selectCampusModels(context, transaction, includeRetired)
+	if errSelectModels != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errSelectModels, errRollback)
+		}
+
+		return nil, errSelectModels
+	}
+
+	errCommit := transaction.Commit()
+	if errCommit != nil {
+		return nil, errCommit
+	}
+
+	campuses := make([]*Campus, 0, len(models))
+	for _, model := range models {
+		campuses = append(campuses, &Campus{
+			model: model,
+			valid: true,
+		})
+	}
+
+	return campuses, nil
+}
+
+func CreateCampus(context context.Context, actor *User, campusID string, content *AddressContent) (*Campus, error) {
+	if !actor.valid {
+		panic(ErrUserInvalid)
+	}
+	if !actor.is("administrator") {
+		return nil, ErrUserNotAdministrator
+	}
+
+	errPing := database.Ping(context)
+	if errPing != nil {
+		return nil, errPing
+	}
+
+	transaction, errBegin := database.Begin(context)
+	if errBegin != nil {
+		return nil, errBegin
+	}
+
+	campus, errNewCampus := newCampus(context, transaction, campusID, content)
+	if errNewCampus != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errNewCampus, errRollback)
+		}
+
+		return nil, errNewCampus
+	}
+
+	errRecord := recordAudit(context, transaction, fmt.Sprintf("Created campus %s.", campus.model.ID), actor)
+	if errRecord != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errRecord, errRollback)
+		}
+
+		return nil, errRecord
+	}
+
+	errCommit := transaction.Commit()
+	if errCommit != nil {
+		return nil, errCommit
+	}
+
+	return campus, nil
+}
+
+func UpdateCampus(context context.Context, actor *User, campusID string, content *AddressContent) (*Campus, error) {
+	if !actor.valid {
+		panic(ErrUserInvalid)
+	}
+	if !actor.is("administrator") {
+		return nil, ErrUserNotAdministrator
+	}
+
+	errPing := database.Ping(context)
+	if errPing != nil {
+		return nil, errPing
+	}
+
+	transaction, errBegin := database.Begin(context)
+	if errBegin != nil {
+		return nil, errBegin
+	}
+
+	campus, errGetCampus := getCampusByID(context, transaction, campusID)
+	if errGetCampus != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errGetCampus, errRollback)
+		}
+
+		return nil, errGetCampus
+	}
+
+	errUpdate := campus.update(context, transaction, content)
+	if errUpdate != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errUpdate, errRollback)
+		}
+
+		return nil, errUpdate
+	}
+
+	errRecord := recordAudit(context, transaction, fmt.Sprintf("Updated campus %s.", campus.model.ID), actor)
+	if errRecord != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errRecord, errRollback)
+		}
+
+		return nil, errRecord
+	}
+
+	errCommit := transaction.Commit()
+	if errCommit != nil {
+		return nil, errCommit
+	}
+
+	return campus, nil
+}
+
+func RetireCampus(context context.Context, actor *User, campusID string) (*Campus, error) {
+	if !actor.valid {
+		panic(ErrUserInvalid)
+	}
+	if !actor.is("administrator") {
+		return nil, ErrUserNotAdministrator
+	}
+
+	errPing := database.Ping(context)
+	if errPing != nil {
+		return nil, errPing
+	}
+
+	transaction, errBegin := database.Begin(context)
+	if errBegin != nil {
+		return nil, errBegin
+	}
+
+	campus, errGetCampus := getCampusByID(context, transaction, campusID)
+	if errGetCampus != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errGetCampus, errRollback)
+		}
+
+		return nil, errGetCampus
+	}
+
+	errRetire := campus.retire(context, transaction)
+	if errRetire != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errRetire, errRollback)
+		}
+
+		return nil, errRetire
+	}
+
+	errRecord := recordAudit(context, transaction, fmt.Sprintf("Retired campus %s.", campus.model.ID), actor)
+	if errRecord != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errRecord, errRollback)
+		}
+
+		return nil, errRecord
+	}
+
+	errCommit := transaction.Commit()
+	if errCommit != nil {
+		return nil, errCommit
+	}
+
+	return campus, nil
+}
+
 func (campus *Campus) MarshalJSON() ([]byte, error) {
 	if !campus.valid {
 		panic(ErrCampusInvalid)
 	}
 
 	campusMap := map[string]any{
+		"id":      campus.model.ID,
 		"name":    campus.model.Name,
 		"address": campus.model.Address,
 		"city":    campus.model.City,
@@ -84,6 +456,9 @@ func (campus *Campus) MarshalJSON() ([]byte, error) {
 	if campus.model.Unit.Valid {
 		campusMap["unit"] = campus.model.Unit.String
 	}
+	if campus.model.RetiredOn.Valid {
+		campusMap["retiredOn"] = campus.model.RetiredOn.Time
+	}
 
 	return json.Marshal(campusMap)
 }
@@ -5,20 +5,35 @@ import (
 	"database/sql"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"strings"
 
 	"github.com/google/uuid"
 	"github.com/sorucoder/samuel/internal/database"
 )
 
 type companyModel struct {
-	UUID    uuid.UUID      `db:"uuid"`
-	Name    string         `db:"name"`
-	Address string         `db:"address"`
-	Unit    sql.NullString `db:"unit"`
-	City    string         `db:"city"`
-	State   string         `db:"state"`
-	ZIP     string         `db:"zip"`
-	Phone   string         `db:"phone"`
+	UUID              uuid.UUID      `db:"uuid"`
+	Name              string         `db:"name"`
+	Address           string         `db:"address"`
+	Unit              sql.NullString `db:"unit"`
+	City              string         `db:"city"`
+	State             string         `db:"state"`
+	ZIP               string         `db:"zip"`
+	Phone             string         `db:"phone"`
+	ArchivedOn        sql.NullTime   `db:"archived_on"`
+	TwoFactorRequired bool           `db:"two_factor_required"`
+}
+
+func insertCompanyModel(context context.Context, transaction *database.Transaction, companyContent *AddressContent) (*companyModel, error) {
+	companyUUID := uuid.New()
+
+	_, errInsert := transaction.Execute(context, "INSERT INTO `companies` (`uuid`, `name`, `address`, `unit`, `city`, `state`, `zip`, `phone`) VALUE (?, ?, ?, ?, ?, ?, ?, ?)", companyUUID, companyContent.Name, companyContent.Address, companyContent.Unit, companyContent.City, companyContent.State, companyContent.ZIP, companyContent.Phone)
+	if errInsert != nil {
+		return nil, errInsert
+	}
+
+	return getCompanyModelByUUID(context, transaction, companyUUID)
 }
 
 func getCompanyModelByUUID(context context.Context, transaction *database.Transaction, companyUUID uuid.UUID) (*companyModel, error) {
@@ -32,15 +47,152 @@ func getCompanyModelByUUID(context context.Context, transaction *database.Transa
 	return model, nil
 }
 
+func writeCompanyModelFilter(queryBuilder *strings.Builder, search string, includeArchived bool) []any {
+	arguments := make([]any, 0, 2)
+	conditions := make([]string, 0, 2)
+	if !includeArchived {
+		conditions = append(conditions, "`archived_on` IS NULL")
+	}
+	if search != "" {
+		conditions = append(conditions, "(`name` LIKE CONCAT('%', ?, '%') OR `city` LIKE CONCAT('%', ?, '%'))")
+		arguments = append(arguments, search, search)
+	}
+	if len(conditions) > 0 {
+		queryBuilder.WriteString(" WHERE ")
+		queryBuilder.WriteString(strings.Join(conditions, " AND "))
+	}
+
+	return arguments
+}
+
+func selectCompanyModels(context context.Context, transaction *database.Transaction, search string, includeArchived bool, number int, limit int) ([]*companyModel, error) {
+	var queryBuilder strings.Builder
+	queryBuilder.WriteString("SELECT * FROM `companies`")
+	arguments := writeCompanyModelFilter(&queryBuilder, search, includeArchived)
+	queryBuilder.WriteString(" ORDER BY `name` LIMIT ? OFFSET ?")
+
+	offset := number * limit
+	arguments = append(arguments, limit, offset)
+
+	models := make([]*companyModel, 0, limit)
+
+	errSelect := transaction.Select(context, &models, queryBuilder.String(), arguments...)
+	if errSelect != nil {
+		return nil, errSelect
+	}
+
+	return models, nil
+}
+
+func countCompanyModels(context context.Context, transaction *database.Transaction, search string, includeArchived bool) (int64, error) {
+	var queryBuilder strings.Builder
+	queryBuilder.WriteString("SELECT COUNT(*) FROM `companies`")
+	arguments := writeCompanyModelFilter(&queryBuilder, search, includeArchived)
+
+	var count int64
+
+	errGet := transaction.Get(context, &count, queryBuilder.String(), arguments...)
+	if errGet != nil {
+		return 0, errGet
+	}
+
+	return count, nil
+}
+
+func (model *companyModel) archived() bool {
+	return model.ArchivedOn.Valid
+}
+
+func (model *companyModel) update(context context.Context, transaction *database.Transaction, newCompanyContent *AddressContent) error {
+	_, errUpdate := transaction.Execute(context, "UPDATE `companies` SET `name` = ?, `address` = ?, `unit` = ?, `city` = ?, `state` = ?, `zip` = ?, `phone` = ? WHERE `uuid` = ?", newCompanyContent.Name, newCompanyContent.Address, newCompanyContent.Unit, newCompanyContent.City, newCompanyContent.State, newCompanyContent.ZIP, newCompanyContent.Phone, model.UUID)
+	if errUpdate != nil {
+		return errUpdate
+	}
+
+	errGet := transaction.Get(context, model, "SELECT * FROM `companies` WHERE `uuid` = ?", model.UUID)
+	if errGet != nil {
+		return errGet
+	}
+
+	return nil
+}
+
+func (model *companyModel) updateArchivedOn(context context.Context, transaction *database.Transaction) error {
+	_, errUpdate := transaction.Execute(context, "UPDATE `companies` SET `archived_on` = NOW() WHERE `uuid` = ?", model.UUID)
+	if errUpdate != nil {
+		return errUpdate
+	}
+
+	errGetArchivedOn := transaction.Get(context, &model.ArchivedOn, "SELECT `archived_on` FROM `companies` WHERE `uuid` = ?", model.UUID)
+	if errGetArchivedOn != nil {
+		return errGetArchivedOn
+	}
+
+	return nil
+}
+
+func (model *companyModel) updateTwoFactorRequired(context context.Context, transaction *database.Transaction, twoFactorRequired bool) error {
+	_, errUpdate := transaction.Execute(context, "UPDATE `companies` SET `two_factor_required` = ? WHERE `uuid` = ?", twoFactorRequired, model.UUID)
+	if errUpdate != nil {
+		return errUpdate
+	}
+
+	model.TwoFactorRequired = twoFactorRequired
+
+	return nil
+}
+
 type Company struct {
-	model *companyModel
-	valid bool
+	model       *companyModel
+	supervisors []*Supervisor
+	internships []*Internship
+	valid       bool
 }
 
 var (
-	ErrCompanyInvalid error = errors.New("company invalid")
+	ErrCompanyInvalid   error = errors.New("company invalid")
+	ErrCompanyForbidden error = errors.New("company forbidden")
+	ErrCompanyArchived  error = errors.New("company archived")
 )
 
+func canManageCompanies(user *User) bool {
+	return user.is("administrator") || len(user.coordinators) > 0
+}
+
+func newCompany(context context.Context, transaction *database.Transaction, content *AddressContent) (*Company, error) {
+	validation := newValidationError()
+	content.validate(validation)
+	if !validation.empty() {
+		return nil, validation
+	}
+
+	company := new(Company)
+
+	var errInsertModel error
+	company.model, errInsertModel = insertCompanyModel(context, transaction, content)
+	if errInsertModel != nil {
+		return nil, errInsertModel
+	}
+
+	company.valid = true
+
+	return company, nil
+}
+
+func getCompanyByUUID(context context.Context, transaction *database.Transaction, companyUUID uuid.UUID) (*Company, error) {
+	company := new(Company)
+
+	var errGetModel error
+	company.model, errGetModel = getCompanyModelByUUID(context, transaction, companyUUID)
+	if errGetModel != nil {
+		return nil, errGetModel
+	}
+
+	company.valid = true
+
+	return company, nil
+}
+
 func getCompanyBySupervisor(context context.Context, transaction *database.Transaction, supervisor *Supervisor) (*Company, error) {
 	company := new(Company)
 
@@ -55,22 +207,365 @@ func getCompanyBySupervisor(context context.Context, transaction *database.Trans
 	return company, nil
 }
 
+func getCompanyBatch(context context.Context, transaction *database.Transaction, search string, includeArchived bool, page int, count int) (*Batch[*Company], error) {
+	companies := make([]*Company, 0, count)
+
+	companyModelCount, errCountModels := countCompanyModels(context, transaction, search, includeArchived)
+	if errCountModels != nil {
+		return nil, errCountModels
+	}
+
+	companyModels, errGetModels := selectCompanyModels(context, transaction, search, includeArchived, page, count)
+	if errGetModels != nil {
+		return nil, errGetModels
+	}
+	for _, companyModel := range companyModels {
+		companies = append(companies, &Company{
+			model: companyModel,
+			valid: true,
+		})
+	}
+
+	return newBatch(page, count, companyModelCount, "companies", companies...), nil
+}
+
+func (company *Company) loadDirectory(context context.Context, transaction *database.Transaction) error {
+	supervisorModels, errSelectSupervisorModels := selectSupervisorModelsByCompanyUUID(context, transaction, company.model.UUID)
+	if errSelectSupervisorModels != nil {
+		return errSelectSupervisorModels
+	}
+
+	// Supervisors reference a summary of the company so the detail view does not nest itself.
+	summary := &Company{
+		model: company.model,
+		valid: true,
+	}
+	company.supervisors = make([]*Supervisor, 0, len(supervisorModels))
+	for _, supervisorModel := range supervisorModels {
+		company.supervisors = append(company.supervisors, &Supervisor{
+			model:   supervisorModel,
+			company: summary,
+			valid:   true,
+		})
+	}
+
+	internshipModels, errSelectInternshipModels := selectInternshipModelsByCompanyUUID(context, transaction, company.model.UUID)
+	if errSelectInternshipModels != nil {
+		return errSelectInternshipModels
+	}
+
+	company.internships = make([]*Internship, 0, len(internshipModels))
+	for _, internshipModel := range internshipModels {
+		internship, errLoadInternship := loadInternship(context, transaction, internshipModel)
+		if errLoadInternship != nil {
+			return errLoadInternship
+		}
+
+		company.internships = append(company.internships, internship)
+	}
+
+	return nil
+}
+
+func (company *Company) update(context context.Context, transaction *database.Transaction, content *AddressContent) error {
+	if company.model.archived() {
+		return ErrCompanyArchived
+	}
+
+	validation := newValidationError()
+	content.validate(validation)
+	if !validation.empty() {
+		return validation
+	}
+
+	errUpdateModel := company.model.update(context, transaction, content)
+	if errUpdateModel != nil {
+		return errUpdateModel
+	}
+
+	return nil
+}
+
+func (company *Company) archive(context context.Context, transaction *database.Transaction) error {
+	if company.model.archived() {
+		return ErrCompanyArchived
+	}
+
+	errUpdateModel := company.model.updateArchivedOn(context, transaction)
+	if errUpdateModel != nil {
+		return errUpdateModel
+	}
+
+	return nil
+}
+
+func GetCompanyBatch(context context.Context, user *User, search string, includeArchived bool, batchNumber int, batchSize int) (*Batch[*Company], error) {
+	if !user.valid {
+		panic(ErrUserInvalid)
+	}
+	if !canManageCompanies(user) {
+		return nil, ErrCompanyForbidden
+	}
+
+	errPing := database.Ping(context)
+	if errPing != nil {
+		return nil, errPing
+	}
+
+	transaction, errBegin := database.Begin(context)
+	if errBegin != nil {
+		return nil, errBegin
+	}
+
+	companyBatch, errGetCompanyBatch := getCompanyBatch(context, transaction, strings.TrimSpace(search), includeArchived, batchNumber, batchSize)
+	if errGetCompanyBatch != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errGetCompanyBatch, errRollback)
+		}
+
+		return nil, errGetCompanyBatch
+	}
+
+	errCommit := transaction.Commit()
+	if errCommit != nil {
+		return nil, errCommit
+	}
+
+	return companyBatch, nil
+}
+
+func GetCompanyByUUID(context context.Context, user *User, companyUUID uuid.UUID) (*Company, error) {
+	if !user.valid {
+		panic(ErrUserInvalid)
+	}
+	if !canManageCompanies(user) {
+		return nil, ErrCompanyForbidden
+	}
+
+	errPing := database.Ping(context)
+	if errPing != nil {
+		return nil, errPing
+	}
+
+	transaction, errBegin := database.Begin(context)
+	if errBegin != nil {
+		return nil, errBegin
+	}
+
+	company, errGetCompany := getCompanyByUUID(context, transaction, companyUUID)
+	if errGetCompany != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errGetCompany, errRollback)
+		}
+
+		return nil, errGetCompany
+	}
+
+	errLoadDirectory := company.loadDirectory(context, transaction)
+	if errLoadDirectory != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errLoadDirectory, errRollback)
+		}
+
+		return nil, errLoadDirectory
+	}
+
+	errCommit := transaction.Commit()
+	if errCommit != nil {
+		return nil, errCommit
+	}
+
+	return company, nil
+}
+
+func CreateCompany(context context.Context, actor *User, content *AddressContent) (*Company, error) {
+	if !actor.valid {
+		panic(ErrUserInvalid)
+	}
+	if !canManageCompanies(actor) {
+		return nil, ErrCompanyForbidden
+	}
+
+	errPing := database.Ping(context)
+	if errPing != nil {
+		return nil, errPing
+	}
+
+	transaction, errBegin := database.Begin(context)
+	if errBegin != nil {
+		return nil, errBegin
+	}
+
+	company, errNewCompany := newCompany(context, transaction, content)
+	if errNewCompany != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errNewCompany, errRollback)
+		}
+
+		return nil, errNewCompany
+	}
+
+	errRecord := recordAudit(context, transaction, fmt.Sprintf("Created company %s.", company.model.UUID), actor)
+	if errRecord != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errRecord, errRollback)
+		}
+
+		return nil, errRecord
+	}
+
+	errCommit := transaction.Commit()
+	if errCommit != nil {
+		return nil, errCommit
+	}
+
+	return company, nil
+}
+
+func UpdateCompany(context context.Context, actor *User, companyUUID uuid.UUID, content *AddressContent) (*Company, error) {
+	if !actor.valid {
+		panic(ErrUserInvalid)
+	}
+	if !canManageCompanies(actor) {
+		return nil, ErrCompanyForbidden
+	}
+
+	errPing := database.Ping(context)
+	if errPing != nil {
+		return nil, errPing
+	}
+
+	transaction, errBegin := database.Begin(context)
+	if errBegin != nil {
+		return nil, errBegin
+	}
+
+	company, errGetCompany := getCompanyByUUID(context, transaction, companyUUID)
+	if errGetCompany != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errGetCompany, errRollback)
+		}
+
+		return nil, errGetCompany
+	}
+
+	errUpdate := company.update(context, transaction, content)
+	if errUpdate != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errUpdate, errRollback)
+		}
+
+		return nil, errUpdate
+	}
+
+	errRecord := recordAudit(context, transaction, fmt.Sprintf("Updated company %s.", company.model.UUID), actor)
+	if errRecord != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errRecord, errRollback)
+		}
+
+		return nil, errRecord
+	}
+
+	errCommit := transaction.Commit()
+	if errCommit != nil {
+		return nil, errCommit
+	}
+
+	return company, nil
+}
+
+func ArchiveCompany(context context.Context, actor *User, companyUUID uuid.UUID) (*Company, error) {
+	if !actor.valid {
+		panic(ErrUserInvalid)
+	}
+	if !canManageCompanies(actor) {
+		return nil, ErrCompanyForbidden
+	}
+
+	errPing := database.Ping(context)
+	if errPing != nil {
+		return nil, errPing
+	}
+
+	transaction, errBegin := database.Begin(context)
+	if errBegin != nil {
+		return nil, errBegin
+	}
+
+	company, errGetCompany := getCompanyByUUID(context, transaction, companyUUID)
+	if errGetCompany != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errGetCompany, errRollback)
+		}
+
+		return nil, errGetCompany
+	}
+
+	errArchive := company.archive(context, transaction)
+	if errArchive != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errArchive, errRollback)
+		}
+
+		return nil, errArchive
+	}
+
+	errRecord := recordAudit(context, transaction, fmt.Sprintf("Archived company %s.", company.model.UUID), actor)
+	if errRecord != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errRecord, errRollback)
+		}
+
+		return nil, errRecord
+	}
+
+	errCommit := transaction.Commit()
+	if errCommit != nil {
+		return nil, errCommit
+	}
+
+	return company, nil
+}
+
 func (company *Company) MarshalJSON() ([]byte, error) {
 	if !company.valid {
 		panic(ErrCompanyInvalid)
 	}
 
 	companyMap := map[string]any{
-		"name":    company.model.Name,
-		"address": company.model.Address,
-		"city":    company.model.City,
-		"state":   company.model.State,
-		"zip":     company.model.ZIP,
-		"phone":   company.model.Phone,
+		"uuid":              company.model.UUID,
+		"name":              company.model.Name,
+		"address":           company.model.Address,
+		"city":              company.model.City,
+		"state":             company.model.State,
+		"zip":               company.model.ZIP,
+		"phone":             company.model.Phone,
+		"twoFactorRequired": company.model.TwoFactorRequired,
 	}
 	if company.model.Unit.Valid {
 		companyMap["unit"] = company.model.Unit.String
 	}
+	if company.model.ArchivedOn.Valid {
+		companyMap["archivedOn"] = company.model.ArchivedOn.Time
+	}
+	if company.supervisors != nil {
+		companyMap["supervisors"] = company.supervisors
+	}
+	if company.internships != nil {
+		companyMap["internships"] = company.internships
+	}
 
 	return json.Marshal(companyMap)
 }
@@ -0,0 +1,364 @@
+package samuel
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/sorucoder/samuel/internal/database"
+)
+
+type coordinatorModel struct {
+	CampusID       string    `db:"campus_id"`
+	ProgramID      string    `db:"program_id"`
+	InstructorUUID uuid.UUID `db:"instructor_uuid"`
+}
+
+func upsertCoordinatorModel(context context.Context, transaction *database.Transaction, coordinatorCampusID string, coordinatorProgramID string, coordinatorInstructorUUID uuid.UUID) (*coordinatorModel, error) {
+	_, errUpsert := transaction.Execute(context, "INSERT INTO `coordinators` (`campus_id`, `program_id`, `instructor_uuid`) VALUE (?, ?, ?) ON DUPLICATE KEY UPDATE `instructor_uuid` = VALUES(`instructor_uuid`)", coordinatorCampusID, coordinatorProgramID, coordinatorInstructorUUID)
+	if errUpsert != nil {
+		return nil, errUpsert
+	}
+
+	return getCoordinatorModelByCampusIDAndProgramID(context, transaction, coordinatorCampusID, coordinatorProgramID)
+}
+
+func getCoordinatorModelByCampusIDAndProgramID(context context.Context, transaction *database.Transaction, coordinatorCampusID string, coordinatorProgramID string) (*coordinatorModel, error) {
+	model := new(coordinatorModel)
+
+	errGet := transaction.Get(context, model, "SELECT * FROM `coordinators` WHERE `campus_id` = ? AND `program_id` = ?", coordinatorCampusID, coordinatorProgramID)
+	if errGet != nil {
+		return nil, errGet
+	}
+
+	return model, nil
+}
+
+func selectCoordinatorModels(context context.Context, transaction *database.Transaction) ([]*coordinatorModel, error) {
+	models := make([]*coordinatorModel, 0)
+
+	errSelect := transaction.Select(context, &models, "SELECT * FROM `coordinators` ORDER BY `campus_id`, `program_id`")
+	if errSelect != nil {
+		return nil, errSelect
+	}
+
+	return models, nil
+}
+
+func selectCoordinatorModelsByInstructorUUID(context context.Context, transaction *database.Transaction, coordinatorInstructorUUID uuid.UUID) ([]*coordinatorModel, error) {
+	models := make([]*coordinatorModel, 0)
+
+	errSelect := transaction.Select(context, &models, "SELECT * FROM `coordinators` WHERE `instructor_uuid` = ? ORDER BY `campus_id`, `program_id`", coordinatorInstructorUUID)
+	if errSelect != nil {
+		return nil, errSelect
+	}
+
+	return models, nil
+}
+
+func (model *coordinatorModel) delete(context context.Context, transaction *database.Transaction) error {
+	_, errDelete := transaction.Execute(context, "DELETE FROM `coordinators` WHERE `campus_id` = ? AND `program_id` = ?", model.CampusID, model.ProgramID)
+	if errDelete != nil {
+		return errDelete
+	}
+
+	return nil
+}
+
+func (user *User) coordinates(campusID string, programID string) bool {
+	for _, coordinator := range user.coordinators {
+		if coordinator.CampusID == campusID && coordinator.ProgramID == programID {
+			return true
+		}
+	}
+	return false
+}
+
+func (user *User) coordinatesProgram(programID string) bool {
+	for _, coordinator := range user.coordinators {
+		if coordinator.ProgramID == programID {
+			return true
+		}
+	}
+	return false
+}
+
+type Coordinator struct {
+	model      *coordinatorModel
+	campus     *Campus
+	program    *Program
+	instructor *Instructor
+	valid      bool
+}
+
+var (
+	ErrCoordinatorInvalid error = errors.New("coordinator invalid")
+)
+
+func loadCoordinator(context context.Context, transaction *database.Transaction, model *coordinatorModel) (*Coordinator, error) {
+	coordinator := new(Coordinator)
+	coordinator.model = model
+
+	var errGetCampus error
+	coordinator.campus, errGetCampus = getCampusByID(context, transaction, model.CampusID)
+	if errGetCampus != nil {
+		return nil, errGetCampus
+	}
+
+	var errGetProgram error
+	coordinator.program, errGetProgram = getProgramByID(context, transaction, model.ProgramID)
+	if errGetProgram != nil {
+		return nil, errGetProgram
+	}
+
+	instructorUser, errGetInstructorUser := getUserByUUID(context, transaction, model.InstructorUUID)
+	if errGetInstructorUser != nil {
+		return nil, errGetInstructorUser
+	}
+
+	var errGetInstructor error
+	coordinator.instructor, errGetInstructor = getInstructorByUser(context, transaction, instructorUser)
+	if errGetInstructor != nil {
+		return nil, errGetInstructor
+	}
+
+	coordinator.valid = true
+
+	return coordinator, nil
+}
+
+func GetCoordinators(context context.Context) ([]*Coordinator, error) {
+	errPing := database.Ping(context)
+	if errPing != nil {
+		return nil, errPing
+	}
+
+	transaction, errBegin := database.Begin(context)
+	if errBegin != nil {
+		return nil, errBegin
+	}
+
+	models, errSelectModels := selectCoordinatorModels(context, transaction)
+	if errSelectModels != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errSelectModels, errRollback)
+		}
+
+		return nil, errSelectModels
+	}
+
+	coordinators := make([]*Coordinator, 0, len(models))
+	for _, model := range models {
+		coordinator, errLoadCoordinator := loadCoordinator(context, transaction, model)
+		if errLoadCoordinator != nil {
+			errRollback := transaction.Rollback()
+			if errRollback != nil {
+				return nil, errors.Join(errLoadCoordinator, errRollback)
+			}
+
+			return nil, errLoadCoordinator
+		}
+		coordinators = append(coordinators, coordinator)
+	}
+
+	errCommit := transaction.Commit()
+	if errCommit != nil {
+		return nil, errCommit
+	}
+
+	return coordinators, nil
+}
+
+func AssignCoordinator(context context.Context, actor *User, campusID string, programID string, instructorUUID uuid.UUID) (*Coordinator, error) {
+	if !actor.valid {
+		panic(ErrUserInvalid)
+	}
+	if !actor.is("administrator") {
+		return nil, ErrUserNotAdministrator
+	}
+
+	errPing := database.Ping(context)
+	if errPing != nil {
+		return nil, errPing
+	}
+
+	transaction, errBegin := database.Begin(context)
+	if errBegin != nil {
+		return nil, errBegin
+	}
+
+	instructorUser, errGetInstructorUser := getUserByUUID(context, transaction, instructorUUID)
+	if errGetInstructorUser != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errGetInstructorUser, errRollback)
+		}
+
+		return nil, errGetInstructorUser
+	}
+	if !instructorUser.holds("instructor") {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(ErrUserNotInstructor, errRollback)
+		}
+
+		return nil, ErrUserNotInstructor
+	}
+
+	campusModel, errGetCampusModel := getCampusModelByID(context, transaction, campusID)
+	if errGetCampusModel != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errGetCampusModel, errRollback)
+		}
+
+		return nil, errGetCampusModel
+	}
+	if campusModel.retired() {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(ErrCampusRetired, errRollback)
+		}
+
+		return nil, ErrCampusRetired
+	}
+
+	programModel, errGetProgramModel := getProgramModelByID(context, transaction, programID)
+	if errGetProgramModel != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errGetProgramModel, errRollback)
+		}
+
+		return nil, errGetProgramModel
+	}
+	if programModel.retired() {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(ErrProgramRetired, errRollback)
+		}
+
+		return nil, ErrProgramRetired
+	}
+
+	model, errUpsertModel := upsertCoordinatorModel(context, transaction, campusID, programID, instructorUser.model.UUID)
+	if errUpsertModel != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errUpsertModel, errRollback)
+		}
+
+		return nil, errUpsertModel
+	}
+
+	coordinator, errLoadCoordinator := loadCoordinator(context, transaction, model)
+	if errLoadCoordinator != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errLoadCoordinator, errRollback)
+		}
+
+		return nil, errLoadCoordinator
+	}
+
+	errNotify := notifyUser(context, transaction, fmt.Sprintf("You are now the coordinator of %s at %s.", coordinator.program.model.Name, coordinator.campus.model.Name), actor, instructorUser)
+	if errNotify != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errNotify, errRollback)
+		}
+
+		return nil, errNotify
+	}
+
+	errRecord := recordAudit(context, transaction, fmt.Sprintf("Assigned user %s as coordinator of program %s at campus %s.", instructorUser.model.UUID, programID, campusID), actor)
+	if errRecord != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errRecord, errRollback)
+		}
+
+		return nil, errRecord
+	}
+
+	errCommit := transaction.Commit()
+	if errCommit != nil {
+		return nil, errCommit
+	}
+
+	return coordinator, nil
+}
+
+func UnassignCoordinator(context context.Context, actor *User, campusID string, programID string) error {
+	if !actor.valid {
+		panic(ErrUserInvalid)
+	}
+	if !actor.is("administrator") {
+		return ErrUserNotAdministrator
+	}
+
+	errPing := database.Ping(context)
+	if errPing != nil {
+		return errPing
+	}
+
+	transaction, errBegin := database.Begin(context)
+	if errBegin != nil {
+		return errBegin
+	}
+
+	model, errGetModel := getCoordinatorModelByCampusIDAndProgramID(context, transaction, campusID, programID)
+	if errGetModel != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return errors.Join(errGetModel, errRollback)
+		}
+
+		return errGetModel
+	}
+
+	errDeleteModel := model.delete(context, transaction)
+	if errDeleteModel != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return errors.Join(errDeleteModel, errRollback)
+		}
+
+		return errDeleteModel
+	}
+
+	errRecord := recordAudit(context, transaction, fmt.Sprintf("Unassigned user %s as coordinator of program %s at campus %s.", model.InstructorUUID, programID, campusID), actor)
+	if errRecord != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return errors.Join(errRecord, errRollback)
+		}
+
+		return errRecord
+	}
+
+	errCommit := transaction.Commit()
+	if errCommit != nil {
+		return errCommit
+	}
+
+	return nil
+}
+
+func (coordinator *Coordinator) MarshalJSON() ([]byte, error) {
+	if !coordinator.valid {
+		panic(ErrCoordinatorInvalid)
+	}
+
+	return json.Marshal(map[string]any{
+		"campusID":       coordinator.model.CampusID,
+		"campus":         coordinator.campus,
+		"programID":      coordinator.model.ProgramID,
+		"program":        coordinator.program,
+		"instructorUUID": coordinator.model.InstructorUUID,
+		"instructor":     coordinator.instructor,
+	})
+}
@@ -0,0 +1,367 @@
+package samuel
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/sorucoder/samuel/internal/configuration"
+	"github.com/sorucoder/samuel/internal/database"
+	"github.com/sorucoder/samuel/internal/ldap"
+)
+
+type directoryProfile struct {
+	FirstName string
+	LastName  string
+	Email     string
+	Phone     string
+	ProgramID string
+}
+
+func normalizeDirectoryPhone(telephoneNumber string) string {
+	var phoneBuilder strings.Builder
+	for _, character := range telephoneNumber {
+		if unicode.IsDigit(character) {
+			phoneBuilder.WriteRune(character)
+		}
+	}
+
+	phone := phoneBuilder.String()
+	if len(phone) == 11 && strings.HasPrefix(phone, "1") {
+		phone = phone[1:]
+	}
+
+	return phone
+}
+
+func (profile *directoryProfile) merge(entry *ldap.Entry, activeProgramIDs map[string]bool) *directoryProfile {
+	merged := *profile
+
+	givenName := strings.TrimSpace(entry.GivenName)
+	if givenName != "" && utf8.RuneCountInString(givenName) <= userNameMaximumLength {
+		merged.FirstName = givenName
+	}
+
+	surname := strings.TrimSpace(entry.Surname)
+	if surname != "" && utf8.RuneCountInString(surname) <= userNameMaximumLength {
+		merged.LastName = surname
+	}
+
+	email := strings.TrimSpace(entry.Mail)
+	if address, errParse := mail.ParseAddress(email); errParse == nil && address.Address == email && utf8.RuneCountInString(email) <= userEmailMaximumLength {
+		merged.Email = email
+	}
+
+	phone := normalizeDirectoryPhone(entry.TelephoneNumber)
+	if addressPhonePattern.MatchString(phone) {
+		merged.Phone = phone
+	}
+
+	if profile.ProgramID != "" {
+		programID := strings.ToLower(strings.TrimSpace(entry.Department))
+		if activeProgramIDs[programID] {
+			merged.ProgramID = programID
+		}
+	}
+
+	return &merged
+}
+
+func (profile *directoryProfile) diff(merged *directoryProfile) map[string][2]string {
+	fields := make(map[string][2]string)
+	if profile.FirstName != merged.FirstName {
+		fields["firstName"] = [2]string{profile.FirstName, merged.FirstName}
+	}
+	if profile.LastName != merged.LastName {
+		fields["lastName"] = [2]string{profile.LastName, merged.LastName}
+	}
+	if profile.Email != merged.Email {
+		fields["email"] = [2]string{profile.Email, merged.Email}
+	}
+	if profile.Phone != merged.Phone {
+		fields["phone"] = [2]string{profile.Phone, merged.Phone}
+	}
+	if profile.ProgramID != merged.ProgramID {
+		fields["programID"] = [2]string{profile.ProgramID, merged.ProgramID}
+	}
+
+	return fields
+}
+
+type directoryChange struct {
+	user       *userModel
+	roleID     string
+	student    *studentModel
+	instructor *instructorModel
+	action     string
+	profile    *directoryProfile
+	fields     map[string][2]string
+}
+
+func (change *directoryChange) apply(context context.Context, transaction *database.Transaction) error {
+	if len(change.fields) > 0 {
+		var errUpdateProfile error
+		switch {
+		case change.student != nil:
+			errUpdateProfile = change.student.updateDirectoryProfile(context, transaction, change.profile)
+		case change.instructor != nil:
+			errUpdateProfile = change.instructor.updateDirectoryProfile(context, transaction, change.profile)
+		}
+		if errUpdateProfile != nil {
+			return errUpdateProfile
+		}
+	}
+
+	switch change.action {
+	case "remove":
+		return change.user.updateDirectoryRemovedOn(context, transaction, true)
+	case "restore":
+		return change.user.updateDirectoryRemovedOn(context, transaction, false)
+	}
+
+	return nil
+}
+
+type DirectorySynchronization struct {
+	changes []*directoryChange
+	applied bool
+	valid   bool
+}
+
+var (
+	ErrDirectorySynchronizationInvalid error = errors.New("directory synchronization invalid")
+	ErrDirectorySynchronizationUnsafe  error = errors.New("directory synchronization unsafe")
+)
+
+func diffDirectory(context context.Context, transaction *database.Transaction, entries []*ldap.Entry) ([]*directoryChange, int, error) {
+	entriesByIdentity := make(map[string]*ldap.Entry, len(entries))
+	for _, entry := range entries {
+		entriesByIdentity[strings.ToLower(entry.Identity)] = entry
+	}
+
+	programModels, errSelectProgramModels := selectProgramModels(context, transaction, false)
+	if errSelectProgramModels != nil {
+		return nil, 0, errSelectProgramModels
+	}
+	activeProgramIDs := make(map[string]bool, len(programModels))
+	for _, programModel := range programModels {
+		activeProgramIDs[programModel.ID] = true
+	}
+
+	userModels, errSelectUserModels := selectDirectoryUserModels(context, transaction)
+	if errSelectUserModels != nil {
+		return nil, 0, errSelectUserModels
+	}
+
+	// A user holding both directory roles appears once per role; only the first
+	// row carries the removal or restoration so that it is not applied twice.
+	flagged := make(map[uuid.UUID]bool, len(userModels))
+	users := make(map[uuid.UUID]bool, len(userModels))
+
+	changes := make([]*directoryChange, 0)
+	for _, directoryUserModel := range userModels {
+		userModel := &directoryUserModel.userModel
+		users[userModel.UUID] = true
+		change := &directoryChange{user: userModel, roleID: directoryUserModel.DirectoryRoleID}
+
+		var current *directoryProfile
+		switch directoryUserModel.DirectoryRoleID {
+		case "student":
+			var errGetStudentModel error
+			change.student, errGetStudentModel = getStudentModelByUserUUID(context, transaction, userModel.UUID)
+			if errGetStudentModel != nil {
+				return nil, 0, errGetStudentModel
+			}
+			current = &directoryProfile{
+				FirstName: change.student.FirstName,
+				LastName:  change.student.LastName,
+				Email:     change.student.Email,
+				Phone:     change.student.Phone,
+				ProgramID: change.student.ProgramID,
+			}
+		case "instructor":
+			var errGetInstructorModel error
+			change.instructor, errGetInstructorModel = getInstructorModelByUserUUID(context, transaction, userModel.UUID)
+			if errGetInstructorModel != nil {
+				return nil, 0, errGetInstructorModel
+			}
+			current = &directoryProfile{
+				FirstName: change.instructor.FirstName,
+				LastName:  change.instructor.LastName,
+				Email:     change.instructor.Email,
+				Phone:     change.instructor.Phone,
+			}
+		}
+
+		entry, exists := entriesByIdentity[strings.ToLower(userModel.Identity)]
+		if !exists {
+			if !userModel.removedFromDirectory() && !flagged[userModel.UUID] {
+				flagged[userModel.UUID] = true
+				change.action = "remove"
+				changes = append(changes, change)
+			}
+			continue
+		}
+
+		change.profile = current.merge(entry, activeProgramIDs)
+		change.fields = current.diff(change.profile)
+		switch {
+		case userModel.removedFromDirectory() && !flagged[userModel.UUID]:
+			flagged[userModel.UUID] = true
+			change.action = "restore"
+		case len(change.fields) > 0:
+			change.action = "update"
+		default:
+			continue
+		}
+
+		changes = append(changes, change)
+	}
+
+	return changes, len(users), nil
+}
+
+func (directorySynchronization *DirectorySynchronization) count(action string) int {
+	count := 0
+	for _, change := range directorySynchronization.changes {
+		if change.action == action {
+			count++
+		}
+	}
+
+	return count
+}
+
+func SynchronizeDirectory(context context.Context, actor *User, apply bool) (*DirectorySynchronization, error) {
+	if !actor.valid {
+		panic(ErrUserInvalid)
+	}
+	if !actor.is("administrator") {
+		return nil, ErrUserNotAdministrator
+	}
+
+	entries, errSearch := ldap.Search(context)
+	if errSearch != nil {
+		return nil, errSearch
+	}
+
+	errPing := database.Ping(context)
+	if errPing != nil {
+		return nil, errPing
+	}
+
+	transaction, errBegin := database.Begin(context)
+	if errBegin != nil {
+		return nil, errBegin
+	}
+
+	changes, userCount, errDiff := diffDirectory(context, transaction, entries)
+	if errDiff != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errDiff, errRollback)
+		}
+
+		return nil, errDiff
+	}
+
+	directorySynchronization := &DirectorySynchronization{
+		changes: changes,
+		valid:   true,
+	}
+
+	if !apply {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errRollback
+		}
+
+		return directorySynchronization, nil
+	}
+
+	// An empty or truncated directory answer would otherwise remove every user it
+	// no longer lists, so refuse to apply more removals than the configured share.
+	removalCount := directorySynchronization.count("remove")
+	if len(entries) == 0 || float64(removalCount) > configuration.LDAP.GetFloat64("syncMaximumRemovalShare")*float64(userCount) {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(ErrDirectorySynchronizationUnsafe, errRollback)
+		}
+
+		return nil, ErrDirectorySynchronizationUnsafe
+	}
+
+	for _, change := range changes {
+		errApply := change.apply(context, transaction)
+		if errApply != nil {
+			errRollback := transaction.Rollback()
+			if errRollback != nil {
+				return nil, errors.Join(errApply, errRollback)
+			}
+
+			return nil, errApply
+		}
+	}
+
+	errRecord := recordAudit(context, transaction, fmt.Sprintf("Synchronized directory: %d updated, %d removed, %d restored.", directorySynchronization.count("update"), removalCount, directorySynchronization.count("restore")), actor)
+	if errRecord != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errRecord, errRollback)
+		}
+
+		return nil, errRecord
+	}
+
+	errCommit := transaction.Commit()
+	if errCommit != nil {
+		return nil, errCommit
+	}
+
+	directorySynchronization.applied = true
+
+	return directorySynchronization, nil
+}
+
+func RecordDirectorySynchronizationFailure(actor *User, cause error) error {
+	return recordDetachedAudit(fmt.Sprintf("Could not synchronize directory: %v.", cause), actor)
+}
+
+func (directorySynchronization *DirectorySynchronization) MarshalJSON() ([]byte, error) {
+	if !directorySynchronization.valid {
+		panic(ErrDirectorySynchronizationInvalid)
+	}
+
+	changeMaps := make([]map[string]any, 0, len(directorySynchronization.changes))
+	for _, change := range directorySynchronization.changes {
+		changeMap := map[string]any{
+			"uuid":     change.user.UUID,
+			"identity": change.user.Identity,
+			"role":     change.roleID,
+			"action":   change.action,
+		}
+		if len(change.fields) > 0 {
+			fieldMaps := make(map[string]any, len(change.fields))
+			for field, values := range change.fields {
+				fieldMaps[field] = map[string]string{
+					"from": values[0],
+					"to":   values[1],
+				}
+			}
+			changeMap["fields"] = fieldMaps
+		}
+
+		changeMaps = append(changeMaps, changeMap)
+	}
+
+	return json.Marshal(map[string]any{
+		"applied": directorySynchronization.applied,
+		"changes": changeMaps,
+	})
+}
@@ -0,0 +1,30 @@
+package samuel
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sorucoder/samuel/internal/database"
+	"github.com/sorucoder/samuel/internal/email"
+)
+
+const emailSendTimeout time.Duration = time.Minute
+
+// sendEmailOnCommit sends an email once transaction commits. The message is
+// sent in the background on a context of its own, so a slow mail server
+// neither holds the request open nor is cut short when it returns; a failure
+// is recorded in the audit log against actor.
+func sendEmailOnCommit(transaction *database.Transaction, actor *User, description string, to *email.Address, template *email.Template, pipeline any) {
+	transaction.OnCommit(func() {
+		go func() {
+			context, cancel := context.WithTimeout(context.Background(), emailSendTimeout)
+			defer cancel()
+
+			errSend := email.Send(context, to, template, pipeline)
+			if errSend != nil {
+				recordDetachedAudit(fmt.Sprintf("Could not send %s: %v.", description, errSend), actor)
+			}
+		}()
+	})
+}
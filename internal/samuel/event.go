@@ -0,0 +1,207 @@
+package samuel
+
+import (
+	"encoding/json"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sorucoder/samuel/internal/database"
+)
+
+const (
+	eventHistorySize      int           = 64
+	eventHistoryLifetime  time.Duration = 10 * time.Minute
+	eventSubscriptionSize int           = 16
+)
+
+type Event struct {
+	id       uint64
+	userUUID uuid.UUID
+	name     string
+	data     json.RawMessage
+}
+
+func (event *Event) ID() string {
+	return strconv.FormatUint(event.id, 10)
+}
+
+func (event *Event) Name() string {
+	return event.name
+}
+
+func (event *Event) Data() []byte {
+	return event.data
+}
+
+type EventSubscription struct {
+	userUUID uuid.UUID
+	events   chan *Event
+	closed   bool
+}
+
+func (subscription *EventSubscription) Events() <-chan *Event {
+	return subscription.events
+}
+
+func (subscription *EventSubscription) Close() {
+	hub.unsubscribe(subscription)
+}
+
+type eventHistory struct {
+	events    []*Event
+	evictedID uint64
+}
+
+type eventHub struct {
+	mutex         sync.Mutex
+	startedID     uint64
+	lastID        uint64
+	subscriptions map[uuid.UUID]map[*EventSubscription]struct{}
+	histories     map[uuid.UUID]*eventHistory
+}
+
+var hub *eventHub = newEventHub()
+
+func eventIDAt(moment time.Time) uint64 {
+	return uint64(moment.UnixMicro())
+}
+
+func newEventHub() *eventHub {
+	startedID := eventIDAt(time.Now())
+
+	return &eventHub{
+		startedID:     startedID,
+		lastID:        startedID,
+		subscriptions: make(map[uuid.UUID]map[*EventSubscription]struct{}),
+		histories:     make(map[uuid.UUID]*eventHistory),
+	}
+}
+
+func (hub *eventHub) prune(now time.Time) {
+	cutoffID := eventIDAt(now.Add(-eventHistoryLifetime))
+	for userUUID, history := range hub.histories {
+		for len(history.events) > 0 && history.events[0].id < cutoffID {
+			history.evictedID = history.events[0].id
+			history.events = history.events[1:]
+		}
+		if len(history.events) == 0 {
+			delete(hub.histories, userUUID)
+		}
+	}
+}
+
+func (hub *eventHub) subscribe(userUUID uuid.UUID, lastEventID uint64) (*EventSubscription, []*Event, bool) {
+	hub.mutex.Lock()
+	defer hub.mutex.Unlock()
+
+	now := time.Now()
+	hub.prune(now)
+
+	subscription := &EventSubscription{
+		userUUID: userUUID,
+		events:   make(chan *Event, eventSubscriptionSize),
+	}
+	if hub.subscriptions[userUUID] == nil {
+		hub.subscriptions[userUUID] = make(map[*EventSubscription]struct{})
+	}
+	hub.subscriptions[userUUID][subscription] = struct{}{}
+
+	missed := make([]*Event, 0)
+	if lastEventID == 0 {
+		return subscription, missed, false
+	}
+
+	// Event IDs are timestamps, so anything older than the retained window may have been lost.
+	truncated := lastEventID < hub.startedID || lastEventID < eventIDAt(now.Add(-eventHistoryLifetime))
+	if history, exists := hub.histories[userUUID]; exists {
+		if lastEventID < history.evictedID {
+			truncated = true
+		}
+		for _, event := range history.events {
+			if event.id > lastEventID {
+				missed = append(missed, event)
+			}
+		}
+	}
+
+	return subscription, missed, truncated
+}
+
+func (hub *eventHub) unsubscribe(subscription *EventSubscription) {
+	hub.mutex.Lock()
+	defer hub.mutex.Unlock()
+
+	hub.remove(subscription)
+}
+
+func (hub *eventHub) remove(subscription *EventSubscription) {
+	if subscription.closed {
+		return
+	}
+
+	delete(hub.subscriptions[subscription.userUUID], subscription)
+	if len(hub.subscriptions[subscription.userUUID]) == 0 {
+		delete(hub.subscriptions, subscription.userUUID)
+	}
+	close(subscription.events)
+	subscription.closed = true
+}
+
+func (hub *eventHub) publish(userUUID uuid.UUID, name string, data json.RawMessage) {
+	hub.mutex.Lock()
+	defer hub.mutex.Unlock()
+
+	now := time.Now()
+	hub.prune(now)
+
+	hub.lastID = max(hub.lastID+1, eventIDAt(now))
+	event := &Event{
+		id:       hub.lastID,
+		userUUID: userUUID,
+		name:     name,
+		data:     data,
+	}
+
+	history, exists := hub.histories[userUUID]
+	if !exists {
+		history = new(eventHistory)
+		hub.histories[userUUID] = history
+	}
+	history.events = append(history.events, event)
+	if len(history.events) > eventHistorySize {
+		history.evictedID = history.events[len(history.events)-eventHistorySize-1].id
+		history.events = history.events[len(history.events)-eventHistorySize:]
+	}
+
+	for subscription := range hub.subscriptions[userUUID] {
+		select {
+		case subscription.events <- event:
+		default:
+			// A subscriber that cannot keep up is dropped so it reconnects and replays from history.
+			hub.remove(subscription)
+		}
+	}
+}
+
+func publishEvent(transaction *database.Transaction, recipient uuid.UUID, name string, payload json.Marshaler) error {
+	data, errMarshal := payload.MarshalJSON()
+	if errMarshal != nil {
+		return errMarshal
+	}
+
+	transaction.OnCommit(func() {
+		hub.publish(recipient, name, data)
+	})
+
+	return nil
+}
+
+func SubscribeEvents(user *User, lastEventID uint64) (*EventSubscription, []*Event, bool) {
+	if !user.valid {
+		panic(ErrUserInvalid)
+	}
+
+	return hub.subscribe(user.model.UUID, lastEventID)
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/sorucoder/samuel/internal/database"
+	"github.com/sorucoder/samuel/internal/email"
 )
 
 type instructorModel struct {
@@ -18,6 +19,15 @@ type instructorModel struct {
 	CampusID  string    `db:"campus_id"`
 }
 
+func insertInstructorModel(context context.Context, transaction *database.Transaction, instructorUserUUID uuid.UUID, instructorProfile *UserProfileContent) (*instructorModel, error) {
+	_, errInsert := transaction.Execute(context, "INSERT INTO `instructors` (`user_uuid`, `first_name`, `last_name`, `email`, `phone`, `campus_id`) VALUE (?, ?, ?, ?, ?, ?)", instructorUserUUID, instructorProfile.FirstName, instructorProfile.LastName, instructorProfile.Email, instructorProfile.Phone, instructorProfile.CampusID)
+	if errInsert != nil {
+		return nil, errInsert
+	}
+
+	return getInstructorModelByUserUUID(context, transaction, instructorUserUUID)
+}
+
 func getInstructorModelByUserUUID(context context.Context, transaction *database.Transaction, instructorUserUUID uuid.UUID) (*instructorModel, error) {
 	model := new(instructorModel)
 
@@ -29,6 +39,24 @@ func getInstructorModelByUserUUID(context context.Context, transaction *database
 	return model, nil
 }
 
+func (model *instructorModel) updateDirectoryProfile(context context.Context, transaction *database.Transaction, profile *directoryProfile) error {
+	_, errUpdate := transaction.Execute(context, "UPDATE `instructors` SET `first_name` = ?, `last_name` = ?, `email` = ?, `phone` = ? WHERE `user_uuid` = ?", profile.FirstName, profile.LastName, profile.Email, profile.Phone, model.UserUUID)
+	if errUpdate != nil {
+		return errUpdate
+	}
+
+	model.FirstName = profile.FirstName
+	model.LastName = profile.LastName
+	model.Email = profile.Email
+	model.Phone = profile.Phone
+
+	return nil
+}
+
+func (model *instructorModel) address() *email.Address {
+	return email.NewAddress(model.FirstName, model.LastName, model.Email)
+}
+
 type Instructor struct {
 	model  *instructorModel
 	campus *Campus
@@ -63,7 +91,7 @@ func GetInstructorByUser(context context.Context, user *User) (*Instructor, erro
 	if !user.valid {
 		panic(ErrUserInvalid)
 	}
-	if !user.model.is("instructor") {
+	if !user.holds("instructor") {
 		panic(ErrUserNotInstructor)
 	}
 
@@ -0,0 +1,666 @@
+package samuel
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sorucoder/samuel/internal/database"
+)
+
+type internshipModel struct {
+	UUID           uuid.UUID    `db:"uuid"`
+	StudentUUID    uuid.UUID    `db:"student_uuid"`
+	InstructorUUID uuid.UUID    `db:"instructor_uuid"`
+	SupervisorUUID uuid.UUID    `db:"supervisor_uuid"`
+	StartOn        time.Time    `db:"start_on"`
+	EndOn          time.Time    `db:"end_on"`
+	ClosedOn       sql.NullTime `db:"closed_on"`
+}
+
+func insertInternshipModel(context context.Context, transaction *database.Transaction, internshipStudentUUID uuid.UUID, internshipInstructorUUID uuid.UUID, internshipSupervisorUUID uuid.UUID, internshipStartOn time.Time, internshipEndOn time.Time) (*internshipModel, error) {
+	internshipUUID := uuid.New()
+
+	_, errInsert := transaction.Execute(context, "INSERT INTO `internships` (`uuid`, `student_uuid`, `instructor_uuid`, `supervisor_uuid`, `start_on`, `end_on`) VALUE (?, ?, ?, ?, ?, ?)", internshipUUID, internshipStudentUUID, internshipInstructorUUID, internshipSupervisorUUID, internshipStartOn, internshipEndOn)
+	if errInsert != nil {
+		return nil, errInsert
+	}
+
+	model := new(internshipModel)
+
+	errGet := transaction.Get(context, model, "SELECT * FROM `internships` WHERE `uuid` = ?", internshipUUID)
+	if errGet != nil {
+		return nil, errGet
+	}
+
+	return model, nil
+}
+
+func getInternshipModelByUUID(context context.Context, transaction *database.Transaction, internshipUUID uuid.UUID) (*internshipModel, error) {
+	model := new(internshipModel)
+
+	errGet := transaction.Get(context, model, "SELECT * FROM `internships` WHERE `uuid` = ?", internshipUUID)
+	if errGet != nil {
+		return nil, errGet
+	}
+
+	return model, nil
+}
+
+func writeInternshipModelUserFilter(queryBuilder *strings.Builder, user *User) []any {
+	switch {
+	case user.is("student"):
+		queryBuilder.WriteString(" WHERE `student_uuid` = ?")
+		return []any{user.model.UUID}
+	case user.is("instructor"):
+		queryBuilder.WriteString(" WHERE `instructor_uuid` = ? OR `student_uuid` IN (SELECT `students`.`user_uuid` FROM `students` INNER JOIN `coordinators` ON `coordinators`.`campus_id` = `students`.`campus_id` AND `coordinators`.`program_id` = `students`.`program_id` WHERE `coordinators`.`instructor_uuid` = ?)")
+		return []any{user.model.UUID, user.model.UUID}
+	case user.is("supervisor"):
+		queryBuilder.WriteString(" WHERE `supervisor_uuid` = ?")
+		return []any{user.model.UUID}
+	default:
+		return []any{}
+	}
+}
+
+func selectInternshipModelsByUser(context context.Context, transaction *database.Transaction, user *User, number int, limit int) ([]*internshipModel, error) {
+	var queryBuilder strings.Builder
+	queryBuilder.WriteString("SELECT * FROM `internships`")
+	arguments := writeInternshipModelUserFilter(&queryBuilder, user)
+	queryBuilder.WriteString(" ORDER BY `start_on` DESC LIMIT ? OFFSET ?")
+
+	offset := number * limit
+	arguments = append(arguments, limit, offset)
+
+	models := make([]*internshipModel, 0, limit)
+
+	errSelect := transaction.Select(context, &models, queryBuilder.String(), arguments...)
+	if errSelect != nil {
+		return nil, errSelect
+	}
+
+	return models, nil
+}
+
+func countInternshipModelsByUser(context context.Context, transaction *database.Transaction, user *User) (int64, error) {
+	var queryBuilder strings.Builder
+	queryBuilder.WriteString("SELECT COUNT(*) FROM `internships`")
+	arguments := writeInternshipModelUserFilter(&queryBuilder, user)
+
+	var count int64
+
+	errGet := transaction.Get(context, &count, queryBuilder.String(), arguments...)
+	if errGet != nil {
+		return 0, errGet
+	}
+
+	return count, nil
+}
+
+func selectInternshipModelsByCompanyUUID(context context.Context, transaction *database.Transaction, companyUUID uuid.UUID) ([]*internshipModel, error) {
+	models := make([]*internshipModel, 0)
+
+	errSelect := transaction.Select(context, &models, "SELECT `internships`.* FROM `internships` JOIN `supervisors` ON `internships`.`supervisor_uuid` = `supervisors`.`user_uuid` WHERE `supervisors`.`company_uuid` = ? ORDER BY `internships`.`start_on` DESC", companyUUID)
+	if errSelect != nil {
+		return nil, errSelect
+	}
+
+	return models, nil
+}
+
+func countOpenInternshipModelsByCampusID(context context.Context, transaction *database.Transaction, campusID string) (int64, error) {
+	var count int64
+
+	errGet := transaction.Get(context, &count, "SELECT COUNT(*) FROM `internships` JOIN `students` ON `internships`.`student_uuid` = `students`.`user_uuid` WHERE `students`.`campus_id` = ? AND `internships`.`closed_on` IS NULL", campusID)
+	if errGet != nil {
+		return 0, errGet
+	}
+
+	return count, nil
+}
+
+func countOpenInternshipModelsByProgramID(context context.Context, transaction *database.Transaction, programID string) (int64, error) {
+	var count int64
+
+	errGet := transaction.Get(context, &count, "SELECT COUNT(*) FROM `internships` JOIN `students` ON `internships`.`student_uuid` = `students`.`user_uuid` WHERE `students`.`program_id` = ? AND `internships`.`closed_on` IS NULL", programID)
+	if errGet != nil {
+		return 0, errGet
+	}
+
+	return count, nil
+}
+
+func (model *internshipModel) involves(user *User) bool {
+	return model.StudentUUID == user.model.UUID || model.InstructorUUID == user.model.UUID || model.SupervisorUUID == user.model.UUID
+}
+
+func (model *internshipModel) closed() bool {
+	return model.ClosedOn.Valid
+}
+
+func (model *internshipModel) updateClosedOn(context context.Context, transaction *database.Transaction) error {
+	_, errUpdate := transaction.Execute(context, "UPDATE `internships` SET `closed_on` = NOW() WHERE `uuid` = ?", model.UUID)
+	if errUpdate != nil {
+		return errUpdate
+	}
+
+	errGetClosedOn := transaction.Get(context, &model.ClosedOn, "SELECT `closed_on` FROM `internships` WHERE `uuid` = ?", model.UUID)
+	if errGetClosedOn != nil {
+		return errGetClosedOn
+	}
+
+	return nil
+}
+
+func (model *internshipModel) updateInstructorUUID(context context.Context, transaction *database.Transaction, newInternshipInstructorUUID uuid.UUID) error {
+	_, errUpdate := transaction.Execute(context, "UPDATE `internships` SET `instructor_uuid` = ? WHERE `uuid` = ?", newInternshipInstructorUUID, model.UUID)
+	if errUpdate != nil {
+		return errUpdate
+	}
+
+	model.InstructorUUID = newInternshipInstructorUUID
+
+	return nil
+}
+
+type Internship struct {
+	model      *internshipModel
+	student    *Student
+	instructor *Instructor
+	supervisor *Supervisor
+	valid      bool
+}
+
+var (
+	ErrInternshipInvalid           error = errors.New("internship invalid")
+	ErrInternshipClosed            error = errors.New("internship closed")
+	ErrInternshipNotInvolved       error = errors.New("internship not involved")
+	ErrInternshipStartNotSunday    error = errors.New("internship start not sunday")
+	ErrInternshipEndNotSaturday    error = errors.New("internship end not saturday")
+	ErrInternshipEndBeforeStart    error = errors.New("internship end before start")
+	ErrInternshipCloseForbidden    error = errors.New("internship close forbidden")
+	ErrInternshipCreateForbidden   error = errors.New("internship create forbidden")
+	ErrInternshipReassignForbidden error = errors.New("internship reassign forbidden")
+)
+
+func loadInternship(context context.Context, transaction *database.Transaction, model *internshipModel) (*Internship, error) {
+	internship := new(Internship)
+	internship.model = model
+
+	studentUser, errGetStudentUser := getUserByUUID(context, transaction, model.StudentUUID)
+	if errGetStudentUser != nil {
+		return nil, errGetStudentUser
+	}
+
+	var errGetStudent error
+	internship.student, errGetStudent = getStudentByUser(context, transaction, studentUser)
+	if errGetStudent != nil {
+		return nil, errGetStudent
+	}
+
+	instructorUser, errGetInstructorUser := getUserByUUID(context, transaction, model.InstructorUUID)
+	if errGetInstructorUser != nil {
+		return nil, errGetInstructorUser
+	}
+
+	var errGetInstructor error
+	internship.instructor, errGetInstructor = getInstructorByUser(context, transaction, instructorUser)
+	if errGetInstructor != nil {
+		return nil, errGetInstructor
+	}
+
+	supervisorUser, errGetSupervisorUser := getUserByUUID(context, transaction, model.SupervisorUUID)
+	if errGetSupervisorUser != nil {
+		return nil, errGetSupervisorUser
+	}
+
+	var errGetSupervisor error
+	internship.supervisor, errGetSupervisor = getSupervisorByUser(context, transaction, supervisorUser)
+	if errGetSupervisor != nil {
+		return nil, errGetSupervisor
+	}
+
+	internship.valid = true
+
+	return internship, nil
+}
+
+func newInternship(context context.Context, transaction *database.Transaction, studentUser *User, instructorUser *User, supervisorUser *User, startOn time.Time, endOn time.Time) (*Internship, error) {
+	if !studentUser.holds("student") {
+		return nil, ErrUserNotStudent
+	}
+	if !instructorUser.holds("instructor") {
+		return nil, ErrUserNotInstructor
+	}
+	if !supervisorUser.holds("supervisor") {
+		return nil, ErrUserNotSupervisor
+	}
+	if startOn.Weekday() != time.Sunday {
+		return nil, ErrInternshipStartNotSunday
+	}
+	if endOn.Weekday() != time.Saturday {
+		return nil, ErrInternshipEndNotSaturday
+	}
+	if endOn.Before(startOn) {
+		return nil, ErrInternshipEndBeforeStart
+	}
+
+	model, errInsertModel := insertInternshipModel(context, transaction, studentUser.model.UUID, instructorUser.model.UUID, supervisorUser.model.UUID, startOn, endOn)
+	if errInsertModel != nil {
+		return nil, errInsertModel
+	}
+
+	return loadInternship(context, transaction, model)
+}
+
+func getInternshipByUUID(context context.Context, transaction *database.Transaction, internshipUUID uuid.UUID) (*Internship, error) {
+	model, errGetModel := getInternshipModelByUUID(context, transaction, internshipUUID)
+	if errGetModel != nil {
+		return nil, errGetModel
+	}
+
+	return loadInternship(context, transaction, model)
+}
+
+func getInternshipBatchByUser(context context.Context, transaction *database.Transaction, user *User, page int, count int) (*Batch[*Internship], error) {
+	internships := make([]*Internship, 0, count)
+
+	internshipModelCount, errCountModels := countInternshipModelsByUser(context, transaction, user)
+	if errCountModels != nil {
+		return nil, errCountModels
+	}
+
+	internshipModels, errGetModels := selectInternshipModelsByUser(context, transaction, user, page, count)
+	if errGetModels != nil {
+		return nil, errGetModels
+	}
+	for _, internshipModel := range internshipModels {
+		internship, errLoad := loadInternship(context, transaction, internshipModel)
+		if errLoad != nil {
+			return nil, errLoad
+		}
+
+		internships = append(internships, internship)
+	}
+
+	return newBatch(page, count, internshipModelCount, "internships", internships...), nil
+}
+
+func CreateInternship(context context.Context, actor *User, studentUUID uuid.UUID, instructorUUID uuid.UUID, supervisorUUID uuid.UUID, startOn time.Time, endOn time.Time) (*Internship, error) {
+	if !actor.valid {
+		panic(ErrUserInvalid)
+	}
+	if !actor.is("administrator") {
+		return nil, ErrInternshipCreateForbidden
+	}
+
+	errPing := database.Ping(context)
+	if errPing != nil {
+		return nil, errPing
+	}
+
+	transaction, errBegin := database.Begin(context)
+	if errBegin != nil {
+		return nil, errBegin
+	}
+
+	studentUser, errGetStudentUser := getUserByUUID(context, transaction, studentUUID)
+	if errGetStudentUser != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errGetStudentUser, errRollback)
+		}
+
+		return nil, errGetStudentUser
+	}
+
+	instructorUser, errGetInstructorUser := getUserByUUID(context, transaction, instructorUUID)
+	if errGetInstructorUser != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errGetInstructorUser, errRollback)
+		}
+
+		return nil, errGetInstructorUser
+	}
+
+	supervisorUser, errGetSupervisorUser := getUserByUUID(context, transaction, supervisorUUID)
+	if errGetSupervisorUser != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errGetSupervisorUser, errRollback)
+		}
+
+		return nil, errGetSupervisorUser
+	}
+
+	internship, errNewInternship := newInternship(context, transaction, studentUser, instructorUser, supervisorUser, startOn, endOn)
+	if errNewInternship != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errNewInternship, errRollback)
+		}
+
+		return nil, errNewInternship
+	}
+
+	errRecord := recordAudit(context, transaction, fmt.Sprintf("Created internship %s.", internship.model.UUID), actor)
+	if errRecord != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errRecord, errRollback)
+		}
+
+		return nil, errRecord
+	}
+
+	errCommit := transaction.Commit()
+	if errCommit != nil {
+		return nil, errCommit
+	}
+
+	return internship, nil
+}
+
+func GetInternshipBatchByUser(context context.Context, user *User, batchNumber int, batchSize int) (*Batch[*Internship], error) {
+	if !user.valid {
+		panic(ErrUserInvalid)
+	}
+
+	errPing := database.Ping(context)
+	if errPing != nil {
+		return nil, errPing
+	}
+
+	transaction, errBegin := database.Begin(context)
+	if errBegin != nil {
+		return nil, errBegin
+	}
+
+	internshipBatch, errGetInternshipBatch := getInternshipBatchByUser(context, transaction, user, batchNumber, batchSize)
+	if errGetInternshipBatch != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errGetInternshipBatch, errRollback)
+		}
+
+		return nil, errGetInternshipBatch
+	}
+
+	errCommit := transaction.Commit()
+	if errCommit != nil {
+		return nil, errCommit
+	}
+
+	return internshipBatch, nil
+}
+
+func GetInternshipByUUID(context context.Context, user *User, internshipUUID uuid.UUID) (*Internship, error) {
+	if !user.valid {
+		panic(ErrUserInvalid)
+	}
+
+	errPing := database.Ping(context)
+	if errPing != nil {
+		return nil, errPing
+	}
+
+	transaction, errBegin := database.Begin(context)
+	if errBegin != nil {
+		return nil, errBegin
+	}
+
+	internship, errGetInternship := getInternshipByUUID(context, transaction, internshipUUID)
+	if errGetInternship != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errGetInternship, errRollback)
+		}
+
+		return nil, errGetInternship
+	}
+
+	if !internship.visibleTo(user) {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(ErrInternshipNotInvolved, errRollback)
+		}
+
+		return nil, ErrInternshipNotInvolved
+	}
+
+	errCommit := transaction.Commit()
+	if errCommit != nil {
+		return nil, errCommit
+	}
+
+	return internship, nil
+}
+
+func CloseInternship(context context.Context, actor *User, internshipUUID uuid.UUID) (*Internship, error) {
+	if !actor.valid {
+		panic(ErrUserInvalid)
+	}
+
+	errPing := database.Ping(context)
+	if errPing != nil {
+		return nil, errPing
+	}
+
+	transaction, errBegin := database.Begin(context)
+	if errBegin != nil {
+		return nil, errBegin
+	}
+
+	internship, errGetInternship := getInternshipByUUID(context, transaction, internshipUUID)
+	if errGetInternship != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errGetInternship, errRollback)
+		}
+
+		return nil, errGetInternship
+	}
+
+	errClose := internship.close(context, transaction, actor)
+	if errClose != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errClose, errRollback)
+		}
+
+		return nil, errClose
+	}
+
+	errRecord := recordAudit(context, transaction, fmt.Sprintf("Closed internship %s.", internship.model.UUID), actor)
+	if errRecord != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errRecord, errRollback)
+		}
+
+		return nil, errRecord
+	}
+
+	errCommit := transaction.Commit()
+	if errCommit != nil {
+		return nil, errCommit
+	}
+
+	return internship, nil
+}
+
+func ReassignInternshipInstructor(context context.Context, actor *User, internshipUUID uuid.UUID, instructorUUID uuid.UUID) (*Internship, error) {
+	if !actor.valid {
+		panic(ErrUserInvalid)
+	}
+
+	errPing := database.Ping(context)
+	if errPing != nil {
+		return nil, errPing
+	}
+
+	transaction, errBegin := database.Begin(context)
+	if errBegin != nil {
+		return nil, errBegin
+	}
+
+	internship, errGetInternship := getInternshipByUUID(context, transaction, internshipUUID)
+	if errGetInternship != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errGetInternship, errRollback)
+		}
+
+		return nil, errGetInternship
+	}
+
+	instructorUser, errGetInstructorUser := getUserByUUID(context, transaction, instructorUUID)
+	if errGetInstructorUser != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errGetInstructorUser, errRollback)
+		}
+
+		return nil, errGetInstructorUser
+	}
+
+	errReassign := internship.reassign(context, transaction, actor, instructorUser)
+	if errReassign != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errReassign, errRollback)
+		}
+
+		return nil, errReassign
+	}
+
+	errNotify := notifyUser(context, transaction, fmt.Sprintf("You have been assigned as the instructor of %s %s's internship.", internship.student.model.FirstName, internship.student.model.LastName), actor, instructorUser)
+	if errNotify != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errNotify, errRollback)
+		}
+
+		return nil, errNotify
+	}
+
+	errRecord := recordAudit(context, transaction, fmt.Sprintf("Reassigned internship %s to instructor %s.", internship.model.UUID, instructorUser.model.UUID), actor)
+	if errRecord != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errRecord, errRollback)
+		}
+
+		return nil, errRecord
+	}
+
+	errCommit := transaction.Commit()
+	if errCommit != nil {
+		return nil, errCommit
+	}
+
+	return internship, nil
+}
+
+func (internship *Internship) UUID() uuid.UUID {
+	if !internship.valid {
+		panic(ErrInternshipInvalid)
+	}
+
+	return internship.model.UUID
+}
+
+func (internship *Internship) Closed() bool {
+	if !internship.valid {
+		panic(ErrInternshipInvalid)
+	}
+
+	return internship.model.closed()
+}
+
+func (internship *Internship) involves(user *User) bool {
+	return internship.model.involves(user)
+}
+
+func (internship *Internship) coordinatedBy(user *User) bool {
+	return user.coordinates(internship.student.model.CampusID, internship.student.model.ProgramID)
+}
+
+func (internship *Internship) visibleTo(user *User) bool {
+	return user.is("administrator") || internship.involves(user) || internship.coordinatedBy(user)
+}
+
+func (internship *Internship) contains(day time.Time) bool {
+	return !day.Before(internship.model.StartOn) && !day.After(internship.model.EndOn)
+}
+
+func (internship *Internship) ended() bool {
+	return !time.Now().Before(internship.model.EndOn.AddDate(0, 0, 1))
+}
+
+func (internship *Internship) close(context context.Context, transaction *database.Transaction, actor *User) error {
+	if !actor.is("administrator") && actor.model.UUID != internship.model.InstructorUUID && !internship.coordinatedBy(actor) {
+		return ErrInternshipCloseForbidden
+	}
+	if internship.model.closed() {
+		return ErrInternshipClosed
+	}
+
+	errUpdateModel := internship.model.updateClosedOn(context, transaction)
+	if errUpdateModel != nil {
+		return errUpdateModel
+	}
+
+	return nil
+}
+
+func (internship *Internship) reassign(context context.Context, transaction *database.Transaction, actor *User, instructorUser *User) error {
+	if !actor.is("administrator") && !internship.coordinatedBy(actor) {
+		return ErrInternshipReassignForbidden
+	}
+	if !instructorUser.holds("instructor") {
+		return ErrUserNotInstructor
+	}
+	if internship.model.closed() {
+		return ErrInternshipClosed
+	}
+
+	errUpdateModel := internship.model.updateInstructorUUID(context, transaction, instructorUser.model.UUID)
+	if errUpdateModel != nil {
+		return errUpdateModel
+	}
+
+	var errGetInstructor error
+	internship.instructor, errGetInstructor = getInstructorByUser(context, transaction, instructorUser)
+	if errGetInstructor != nil {
+		return errGetInstructor
+	}
+
+	return nil
+}
+
+func (internship *Internship) MarshalJSON() ([]byte, error) {
+	if !internship.valid {
+		panic(ErrInternshipInvalid)
+	}
+
+	internshipMap := map[string]any{
+		"uuid":       internship.model.UUID,
+		"student":    internship.student,
+		"instructor": internship.instructor,
+		"supervisor": internship.supervisor,
+		"startOn":    internship.model.StartOn,
+		"endOn":      internship.model.EndOn,
+	}
+	if internship.model.ClosedOn.Valid {
+		internshipMap["closedOn"] = internship.model.ClosedOn.Time
+	}
+
+	return json.Marshal(internshipMap)
+}
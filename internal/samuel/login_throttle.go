@@ -0,0 +1,261 @@
+package samuel
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sorucoder/samuel/internal/configuration"
+	"github.com/sorucoder/samuel/internal/database"
+)
+
+type failedLoginSummaryModel struct {
+	Count           int64        `db:"count"`
+	LastAttemptedOn sql.NullTime `db:"last_attempted_on"`
+}
+
+func insertFailedLoginModel(context context.Context, transaction *database.Transaction, identity string, address string) error {
+	_, errInsert := transaction.Execute(context, "INSERT INTO `failed_logins` (`identity`, `address`) VALUE (?, ?)", identity, address)
+	if errInsert != nil {
+		return errInsert
+	}
+
+	return nil
+}
+
+func getFailedLoginSummaryModelByIdentity(context context.Context, transaction *database.Transaction, identity string, window time.Duration) (*failedLoginSummaryModel, error) {
+	model := new(failedLoginSummaryModel)
+
+	errGet := transaction.Get(context, model, "SELECT COUNT(*) AS `count`, MAX(`attempted_on`) AS `last_attempted_on` FROM `failed_logins` WHERE `identity` = ? AND `attempted_on` > DATE_SUB(NOW(), INTERVAL ? SECOND) FOR UPDATE", identity, int64(window.Seconds()))
+	if errGet != nil {
+		return nil, errGet
+	}
+
+	return model, nil
+}
+
+func getFailedLoginSummaryModelByAddress(context context.Context, transaction *database.Transaction, address string, window time.Duration) (*failedLoginSummaryModel, error) {
+	model := new(failedLoginSummaryModel)
+
+	errGet := transaction.Get(context, model, "SELECT COUNT(*) AS `count`, MAX(`attempted_on`) AS `last_attempted_on` FROM `failed_logins` WHERE `address` = ? AND `attempted_on` > DATE_SUB(NOW(), INTERVAL ? SECOND) FOR UPDATE", address, int64(window.Seconds()))
+	if errGet != nil {
+		return nil, errGet
+	}
+
+	return model, nil
+}
+
+func deleteFailedLoginModelsByIdentity(context context.Context, transaction *database.Transaction, identity string) error {
+	_, errDelete := transaction.Execute(context, "DELETE FROM `failed_logins` WHERE `identity` = ?", identity)
+	if errDelete != nil {
+		return errDelete
+	}
+
+	return nil
+}
+
+func (model *failedLoginSummaryModel) retryAfter(threshold int64) time.Duration {
+	if model.Count < threshold || !model.LastAttemptedOn.Valid {
+		return 0
+	}
+
+	backoff := configuration.Application.GetDuration("loginBackoff")
+	maximumBackoff := configuration.Application.GetDuration("loginMaximumBackoff")
+	for doublings := model.Count - threshold; doublings > 0 && backoff < maximumBackoff; doublings-- {
+		backoff *= 2
+	}
+	backoff = min(backoff, maximumBackoff)
+
+	return time.Until(model.LastAttemptedOn.Time.Add(backoff))
+}
+
+type LoginThrottledError struct {
+	retryAfter time.Duration
+	locked     bool
+}
+
+func (loginThrottledError *LoginThrottledError) RetryAfter() time.Duration {
+	return loginThrottledError.retryAfter
+}
+
+func (loginThrottledError *LoginThrottledError) Locked() bool {
+	return loginThrottledError.locked
+}
+
+func (loginThrottledError *LoginThrottledError) Error() string {
+	if loginThrottledError.locked {
+		return fmt.Sprintf("user locked for %s", loginThrottledError.retryAfter.Round(time.Second))
+	}
+
+	return fmt.Sprintf("login throttled for %s", loginThrottledError.retryAfter.Round(time.Second))
+}
+
+var (
+	ErrUserNotLocked error = errors.New("user not locked")
+)
+
+func checkLoginThrottle(context context.Context, transaction *database.Transaction, identity string, address string) error {
+	window := configuration.Application.GetDuration("loginWindow")
+
+	identitySummary, errGetIdentitySummary := getFailedLoginSummaryModelByIdentity(context, transaction, identity, window)
+	if errGetIdentitySummary != nil {
+		return errGetIdentitySummary
+	}
+
+	addressSummary, errGetAddressSummary := getFailedLoginSummaryModelByAddress(context, transaction, address, window)
+	if errGetAddressSummary != nil {
+		return errGetAddressSummary
+	}
+
+	retryAfter := max(identitySummary.retryAfter(configuration.Application.GetInt64("loginIdentityThreshold")), addressSummary.retryAfter(configuration.Application.GetInt64("loginAddressThreshold")))
+	if retryAfter > 0 {
+		return &LoginThrottledError{retryAfter: retryAfter}
+	}
+
+	return nil
+}
+
+func (user *User) checkLocked() error {
+	if !user.model.locked() {
+		return nil
+	}
+
+	return &LoginThrottledError{
+		retryAfter: time.Until(user.model.LockedUntil.Time),
+		locked:     true,
+	}
+}
+
+func recordFailedLoginAttempt(context context.Context, transaction *database.Transaction, identity string, address string, description string, user *User) error {
+	errInsertModel := insertFailedLoginModel(context, transaction, identity, address)
+	if errInsertModel != nil {
+		return errInsertModel
+	}
+
+	var errRecord error
+	if user != nil {
+		errRecord = recordAudit(context, transaction, description, user)
+	} else {
+		errRecord = recordAnonymousAudit(context, transaction, description)
+	}
+	if errRecord != nil {
+		return errRecord
+	}
+
+	if user == nil || user.model.locked() {
+		return nil
+	}
+
+	summary, errGetSummary := getFailedLoginSummaryModelByIdentity(context, transaction, identity, configuration.Application.GetDuration("loginWindow"))
+	if errGetSummary != nil {
+		return errGetSummary
+	}
+	if summary.Count < configuration.Application.GetInt64("loginLockoutThreshold") {
+		return nil
+	}
+
+	lockoutDuration := configuration.Application.GetDuration("loginLockoutDuration")
+
+	errLock := user.model.updateLockedUntil(context, transaction, lockoutDuration)
+	if errLock != nil {
+		return errLock
+	}
+
+	return recordAudit(context, transaction, fmt.Sprintf("Locked for %s after %d failed logins.", lockoutDuration, summary.Count), user)
+}
+
+func failLogin(context context.Context, transaction *database.Transaction, identity string, address string, user *User, cause error) error {
+	errRecord := recordFailedLoginAttempt(context, transaction, identity, address, fmt.Sprintf("Failed login as %q from %s.", identity, address), user)
+	if errRecord != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return errors.Join(cause, errRecord, errRollback)
+		}
+
+		return errors.Join(cause, errRecord)
+	}
+
+	errCommit := transaction.Commit()
+	if errCommit != nil {
+		return errors.Join(cause, errCommit)
+	}
+
+	return cause
+}
+
+func UnlockUser(context context.Context, actor *User, userUUID uuid.UUID) (*User, error) {
+	if !actor.valid {
+		panic(ErrUserInvalid)
+	}
+	if !actor.is("administrator") {
+		return nil, ErrUserNotAdministrator
+	}
+
+	errPing := database.Ping(context)
+	if errPing != nil {
+		return nil, errPing
+	}
+
+	transaction, errBegin := database.Begin(context)
+	if errBegin != nil {
+		return nil, errBegin
+	}
+
+	user, errGetUser := getUserByUUID(context, transaction, userUUID)
+	if errGetUser != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errGetUser, errRollback)
+		}
+
+		return nil, errGetUser
+	}
+	if !user.model.locked() {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(ErrUserNotLocked, errRollback)
+		}
+
+		return nil, ErrUserNotLocked
+	}
+
+	errUnlock := user.model.updateLockedUntil(context, transaction, 0)
+	if errUnlock != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errUnlock, errRollback)
+		}
+
+		return nil, errUnlock
+	}
+
+	errDeleteFailedLogins := deleteFailedLoginModelsByIdentity(context, transaction, user.model.Identity)
+	if errDeleteFailedLogins != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errDeleteFailedLogins, errRollback)
+		}
+
+		return nil, errDeleteFailedLogins
+	}
+
+	errRecord := recordAudit(context, transaction, fmt.Sprintf("Unlocked user %s.", user.model.UUID), actor)
+	if errRecord != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errRecord, errRollback)
+		}
+
+		return nil, errRecord
+	}
+
+	errCommit := transaction.Commit()
+	if errCommit != nil {
+		return nil, errCommit
+	}
+
+	return user, nil
+}
@@ -0,0 +1,466 @@
+package samuel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/sorucoder/samuel/internal/database"
+)
+
+const (
+	messageMaximumLength int = 1000
+)
+
+func countInternshipModelsByParticipants(context context.Context, transaction *database.Transaction, firstUserUUID uuid.UUID, secondUserUUID uuid.UUID) (int64, error) {
+	var count int64
+
+	errGet := transaction.Get(context, &count, "SELECT COUNT(*) FROM `internships` WHERE ? IN (`student_uuid`, `instructor_uuid`, `supervisor_uuid`) AND ? IN (`student_uuid`, `instructor_uuid`, `supervisor_uuid`)", firstUserUUID, secondUserUUID)
+	if errGet != nil {
+		return 0, errGet
+	}
+
+	return count, nil
+}
+
+func selectPersonalNotificationModelsByThreadUUID(context context.Context, transaction *database.Transaction, threadUUID uuid.UUID) ([]*notificationModel, error) {
+	models := make([]*notificationModel, 0)
+
+	errSelect := transaction.Select(context, &models, "SELECT * FROM `notifications` WHERE `type` = 'personal' AND `thread_uuid` = ? ORDER BY `sent_on` ASC", threadUUID)
+	if errSelect != nil {
+		return nil, errSelect
+	}
+
+	return models, nil
+}
+
+func selectPersonalNotificationModelsByUserUUID(context context.Context, transaction *database.Transaction, userUUID uuid.UUID, number int, limit int) ([]*notificationModel, error) {
+	offset := number * limit
+
+	models := make([]*notificationModel, 0, limit)
+
+	errSelect := transaction.Select(context, &models, "SELECT * FROM `notifications` WHERE `type` = 'personal' AND (`from_user_uuid` = ? OR `to_user_uuid` = ?) ORDER BY `sent_on` DESC LIMIT ? OFFSET ?", userUUID, userUUID, limit, offset)
+	if errSelect != nil {
+		return nil, errSelect
+	}
+
+	return models, nil
+}
+
+func countPersonalNotificationModelsByUserUUID(context context.Context, transaction *database.Transaction, userUUID uuid.UUID) (int64, error) {
+	var count int64
+
+	errGet := transaction.Get(context, &count, "SELECT COUNT(*) FROM `notifications` WHERE `type` = 'personal' AND (`from_user_uuid` = ? OR `to_user_uuid` = ?)", userUUID, userUUID)
+	if errGet != nil {
+		return 0, errGet
+	}
+
+	return count, nil
+}
+
+var (
+	ErrMessageNotRelated     error = errors.New("message not related")
+	ErrMessageNotParticipant error = errors.New("message not participant")
+	ErrMessageNotPersonal    error = errors.New("message not personal")
+	ErrMessageLogForbidden   error = errors.New("message log forbidden")
+)
+
+func validateMessage(message string) (string, error) {
+	message = strings.TrimSpace(message)
+
+	validation := newValidationError()
+	if message == "" {
+		validation.add("message", "must not be empty")
+	} else if utf8.RuneCountInString(message) > messageMaximumLength {
+		validation.add("message", fmt.Sprintf("must be at most %d characters", messageMaximumLength))
+	}
+	if !validation.empty() {
+		return "", validation
+	}
+
+	return message, nil
+}
+
+func related(context context.Context, transaction *database.Transaction, firstUser *User, secondUser *User) (bool, error) {
+	if firstUser.model.UUID == secondUser.model.UUID {
+		return false, nil
+	}
+
+	count, errCount := countInternshipModelsByParticipants(context, transaction, firstUser.model.UUID, secondUser.model.UUID)
+	if errCount != nil {
+		return false, errCount
+	}
+
+	return count > 0, nil
+}
+
+func newMessage(context context.Context, transaction *database.Transaction, sender *User, recipient *User, message string, replyTo *notificationModel) (*Notification, error) {
+	message, errValidate := validateMessage(message)
+	if errValidate != nil {
+		return nil, errValidate
+	}
+
+	isRelated, errRelated := related(context, transaction, sender, recipient)
+	if errRelated != nil {
+		return nil, errRelated
+	}
+	if !isRelated {
+		return nil, ErrMessageNotRelated
+	}
+
+	model, errInsertModel := insertPersonalNotificationModel(context, transaction, sender.model.UUID, recipient.model.UUID, message, replyTo)
+	if errInsertModel != nil {
+		return nil, errInsertModel
+	}
+
+	notification := &Notification{
+		model: model,
+		from:  sender,
+		to:    recipient,
+		valid: true,
+	}
+
+	errPublish := publishEvent(transaction, recipient.model.UUID, "notification", notification)
+	if errPublish != nil {
+		return nil, errPublish
+	}
+
+	return notification, nil
+}
+
+func loadMessages(context context.Context, transaction *database.Transaction, models []*notificationModel) ([]*Notification, error) {
+	users := make(map[uuid.UUID]*User)
+	loadUser := func(userUUID uuid.UUID) (*User, error) {
+		user, loaded := users[userUUID]
+		if !loaded {
+			var errGetUser error
+			user, errGetUser = getUserByUUID(context, transaction, userUUID)
+			if errGetUser != nil {
+				return nil, errGetUser
+			}
+			users[userUUID] = user
+		}
+		return user, nil
+	}
+
+	messages := make([]*Notification, 0, len(models))
+	for _, model := range models {
+		sender, errGetSender := loadUser(model.FromUserUUID)
+		if errGetSender != nil {
+			return nil, errGetSender
+		}
+
+		recipient, errGetRecipient := loadUser(model.ToUserUUID)
+		if errGetRecipient != nil {
+			return nil, errGetRecipient
+		}
+
+		messages = append(messages, &Notification{
+			model: model,
+			from:  sender,
+			to:    recipient,
+			valid: true,
+		})
+	}
+
+	return messages, nil
+}
+
+func SendMessage(context context.Context, sender *User, recipientUUID uuid.UUID, message string) (*Notification, error) {
+	if !sender.valid {
+		panic(ErrUserInvalid)
+	}
+
+	errPing := database.Ping(context)
+	if errPing != nil {
+		return nil, errPing
+	}
+
+	transaction, errBegin := database.Begin(context)
+	if errBegin != nil {
+		return nil, errBegin
+	}
+
+	recipient, errGetRecipient := getUserByUUID(context, transaction, recipientUUID)
+	if errGetRecipient != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errGetRecipient, errRollback)
+		}
+
+		return nil, errGetRecipient
+	}
+
+	notification, errNewMessage := newMessage(context, transaction, sender, recipient, message, nil)
+	if errNewMessage != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errNewMessage, errRollback)
+		}
+
+		return nil, errNewMessage
+	}
+
+	errRecord := recordAudit(context, transaction, fmt.Sprintf("Sent message %s to user %s.", notification.model.UUID, recipient.model.UUID), sender)
+	if errRecord != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errRecord, errRollback)
+		}
+
+		return nil, errRecord
+	}
+
+	errCommit := transaction.Commit()
+	if errCommit != nil {
+		return nil, errCommit
+	}
+
+	return notification, nil
+}
+
+func ReplyToMessage(context context.Context, sender *User, replyToUUID uuid.UUID, message string) (*Notification, error) {
+	if !sender.valid {
+		panic(ErrUserInvalid)
+	}
+
+	errPing := database.Ping(context)
+	if errPing != nil {
+		return nil, errPing
+	}
+
+	transaction, errBegin := database.Begin(context)
+	if errBegin != nil {
+		return nil, errBegin
+	}
+
+	replyTo, errGetReplyTo := getNotificationModelByUUID(context, transaction, replyToUUID)
+	if errGetReplyTo != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errGetReplyTo, errRollback)
+		}
+
+		return nil, errGetReplyTo
+	}
+
+	var recipientUUID uuid.UUID
+	switch {
+	case replyTo.Type != "personal":
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(ErrMessageNotPersonal, errRollback)
+		}
+
+		return nil, ErrMessageNotPersonal
+	case replyTo.ToUserUUID == sender.model.UUID:
+		recipientUUID = replyTo.FromUserUUID
+	case replyTo.FromUserUUID == sender.model.UUID:
+		recipientUUID = replyTo.ToUserUUID
+	default:
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(ErrMessageNotParticipant, errRollback)
+		}
+
+		return nil, ErrMessageNotParticipant
+	}
+
+	recipient, errGetRecipient := getUserByUUID(context, transaction, recipientUUID)
+	if errGetRecipient != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errGetRecipient, errRollback)
+		}
+
+		return nil, errGetRecipient
+	}
+
+	notification, errNewMessage := newMessage(context, transaction, sender, recipient, message, replyTo)
+	if errNewMessage != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errNewMessage, errRollback)
+		}
+
+		return nil, errNewMessage
+	}
+
+	errRecord := recordAudit(context, transaction, fmt.Sprintf("Sent message %s to user %s in reply to message %s.", notification.model.UUID, recipient.model.UUID, replyTo.UUID), sender)
+	if errRecord != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errRecord, errRollback)
+		}
+
+		return nil, errRecord
+	}
+
+	errCommit := transaction.Commit()
+	if errCommit != nil {
+		return nil, errCommit
+	}
+
+	return notification, nil
+}
+
+func GetMessageThread(context context.Context, user *User, messageUUID uuid.UUID) ([]*Notification, error) {
+	if !user.valid {
+		panic(ErrUserInvalid)
+	}
+
+	errPing := database.Ping(context)
+	if errPing != nil {
+		return nil, errPing
+	}
+
+	transaction, errBegin := database.Begin(context)
+	if errBegin != nil {
+		return nil, errBegin
+	}
+
+	model, errGetModel := getNotificationModelByUUID(context, transaction, messageUUID)
+	if errGetModel != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errGetModel, errRollback)
+		}
+
+		return nil, errGetModel
+	}
+
+	if model.Type != "personal" || !model.ThreadUUID.Valid {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(ErrMessageNotPersonal, errRollback)
+		}
+
+		return nil, ErrMessageNotPersonal
+	}
+	if !user.is("administrator") && model.FromUserUUID != user.model.UUID && model.ToUserUUID != user.model.UUID {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(ErrMessageNotParticipant, errRollback)
+		}
+
+		return nil, ErrMessageNotParticipant
+	}
+
+	models, errSelectModels := selectPersonalNotificationModelsByThreadUUID(context, transaction, model.ThreadUUID.UUID)
+	if errSelectModels != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errSelectModels, errRollback)
+		}
+
+		return nil, errSelectModels
+	}
+
+	if !user.is("administrator") {
+		visibleModels := make([]*notificationModel, 0, len(models))
+		for _, threadModel := range models {
+			// Participants do not see messages they deleted from their own inbox.
+			if threadModel.DeletedOn.Valid && threadModel.ToUserUUID == user.model.UUID {
+				continue
+			}
+			visibleModels = append(visibleModels, threadModel)
+		}
+		models = visibleModels
+	}
+
+	messages, errLoadMessages := loadMessages(context, transaction, models)
+	if errLoadMessages != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errLoadMessages, errRollback)
+		}
+
+		return nil, errLoadMessages
+	}
+
+	errCommit := transaction.Commit()
+	if errCommit != nil {
+		return nil, errCommit
+	}
+
+	return messages, nil
+}
+
+func GetMessageLogBatchByUser(context context.Context, administrator *User, userUUID uuid.UUID, batchNumber int, batchSize int) (*Batch[*Notification], error) {
+	if !administrator.valid {
+		panic(ErrUserInvalid)
+	}
+	if !administrator.is("administrator") {
+		return nil, ErrMessageLogForbidden
+	}
+
+	errPing := database.Ping(context)
+	if errPing != nil {
+		return nil, errPing
+	}
+
+	transaction, errBegin := database.Begin(context)
+	if errBegin != nil {
+		return nil, errBegin
+	}
+
+	_, errGetUser := getUserModelByUUID(context, transaction, userUUID)
+	if errGetUser != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errGetUser, errRollback)
+		}
+
+		return nil, errGetUser
+	}
+
+	total, errCountModels := countPersonalNotificationModelsByUserUUID(context, transaction, userUUID)
+	if errCountModels != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errCountModels, errRollback)
+		}
+
+		return nil, errCountModels
+	}
+
+	models, errSelectModels := selectPersonalNotificationModelsByUserUUID(context, transaction, userUUID, batchNumber, batchSize)
+	if errSelectModels != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errSelectModels, errRollback)
+		}
+
+		return nil, errSelectModels
+	}
+
+	messages, errLoadMessages := loadMessages(context, transaction, models)
+	if errLoadMessages != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errLoadMessages, errRollback)
+		}
+
+		return nil, errLoadMessages
+	}
+
+	errRecord := recordAudit(context, transaction, fmt.Sprintf("Viewed message log of user %s.", userUUID), administrator)
+	if errRecord != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errRecord, errRollback)
+		}
+
+		return nil, errRecord
+	}
+
+	errCommit := transaction.Commit()
+	if errCommit != nil {
+		return nil, errCommit
+	}
+
+	return newBatch(batchNumber, batchSize, total, "messages", messages...), nil
+}
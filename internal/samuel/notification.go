@@ -0,0 +1,492 @@
+package samuel
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sorucoder/samuel/internal/database"
+)
+
+type notificationModel struct {
+	UUID         uuid.UUID     `db:"uuid"`
+	FromUserUUID uuid.UUID     `db:"from_user_uuid"`
+	ToUserUUID   uuid.UUID     `db:"to_user_uuid"`
+	Message      string        `db:"message"`
+	SentOn       time.Time     `db:"sent_on"`
+	Seen         bool          `db:"seen"`
+	SeenOn       sql.NullTime  `db:"seen_on"`
+	Type         string        `db:"type"`
+	ThreadUUID   uuid.NullUUID `db:"thread_uuid"`
+	ReplyToUUID  uuid.NullUUID `db:"reply_to_uuid"`
+	DeletedOn    sql.NullTime  `db:"deleted_on"`
+}
+
+func insertNotificationModel(context context.Context, transaction *database.Transaction, notificationFromUserUUID uuid.UUID, notificationToUserUUID uuid.UUID, notificationMessage string, notificationType string) (*notificationModel, error) {
+	notificationUUID := uuid.New()
+
+	_, errInsert := transaction.Execute(context, "INSERT INTO `notifications` (`uuid`, `from_user_uuid`, `to_user_uuid`, `message`, `type`) VALUE (?, ?, ?, ?, ?)", notificationUUID, notificationFromUserUUID, notificationToUserUUID, notificationMessage, notificationType)
+	if errInsert != nil {
+		return nil, errInsert
+	}
+
+	return getNotificationModelByUUID(context, transaction, notificationUUID)
+}
+
+func insertPersonalNotificationModel(context context.Context, transaction *database.Transaction, notificationFromUserUUID uuid.UUID, notificationToUserUUID uuid.UUID, notificationMessage string, notificationReplyTo *notificationModel) (*notificationModel, error) {
+	notificationUUID := uuid.New()
+
+	threadUUID := uuid.NullUUID{UUID: notificationUUID, Valid: true}
+	var replyToUUID uuid.NullUUID
+	if notificationReplyTo != nil {
+		threadUUID = notificationReplyTo.ThreadUUID
+		replyToUUID = uuid.NullUUID{UUID: notificationReplyTo.UUID, Valid: true}
+	}
+
+	_, errInsert := transaction.Execute(context, "INSERT INTO `notifications` (`uuid`, `from_user_uuid`, `to_user_uuid`, `message`, `type`, `thread_uuid`, `reply_to_uuid`) VALUE (?, ?, ?, ?, 'personal', ?, ?)", notificationUUID, notificationFromUserUUID, notificationToUserUUID, notificationMessage, threadUUID, replyToUUID)
+	if errInsert != nil {
+		return nil, errInsert
+	}
+
+	return getNotificationModelByUUID(context, transaction, notificationUUID)
+}
+
+func getNotificationModelByUUID(context context.Context, transaction *database.Transaction, notificationUUID uuid.UUID) (*notificationModel, error) {
+	model := new(notificationModel)
+
+	errGet := transaction.Get(context, model, "SELECT * FROM `notifications` WHERE `uuid` = ?", notificationUUID)
+	if errGet != nil {
+		return nil, errGet
+	}
+
+	return model, nil
+}
+
+func selectNotificationModelsByToUserUUID(context context.Context, transaction *database.Transaction, notificationToUserUUID uuid.UUID, unseenOnly bool, number int, limit int) ([]*notificationModel, error) {
+	var queryBuilder strings.Builder
+	queryBuilder.WriteString("SELECT * FROM `notifications` WHERE `to_user_uuid` = ? AND `deleted_on` IS NULL")
+	if unseenOnly {
+		queryBuilder.WriteString(" AND `seen` = FALSE")
+	}
+	queryBuilder.WriteString(" ORDER BY `sent_on` DESC LIMIT ? OFFSET ?")
+
+	offset := number * limit
+
+	models := make([]*notificationModel, 0, limit)
+
+	errSelect := transaction.Select(context, &models, queryBuilder.String(), notificationToUserUUID, limit, offset)
+	if errSelect != nil {
+		return nil, errSelect
+	}
+
+	return models, nil
+}
+
+func countNotificationModelsByToUserUUID(context context.Context, transaction *database.Transaction, notificationToUserUUID uuid.UUID, unseenOnly bool) (int64, error) {
+	var queryBuilder strings.Builder
+	queryBuilder.WriteString("SELECT COUNT(*) FROM `notifications` WHERE `to_user_uuid` = ? AND `deleted_on` IS NULL")
+	if unseenOnly {
+		queryBuilder.WriteString(" AND `seen` = FALSE")
+	}
+
+	var count int64
+
+	errGet := transaction.Get(context, &count, queryBuilder.String(), notificationToUserUUID)
+	if errGet != nil {
+		return 0, errGet
+	}
+
+	return count, nil
+}
+
+func updateNotificationModelsSeenByToUserUUID(context context.Context, transaction *database.Transaction, notificationToUserUUID uuid.UUID) (int64, error) {
+	result, errUpdate := transaction.Execute(context, "UPDATE `notifications` SET `seen` = TRUE, `seen_on` = NOW() WHERE `to_user_uuid` = ? AND `seen` = FALSE AND `deleted_on` IS NULL", notificationToUserUUID)
+	if errUpdate != nil {
+		return 0, errUpdate
+	}
+
+	return result.RowsAffected(), nil
+}
+
+func updateNotificationModelsSeenByToUserUUIDAndUUIDs(context context.Context, transaction *database.Transaction, notificationToUserUUID uuid.UUID, notificationUUIDs []uuid.UUID) (int64, error) {
+	var queryBuilder strings.Builder
+	queryBuilder.WriteString("UPDATE `notifications` SET `seen` = TRUE, `seen_on` = NOW() WHERE `to_user_uuid` = ? AND `seen` = FALSE AND `deleted_on` IS NULL AND `uuid` IN (?")
+	queryBuilder.WriteString(strings.Repeat(", ?", len(notificationUUIDs)-1))
+	queryBuilder.WriteString(")")
+	arguments := []any{notificationToUserUUID}
+	for _, notificationUUID := range notificationUUIDs {
+		arguments = append(arguments, notificationUUID)
+	}
+
+	result, errUpdate := transaction.Execute(context, queryBuilder.String(), arguments...)
+	if errUpdate != nil {
+		return 0, errUpdate
+	}
+
+	return result.RowsAffected(), nil
+}
+
+func (model *notificationModel) updateSeen(context context.Context, transaction *database.Transaction) error {
+	_, errUpdate := transaction.Execute(context, "UPDATE `notifications` SET `seen` = TRUE, `seen_on` = NOW() WHERE `uuid` = ?", model.UUID)
+	if errUpdate != nil {
+		return errUpdate
+	}
+
+	errGet := transaction.Get(context, model, "SELECT * FROM `notifications` WHERE `uuid` = ?", model.UUID)
+	if errGet != nil {
+		return errGet
+	}
+
+	return nil
+}
+
+func (model *notificationModel) updateDeletedOn(context context.Context, transaction *database.Transaction) error {
+	_, errUpdate := transaction.Execute(context, "UPDATE `notifications` SET `deleted_on` = NOW() WHERE `uuid` = ?", model.UUID)
+	if errUpdate != nil {
+		return errUpdate
+	}
+
+	errGetDeletedOn := transaction.Get(context, &model.DeletedOn, "SELECT `deleted_on` FROM `notifications` WHERE `uuid` = ?", model.UUID)
+	if errGetDeletedOn != nil {
+		return errGetDeletedOn
+	}
+
+	return nil
+}
+
+type Notification struct {
+	model *notificationModel
+	from  *User
+	to    *User
+	valid bool
+}
+
+var (
+	ErrNotificationInvalid      error = errors.New("notification invalid")
+	ErrNotificationNotRecipient error = errors.New("notification not recipient")
+	ErrNotificationsUnspecified error = errors.New("notifications unspecified")
+)
+
+func notifyUser(context context.Context, transaction *database.Transaction, notificationMessage string, sender *User, recipient *User) error {
+	model, errInsertModel := insertNotificationModel(context, transaction, sender.model.UUID, recipient.model.UUID, notificationMessage, "system")
+	if errInsertModel != nil {
+		return errInsertModel
+	}
+
+	notification := &Notification{
+		model: model,
+		from:  sender,
+		valid: true,
+	}
+
+	errPublish := publishEvent(transaction, recipient.model.UUID, "notification", notification)
+	if errPublish != nil {
+		return errPublish
+	}
+
+	return nil
+}
+
+func getNotificationByUUID(context context.Context, transaction *database.Transaction, recipient *User, notificationUUID uuid.UUID) (*Notification, error) {
+	model, errGetModel := getNotificationModelByUUID(context, transaction, notificationUUID)
+	if errGetModel != nil {
+		return nil, errGetModel
+	}
+	if model.DeletedOn.Valid {
+		return nil, sql.ErrNoRows
+	}
+	if model.ToUserUUID != recipient.model.UUID {
+		return nil, ErrNotificationNotRecipient
+	}
+
+	notification := new(Notification)
+	notification.model = model
+
+	var errGetFrom error
+	notification.from, errGetFrom = getUserByUUID(context, transaction, model.FromUserUUID)
+	if errGetFrom != nil {
+		return nil, errGetFrom
+	}
+
+	notification.valid = true
+
+	return notification, nil
+}
+
+func getNotificationBatchByUser(context context.Context, transaction *database.Transaction, recipient *User, unseenOnly bool, page int, count int) (*Batch[*Notification], error) {
+	notifications := make([]*Notification, 0, count)
+
+	notificationModelCount, errCountModels := countNotificationModelsByToUserUUID(context, transaction, recipient.model.UUID, unseenOnly)
+	if errCountModels != nil {
+		return nil, errCountModels
+	}
+
+	notificationModels, errGetModels := selectNotificationModelsByToUserUUID(context, transaction, recipient.model.UUID, unseenOnly, page, count)
+	if errGetModels != nil {
+		return nil, errGetModels
+	}
+
+	senders := make(map[uuid.UUID]*User)
+	for _, notificationModel := range notificationModels {
+		sender, loaded := senders[notificationModel.FromUserUUID]
+		if !loaded {
+			var errGetSender error
+			sender, errGetSender = getUserByUUID(context, transaction, notificationModel.FromUserUUID)
+			if errGetSender != nil {
+				return nil, errGetSender
+			}
+			senders[notificationModel.FromUserUUID] = sender
+		}
+
+		notifications = append(notifications, &Notification{
+			model: notificationModel,
+			from:  sender,
+			valid: true,
+		})
+	}
+
+	return newBatch(page, count, notificationModelCount, "notifications", notifications...), nil
+}
+
+func GetNotificationBatchByUser(context context.Context, user *User, unseenOnly bool, batchNumber int, batchSize int) (*Batch[*Notification], error) {
+	if !user.valid {
+		panic(ErrUserInvalid)
+	}
+
+	errPing := database.Ping(context)
+	if errPing != nil {
+		return nil, errPing
+	}
+
+	transaction, errBegin := database.Begin(context)
+	if errBegin != nil {
+		return nil, errBegin
+	}
+
+	notificationBatch, errGetNotificationBatch := getNotificationBatchByUser(context, transaction, user, unseenOnly, batchNumber, batchSize)
+	if errGetNotificationBatch != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errGetNotificationBatch, errRollback)
+		}
+
+		return nil, errGetNotificationBatch
+	}
+
+	errCommit := transaction.Commit()
+	if errCommit != nil {
+		return nil, errCommit
+	}
+
+	return notificationBatch, nil
+}
+
+func CountUnseenNotifications(context context.Context, user *User) (int64, error) {
+	if !user.valid {
+		panic(ErrUserInvalid)
+	}
+
+	errPing := database.Ping(context)
+	if errPing != nil {
+		return 0, errPing
+	}
+
+	transaction, errBegin := database.Begin(context)
+	if errBegin != nil {
+		return 0, errBegin
+	}
+
+	count, errCount := countNotificationModelsByToUserUUID(context, transaction, user.model.UUID, true)
+	if errCount != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return 0, errors.Join(errCount, errRollback)
+		}
+
+		return 0, errCount
+	}
+
+	errCommit := transaction.Commit()
+	if errCommit != nil {
+		return 0, errCommit
+	}
+
+	return count, nil
+}
+
+func MarkNotificationSeen(context context.Context, user *User, notificationUUID uuid.UUID) (*Notification, error) {
+	if !user.valid {
+		panic(ErrUserInvalid)
+	}
+
+	errPing := database.Ping(context)
+	if errPing != nil {
+		return nil, errPing
+	}
+
+	transaction, errBegin := database.Begin(context)
+	if errBegin != nil {
+		return nil, errBegin
+	}
+
+	notification, errGetNotification := getNotificationByUUID(context, transaction, user, notificationUUID)
+	if errGetNotification != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errGetNotification, errRollback)
+		}
+
+		return nil, errGetNotification
+	}
+
+	if !notification.model.Seen {
+		errUpdateModel := notification.model.updateSeen(context, transaction)
+		if errUpdateModel != nil {
+			errRollback := transaction.Rollback()
+			if errRollback != nil {
+				return nil, errors.Join(errUpdateModel, errRollback)
+			}
+
+			return nil, errUpdateModel
+		}
+	}
+
+	errCommit := transaction.Commit()
+	if errCommit != nil {
+		return nil, errCommit
+	}
+
+	return notification, nil
+}
+
+func MarkNotificationsSeen(context context.Context, user *User, notificationUUIDs []uuid.UUID, all bool) (int64, error) {
+	if !user.valid {
+		panic(ErrUserInvalid)
+	}
+	if !all && len(notificationUUIDs) == 0 {
+		return 0, ErrNotificationsUnspecified
+	}
+
+	errPing := database.Ping(context)
+	if errPing != nil {
+		return 0, errPing
+	}
+
+	transaction, errBegin := database.Begin(context)
+	if errBegin != nil {
+		return 0, errBegin
+	}
+
+	var count int64
+	var errUpdate error
+	if all {
+		count, errUpdate = updateNotificationModelsSeenByToUserUUID(context, transaction, user.model.UUID)
+	} else {
+		count, errUpdate = updateNotificationModelsSeenByToUserUUIDAndUUIDs(context, transaction, user.model.UUID, notificationUUIDs)
+	}
+	if errUpdate != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return 0, errors.Join(errUpdate, errRollback)
+		}
+
+		return 0, errUpdate
+	}
+
+	errCommit := transaction.Commit()
+	if errCommit != nil {
+		return 0, errCommit
+	}
+
+	return count, nil
+}
+
+func DeleteNotification(context context.Context, user *User, notificationUUID uuid.UUID) error {
+	if !user.valid {
+		panic(ErrUserInvalid)
+	}
+
+	errPing := database.Ping(context)
+	if errPing != nil {
+		return errPing
+	}
+
+	transaction, errBegin := database.Begin(context)
+	if errBegin != nil {
+		return errBegin
+	}
+
+	notification, errGetNotification := getNotificationByUUID(context, transaction, user, notificationUUID)
+	if errGetNotification != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return errors.Join(errGetNotification, errRollback)
+		}
+
+		return errGetNotification
+	}
+
+	errDeleteModel := notification.model.updateDeletedOn(context, transaction)
+	if errDeleteModel != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return errors.Join(errDeleteModel, errRollback)
+		}
+
+		return errDeleteModel
+	}
+
+	errRecord := recordAudit(context, transaction, fmt.Sprintf("Deleted notification %s.", notification.model.UUID), user)
+	if errRecord != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return errors.Join(errRecord, errRollback)
+		}
+
+		return errRecord
+	}
+
+	errCommit := transaction.Commit()
+	if errCommit != nil {
+		return errCommit
+	}
+
+	return nil
+}
+
+func (notification *Notification) MarshalJSON() ([]byte, error) {
+	if !notification.valid {
+		panic(ErrNotificationInvalid)
+	}
+
+	notificationMap := map[string]any{
+		"uuid":    notification.model.UUID,
+		"from":    notification.from,
+		"message": notification.model.Message,
+		"sentOn":  notification.model.SentOn,
+		"seen":    notification.model.Seen,
+		"type":    notification.model.Type,
+	}
+	if notification.model.SeenOn.Valid {
+		notificationMap["seenOn"] = notification.model.SeenOn.Time
+	}
+	if notification.to != nil {
+		notificationMap["to"] = notification.to
+	}
+	if notification.model.ThreadUUID.Valid {
+		notificationMap["threadUUID"] = notification.model.ThreadUUID.UUID
+	}
+	if notification.model.ReplyToUUID.Valid {
+		notificationMap["replyToUUID"] = notification.model.ReplyToUUID.UUID
+	}
+	if notification.model.DeletedOn.Valid {
+		notificationMap["deletedOn"] = notification.model.DeletedOn.Time
+	}
+
+	return json.Marshal(notificationMap)
+}
@@ -2,15 +2,38 @@ package samuel
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/sorucoder/samuel/internal/database"
 )
 
+const (
+	programNameMaximumLength int = 255
+)
+
+var (
+	programIDPattern *regexp.Regexp = regexp.MustCompile(`^[a-z]{2,4}$`)
+)
+
 type programModel struct {
-	ID   string `db:"id"`
-	Name string `db:"name"`
+	ID        string       `db:"id"`
+	Name      string       `db:"name"`
+	RetiredOn sql.NullTime `db:"retired_on"`
+}
+
+func insertProgramModel(context context.Context, transaction *database.Transaction, programID string, programName string) (*programModel, error) {
+	_, errInsert := transaction.Execute(context, "INSERT INTO `programs` (`id`, `name`) VALUE (?, ?)", programID, programName)
+	if errInsert != nil {
+		return nil, errInsert
+	}
+
+	return getProgramModelByID(context, transaction, programID)
 }
 
 func getProgramModelByID(context context.Context, transaction *database.Transaction, programID string) (*programModel, error) {
@@ -24,6 +47,64 @@ func getProgramModelByID(context context.Context, transaction *database.Transact
 	return model, nil
 }
 
+func countProgramModelsByID(context context.Context, transaction *database.Transaction, programID string) (int64, error) {
+	var count int64
+
+	errGet := transaction.Get(context, &count, "SELECT COUNT(*) FROM `programs` WHERE `id` = ?", programID)
+	if errGet != nil {
+		return 0, errGet
+	}
+
+	return count, nil
+}
+
+func selectProgramModels(context context.Context, transaction *database.Transaction, includeRetired bool) ([]*programModel, error) {
+	var queryBuilder strings.Builder
+	queryBuilder.WriteString("SELECT * FROM `programs`")
+	if !includeRetired {
+		queryBuilder.WriteString(" WHERE `retired_on` IS NULL")
+	}
+	queryBuilder.WriteString(" ORDER BY `name`")
+
+	models := make([]*programModel, 0)
+
+	errSelect := transaction.Select(context, &models, queryBuilder.String())
+	if errSelect != nil {
+		return nil, errSelect
+	}
+
+	return models, nil
+}
+
+func (model *programModel) retired() bool {
+	return model.RetiredOn.Valid
+}
+
+func (model *programModel) updateName(context context.Context, transaction *database.Transaction, newProgramName string) error {
+	_, errUpdate := transaction.Execute(context, "UPDATE `programs` SET `name` = ? WHERE `id` = ?", newProgramName, model.ID)
+	if errUpdate != nil {
+		return errUpdate
+	}
+
+	model.Name = newProgramName
+
+	return nil
+}
+
+func (model *programModel) updateRetiredOn(context context.Context, transaction *database.Transaction) error {
+	_, errUpdate := transaction.Execute(context, "UPDATE `programs` SET `retired_on` = NOW() WHERE `id` = ?", model.ID)
+	if errUpdate != nil {
+		return errUpdate
+	}
+
+	errGetRetiredOn := transaction.Get(context, &model.RetiredOn, "SELECT `retired_on` FROM `programs` WHERE `id` = ?", model.ID)
+	if errGetRetiredOn != nil {
+		return errGetRetiredOn
+	}
+
+	return nil
+}
+
 type Program struct {
 	model *programModel
 	valid bool
@@ -31,8 +112,68 @@ type Program struct {
 
 var (
 	ErrProgramInvalid error = errors.New("invalid program")
+	ErrProgramExists  error = errors.New("program exists")
+	ErrProgramRetired error = errors.New("program retired")
 )
 
+func validateProgramName(validation *ValidationError, name string) string {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		validation.add("name", "must not be empty")
+	} else if utf8.RuneCountInString(name) > programNameMaximumLength {
+		validation.add("name", fmt.Sprintf("must be at most %d characters", programNameMaximumLength))
+	}
+
+	return name
+}
+
+func newProgram(context context.Context, transaction *database.Transaction, programID string, name string) (*Program, error) {
+	programID = strings.TrimSpace(programID)
+
+	validation := newValidationError()
+	if !programIDPattern.MatchString(programID) {
+		validation.add("id", "must be two to four lowercase letters")
+	}
+	name = validateProgramName(validation, name)
+	if !validation.empty() {
+		return nil, validation
+	}
+
+	programCount, errCountModels := countProgramModelsByID(context, transaction, programID)
+	if errCountModels != nil {
+		return nil, errCountModels
+	}
+	if programCount > 0 {
+		return nil, ErrProgramExists
+	}
+
+	program := new(Program)
+
+	var errInsertModel error
+	program.model, errInsertModel = insertProgramModel(context, transaction, programID, name)
+	if errInsertModel != nil {
+		return nil, errInsertModel
+	}
+
+	program.valid = true
+
+	return program, nil
+}
+
+func getProgramByID(context context.Context, transaction *database.Transaction, programID string) (*Program, error) {
+	program := new(Program)
+
+	var errGetModel error
+	program.model, errGetModel = getProgramModelByID(context, transaction, programID)
+	if errGetModel != nil {
+		return nil, errGetModel
+	}
+
+	program.valid = true
+
+	return program, nil
+}
+
 func getProgramByStudent(context context.Context, transaction *database.Transaction, student *Student) (*Program, error) {
 	program := new(Program)
 
@@ -47,10 +188,262 @@ func getProgramByStudent(context context.Context, transaction *database.Transact
 	return program, nil
 }
 
+func (program *Program) rename(context context.Context, transaction *database.Transaction, name string) error {
+	if program.model.retired() {
+		return ErrProgramRetired
+	}
+
+	validation := newValidationError()
+	name = validateProgramName(validation, name)
+	if !validation.empty() {
+		return validation
+	}
+
+	errUpdateModel := program.model.updateName(context, transaction, name)
+	if errUpdateModel != nil {
+		return errUpdateModel
+	}
+
+	return nil
+}
+
+func (program *Program) retire(context context.Context, transaction *database.Transaction) error {
+	if program.model.retired() {
+		return ErrProgramRetired
+	}
+
+	activeStudentCount, errCountActiveStudents := countActiveStudentModelsByProgramID(context, transaction, program.model.ID)
+	if errCountActiveStudents != nil {
+		return errCountActiveStudents
+	}
+
+	openInternshipCount, errCountOpenInternships := countOpenInternshipModelsByProgramID(context, transaction, program.model.ID)
+	if errCountOpenInternships != nil {
+		return errCountOpenInternships
+	}
+
+	references := newReferenceError("program")
+	references.add("active students", activeStudentCount)
+	references.add("open internships", openInternshipCount)
+	if !references.empty() {
+		return references
+	}
+
+	errUpdateModel := program.model.updateRetiredOn(context, transaction)
+	if errUpdateModel != nil {
+		return errUpdateModel
+	}
+
+	return nil
+}
+
+func GetPrograms(context context.Context, includeRetired bool) ([]*Program, error) {
+	errPing := database.Ping(context)
+	if errPing != nil {
+		return nil, errPing
+	}
+
+	transaction, errBegin := database.Begin(context)
+	if errBegin != nil {
+		return nil, errBegin
+	}
+
+	models, errSelectModels := selectProgramModels(context, transaction, includeRetired)
+	if errSelectModels != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errSelectModels, errRollback)
+		}
+
+		return nil, errSelectModels
+	}
+
+	errCommit := transaction.Commit()
+	if errCommit != nil {
+		return nil, errCommit
+	}
+
+	programs := make([]*Program, 0, len(models))
+	for _, model := range models {
+		programs = append(programs, &Program{
+			model: model,
+			valid: true,
+		})
+	}
+
+	return programs, nil
+}
+
+func CreateProgram(context context.Context, actor *User, programID string, name string) (*Program, error) {
+	if !actor.valid {
+		panic(ErrUserInvalid)
+	}
+	if !actor.is("administrator") {
+		return nil, ErrUserNotAdministrator
+	}
+
+	errPing := database.Ping(context)
+	if errPing != nil {
+		return nil, errPing
+	}
+
+	transaction, errBegin := database.Begin(context)
+	if errBegin != nil {
+		return nil, errBegin
+	}
+
+	program, errNewProgram := newProgram(context, transaction, programID, name)
+	if errNewProgram != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errNewProgram, errRollback)
+		}
+
+		return nil, errNewProgram
+	}
+
+	errRecord := recordAudit(context, transaction, fmt.Sprintf("Created program %s.", program.model.ID), actor)
+	if errRecord != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errRecord, errRollback)
+		}
+
+		return nil, errRecord
+	}
+
+	errCommit := transaction.Commit()
+	if errCommit != nil {
+		return nil, errCommit
+	}
+
+	return program, nil
+}
+
+func UpdateProgram(context context.Context, actor *User, programID string, name string) (*Program, error) {
+	if !actor.valid {
+		panic(ErrUserInvalid)
+	}
+	if !actor.is("administrator") {
+		return nil, ErrUserNotAdministrator
+	}
+
+	errPing := database.Ping(context)
+	if errPing != nil {
+		return nil, errPing
+	}
+
+	transaction, errBegin := database.Begin(context)
+	if errBegin != nil {
+		return nil, errBegin
+	}
+
+	program, errGetProgram := getProgramByID(context, transaction, programID)
+	if errGetProgram != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errGetProgram, errRollback)
+		}
+
+		return nil, errGetProgram
+	}
+
+	errRename := program.rename(context, transaction, name)
+	if errRename != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errRename, errRollback)
+		}
+
+		return nil, errRename
+	}
+
+	errRecord := recordAudit(context, transaction, fmt.Sprintf("Updated program %s.", program.model.ID), actor)
+	if errRecord != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errRecord, errRollback)
+		}
+
+		return nil, errRecord
+	}
+
+	errCommit := transaction.Commit()
+	if errCommit != nil {
+		return nil, errCommit
+	}
+
+	return program, nil
+}
+
+func RetireProgram(context context.Context, actor *User, programID string) (*Program, error) {
+	if !actor.valid {
+		panic(ErrUserInvalid)
+	}
+	if !actor.is("administrator") {
+		return nil, ErrUserNotAdministrator
+	}
+
+	errPing := database.Ping(context)
+	if errPing != nil {
+		return nil, errPing
+	}
+
+	transaction, errBegin := database.Begin(context)
+	if errBegin != nil {
+		return nil, errBegin
+	}
+
+	program, errGetProgram := getProgramByID(context, transaction, programID)
+	if errGetProgram != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errGetProgram, errRollback)
+		}
+
+		return nil, errGetProgram
+	}
+
+	errRetire := program.retire(context, transaction)
+	if errRetire != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errRetire, errRollback)
+		}
+
+		return nil, errRetire
+	}
+
+	errRecord := recordAudit(context, transaction, fmt.Sprintf("Retired program %s.", program.model.ID), actor)
+	if errRecord != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errRecord, errRollback)
+		}
+
+		return nil, errRecord
+	}
+
+	errCommit := transaction.Commit()
+	if errCommit != nil {
+		return nil, errCommit
+	}
+
+	return program, nil
+}
+
 func (program *Program) MarshalJSON() ([]byte, error) {
 	if !program.valid {
 		panic(ErrProgramInvalid)
 	}
 
-	return json.Marshal(program.model.Name)
+	programMap := map[string]any{
+		"id":   program.model.ID,
+		"name": program.model.Name,
+	}
+	if program.model.RetiredOn.Valid {
+		programMap["retiredOn"] = program.model.RetiredOn.Time
+	}
+
+	return json.Marshal(programMap)
 }
@@ -0,0 +1,588 @@
+package samuel
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/sorucoder/samuel/internal/database"
+)
+
+type programEvaluationQuestionModel struct {
+	ProgramID string         `db:"program_id"`
+	Number    uint8          `db:"number"`
+	Question  sql.NullString `db:"question"`
+	Position  uint8          `db:"position"`
+	RetiredOn sql.NullTime   `db:"retired_on"`
+}
+
+func insertProgramEvaluationQuestionModel(context context.Context, transaction *database.Transaction, programID string, question string) (*programEvaluationQuestionModel, error) {
+	// The schema requires question numbers to be greater than one, so numbering starts at two.
+	var number uint8
+	errGetNumber := transaction.Get(context, &number, "SELECT COALESCE(MAX(`number`), 1) + 1 FROM `program_evaluation_questions` WHERE `program_id` = ?", programID)
+	if errGetNumber != nil {
+		return nil, errGetNumber
+	}
+
+	var position uint8
+	errGetPosition := transaction.Get(context, &position, "SELECT COALESCE(MAX(`position`), 0) + 1 FROM `program_evaluation_questions` WHERE `program_id` = ? AND `retired_on` IS NULL", programID)
+	if errGetPosition != nil {
+		return nil, errGetPosition
+	}
+
+	_, errInsert := transaction.Execute(context, "INSERT INTO `program_evaluation_questions` (`program_id`, `number`, `question`, `position`) VALUE (?, ?, ?, ?)", programID, number, question, position)
+	if errInsert != nil {
+		return nil, errInsert
+	}
+
+	return getProgramEvaluationQuestionModelByProgramIDAndNumber(context, transaction, programID, number)
+}
+
+func getProgramEvaluationQuestionModelByProgramIDAndNumber(context context.Context, transaction *database.Transaction, programID string, number uint8) (*programEvaluationQuestionModel, error) {
+	model := new(programEvaluationQuestionModel)
+
+	errGet := transaction.Get(context, model, "SELECT * FROM `program_evaluation_questions` WHERE `program_id` = ? AND `number` = ?", programID, number)
+	if errGet != nil {
+		return nil, errGet
+	}
+
+	return model, nil
+}
+
+func selectProgramEvaluationQuestionModelsByProgramID(context context.Context, transaction *database.Transaction, programID string, includeRetired bool) ([]*programEvaluationQuestionModel, error) {
+	var queryBuilder strings.Builder
+	queryBuilder.WriteString("SELECT * FROM `program_evaluation_questions` WHERE `program_id` = ?")
+	if !includeRetired {
+		queryBuilder.WriteString(" AND `retired_on` IS NULL")
+	}
+	queryBuilder.WriteString(" ORDER BY `retired_on` IS NOT NULL, `position`, `number`")
+
+	models := make([]*programEvaluationQuestionModel, 0)
+
+	errSelect := transaction.Select(context, &models, queryBuilder.String(), programID)
+	if errSelect != nil {
+		return nil, errSelect
+	}
+
+	return models, nil
+}
+
+func (model *programEvaluationQuestionModel) retired() bool {
+	return model.RetiredOn.Valid
+}
+
+func (model *programEvaluationQuestionModel) updatePosition(context context.Context, transaction *database.Transaction, newPosition uint8) error {
+	_, errUpdate := transaction.Execute(context, "UPDATE `program_evaluation_questions` SET `position` = ? WHERE `program_id` = ? AND `number` = ?", newPosition, model.ProgramID, model.Number)
+	if errUpdate != nil {
+		return errUpdate
+	}
+
+	model.Position = newPosition
+
+	return nil
+}
+
+func (model *programEvaluationQuestionModel) updateQuestion(context context.Context, transaction *database.Transaction, newQuestion string) error {
+	_, errUpdate := transaction.Execute(context, "UPDATE `program_evaluation_questions` SET `question` = ? WHERE `program_id` = ? AND `number` = ?", newQuestion, model.ProgramID, model.Number)
+	if errUpdate != nil {
+		return errUpdate
+	}
+
+	model.Question = sql.NullString{String: newQuestion, Valid: true}
+
+	return nil
+}
+
+func (model *programEvaluationQuestionModel) updateRetiredOn(context context.Context, transaction *database.Transaction) error {
+	_, errUpdate := transaction.Execute(context, "UPDATE `program_evaluation_questions` SET `retired_on` = NOW(), `position` = 0 WHERE `program_id` = ? AND `number` = ?", model.ProgramID, model.Number)
+	if errUpdate != nil {
+		return errUpdate
+	}
+
+	errGet := transaction.Get(context, model, "SELECT * FROM `program_evaluation_questions` WHERE `program_id` = ? AND `number` = ?", model.ProgramID, model.Number)
+	if errGet != nil {
+		return errGet
+	}
+
+	return nil
+}
+
+type supervisorProgramEvaluationResponseModel struct {
+	InternshipUUID    uuid.UUID      `db:"internship_uuid"`
+	QuestionProgramID string         `db:"question_program_id"`
+	QuestionNumber    uint8          `db:"question_number"`
+	Question          sql.NullString `db:"question"`
+	Response          sql.NullString `db:"response"`
+}
+
+func insertSupervisorProgramEvaluationResponseModel(context context.Context, transaction *database.Transaction, internshipUUID uuid.UUID, questionProgramID string, questionNumber uint8, response *string) error {
+	_, errInsert := transaction.Execute(context, "INSERT INTO `supervisor_program_evaluation_responses` (`internship_uuid`, `question_program_id`, `question_number`, `response`) VALUE (?, ?, ?, ?)", internshipUUID, questionProgramID, questionNumber, response)
+	if errInsert != nil {
+		return errInsert
+	}
+
+	return nil
+}
+
+func countSupervisorProgramEvaluationResponseModelsByQuestion(context context.Context, transaction *database.Transaction, questionProgramID string, questionNumber uint8) (int64, error) {
+	var count int64
+
+	errGet := transaction.Get(context, &count, "SELECT COUNT(*) FROM `supervisor_program_evaluation_responses` WHERE `question_program_id` = ? AND `question_number` = ?", questionProgramID, questionNumber)
+	if errGet != nil {
+		return 0, errGet
+	}
+
+	return count, nil
+}
+
+func selectSupervisorProgramEvaluationResponseModelsByInternshipUUID(context context.Context, transaction *database.Transaction, internshipUUID uuid.UUID) ([]*supervisorProgramEvaluationResponseModel, error) {
+	models := make([]*supervisorProgramEvaluationResponseModel, 0)
+
+	errSelect := transaction.Select(
+		context,
+		&models,
+		"SELECT `responses`.`internship_uuid`, `responses`.`question_program_id`, `responses`.`question_number`, `questions`.`question`, `responses`.`response` FROM `supervisor_program_evaluation_responses` AS `responses` INNER JOIN `program_evaluation_questions` AS `questions` ON `questions`.`program_id` = `responses`.`question_program_id` AND `questions`.`number` = `responses`.`question_number` WHERE `responses`.`internship_uuid` = ? ORDER BY `questions`.`position`, `questions`.`number`",
+		internshipUUID,
+	)
+	if errSelect != nil {
+		return nil, errSelect
+	}
+
+	return models, nil
+}
+
+func (model *supervisorProgramEvaluationResponseModel) MarshalJSON() ([]byte, error) {
+	responseMap := map[string]any{
+		"number": model.QuestionNumber,
+	}
+	if model.Question.Valid {
+		responseMap["question"] = model.Question.String
+	}
+	if model.Response.Valid {
+		responseMap["response"] = model.Response.String
+	}
+
+	return json.Marshal(responseMap)
+}
+
+type ProgramEvaluationQuestion struct {
+	model *programEvaluationQuestionModel
+	valid bool
+}
+
+var (
+	ErrProgramEvaluationQuestionInvalid   error = errors.New("program evaluation question invalid")
+	ErrProgramEvaluationQuestionForbidden error = errors.New("program evaluation question forbidden")
+	ErrProgramEvaluationQuestionRetired   error = errors.New("program evaluation question retired")
+	ErrProgramEvaluationQuestionAnswered  error = errors.New("program evaluation question answered")
+)
+
+func canManageProgramEvaluationQuestions(user *User, programID string) bool {
+	return user.is("administrator") || user.coordinatesProgram(programID)
+}
+
+func newProgramEvaluationQuestion(context context.Context, transaction *database.Transaction, programID string, question string) (*ProgramEvaluationQuestion, error) {
+	question = strings.TrimSpace(question)
+	if question == "" {
+		validation := newValidationError()
+		validation.add("question", "must not be empty")
+		return nil, validation
+	}
+
+	programEvaluationQuestion := new(ProgramEvaluationQuestion)
+
+	var errInsertModel error
+	programEvaluationQuestion.model, errInsertModel = insertProgramEvaluationQuestionModel(context, transaction, programID, question)
+	if errInsertModel != nil {
+		return nil, errInsertModel
+	}
+
+	programEvaluationQuestion.valid = true
+
+	return programEvaluationQuestion, nil
+}
+
+func getProgramEvaluationQuestionByProgramIDAndNumber(context context.Context, transaction *database.Transaction, programID string, number uint8) (*ProgramEvaluationQuestion, error) {
+	programEvaluationQuestion := new(ProgramEvaluationQuestion)
+
+	var errGetModel error
+	programEvaluationQuestion.model, errGetModel = getProgramEvaluationQuestionModelByProgramIDAndNumber(context, transaction, programID, number)
+	if errGetModel != nil {
+		return nil, errGetModel
+	}
+
+	programEvaluationQuestion.valid = true
+
+	return programEvaluationQuestion, nil
+}
+
+func getProgramEvaluationQuestionsByProgramID(context context.Context, transaction *database.Transaction, programID string, includeRetired bool) ([]*ProgramEvaluationQuestion, error) {
+	models, errSelectModels := selectProgramEvaluationQuestionModelsByProgramID(context, transaction, programID, includeRetired)
+	if errSelectModels != nil {
+		return nil, errSelectModels
+	}
+
+	programEvaluationQuestions := make([]*ProgramEvaluationQuestion, 0, len(models))
+	for _, model := range models {
+		programEvaluationQuestions = append(programEvaluationQuestions, &ProgramEvaluationQuestion{
+			model: model,
+			valid: true,
+		})
+	}
+
+	return programEvaluationQuestions, nil
+}
+
+func beginProgramEvaluationQuestionManagement(context context.Context, user *User, programID string) (*database.Transaction, error) {
+	errPing := database.Ping(context)
+	if errPing != nil {
+		return nil, errPing
+	}
+
+	transaction, errBegin := database.Begin(context)
+	if errBegin != nil {
+		return nil, errBegin
+	}
+
+	_, errGetProgram := getProgramModelByID(context, transaction, programID)
+	if errGetProgram != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errGetProgram, errRollback)
+		}
+
+		return nil, errGetProgram
+	}
+
+	if !canManageProgramEvaluationQuestions(user, programID) {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(ErrProgramEvaluationQuestionForbidden, errRollback)
+		}
+
+		return nil, ErrProgramEvaluationQuestionForbidden
+	}
+
+	return transaction, nil
+}
+
+func GetProgramEvaluationQuestions(context context.Context, user *User, programID string, includeRetired bool) ([]*ProgramEvaluationQuestion, error) {
+	if !user.valid {
+		panic(ErrUserInvalid)
+	}
+
+	transaction, errBegin := beginProgramEvaluationQuestionManagement(context, user, programID)
+	if errBegin != nil {
+		return nil, errBegin
+	}
+
+	programEvaluationQuestions, errGetProgramEvaluationQuestions := getProgramEvaluationQuestionsByProgramID(context, transaction, programID, includeRetired)
+	if errGetProgramEvaluationQuestions != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errGetProgramEvaluationQuestions, errRollback)
+		}
+
+		return nil, errGetProgramEvaluationQuestions
+	}
+
+	errCommit := transaction.Commit()
+	if errCommit != nil {
+		return nil, errCommit
+	}
+
+	return programEvaluationQuestions, nil
+}
+
+func CreateProgramEvaluationQuestion(context context.Context, actor *User, programID string, question string) (*ProgramEvaluationQuestion, error) {
+	if !actor.valid {
+		panic(ErrUserInvalid)
+	}
+
+	transaction, errBegin := beginProgramEvaluationQuestionManagement(context, actor, programID)
+	if errBegin != nil {
+		return nil, errBegin
+	}
+
+	programEvaluationQuestion, errNewProgramEvaluationQuestion := newProgramEvaluationQuestion(context, transaction, programID, question)
+	if errNewProgramEvaluationQuestion != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errNewProgramEvaluationQuestion, errRollback)
+		}
+
+		return nil, errNewProgramEvaluationQuestion
+	}
+
+	errRecord := recordAudit(context, transaction, fmt.Sprintf("Created program evaluation question %d for program %s.", programEvaluationQuestion.model.Number, programID), actor)
+	if errRecord != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errRecord, errRollback)
+		}
+
+		return nil, errRecord
+	}
+
+	errCommit := transaction.Commit()
+	if errCommit != nil {
+		return nil, errCommit
+	}
+
+	return programEvaluationQuestion, nil
+}
+
+func ReorderProgramEvaluationQuestions(context context.Context, actor *User, programID string, numbers []uint8) ([]*ProgramEvaluationQuestion, error) {
+	if !actor.valid {
+		panic(ErrUserInvalid)
+	}
+
+	transaction, errBegin := beginProgramEvaluationQuestionManagement(context, actor, programID)
+	if errBegin != nil {
+		return nil, errBegin
+	}
+
+	programEvaluationQuestions, errGetProgramEvaluationQuestions := getProgramEvaluationQuestionsByProgramID(context, transaction, programID, false)
+	if errGetProgramEvaluationQuestions != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errGetProgramEvaluationQuestions, errRollback)
+		}
+
+		return nil, errGetProgramEvaluationQuestions
+	}
+
+	errReorder := reorderProgramEvaluationQuestions(context, transaction, programEvaluationQuestions, numbers)
+	if errReorder != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errReorder, errRollback)
+		}
+
+		return nil, errReorder
+	}
+
+	errRecord := recordAudit(context, transaction, fmt.Sprintf("Reordered program evaluation questions for program %s.", programID), actor)
+	if errRecord != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errRecord, errRollback)
+		}
+
+		return nil, errRecord
+	}
+
+	reorderedProgramEvaluationQuestions, errGetReorderedProgramEvaluationQuestions := getProgramEvaluationQuestionsByProgramID(context, transaction, programID, false)
+	if errGetReorderedProgramEvaluationQuestions != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errGetReorderedProgramEvaluationQuestions, errRollback)
+		}
+
+		return nil, errGetReorderedProgramEvaluationQuestions
+	}
+
+	errCommit := transaction.Commit()
+	if errCommit != nil {
+		return nil, errCommit
+	}
+
+	return reorderedProgramEvaluationQuestions, nil
+}
+
+func RetireProgramEvaluationQuestion(context context.Context, actor *User, programID string, number uint8) (*ProgramEvaluationQuestion, error) {
+	if !actor.valid {
+		panic(ErrUserInvalid)
+	}
+
+	transaction, errBegin := beginProgramEvaluationQuestionManagement(context, actor, programID)
+	if errBegin != nil {
+		return nil, errBegin
+	}
+
+	programEvaluationQuestion, errGetProgramEvaluationQuestion := getProgramEvaluationQuestionByProgramIDAndNumber(context, transaction, programID, number)
+	if errGetProgramEvaluationQuestion != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errGetProgramEvaluationQuestion, errRollback)
+		}
+
+		return nil, errGetProgramEvaluationQuestion
+	}
+
+	errRetire := programEvaluationQuestion.retire(context, transaction)
+	if errRetire != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errRetire, errRollback)
+		}
+
+		return nil, errRetire
+	}
+
+	errRecord := recordAudit(context, transaction, fmt.Sprintf("Retired program evaluation question %d for program %s.", number, programID), actor)
+	if errRecord != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errRecord, errRollback)
+		}
+
+		return nil, errRecord
+	}
+
+	errCommit := transaction.Commit()
+	if errCommit != nil {
+		return nil, errCommit
+	}
+
+	return programEvaluationQuestion, nil
+}
+
+func UpdateProgramEvaluationQuestion(context context.Context, actor *User, programID string, number uint8, question string) (*ProgramEvaluationQuestion, error) {
+	if !actor.valid {
+		panic(ErrUserInvalid)
+	}
+
+	transaction, errBegin := beginProgramEvaluationQuestionManagement(context, actor, programID)
+	if errBegin != nil {
+		return nil, errBegin
+	}
+
+	programEvaluationQuestion, errGetProgramEvaluationQuestion := getProgramEvaluationQuestionByProgramIDAndNumber(context, transaction, programID, number)
+	if errGetProgramEvaluationQuestion != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errGetProgramEvaluationQuestion, errRollback)
+		}
+
+		return nil, errGetProgramEvaluationQuestion
+	}
+
+	errUpdate := programEvaluationQuestion.update(context, transaction, question)
+	if errUpdate != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errUpdate, errRollback)
+		}
+
+		return nil, errUpdate
+	}
+
+	errRecord := recordAudit(context, transaction, fmt.Sprintf("Updated program evaluation question %d for program %s.", number, programID), actor)
+	if errRecord != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errRecord, errRollback)
+		}
+
+		return nil, errRecord
+	}
+
+	errCommit := transaction.Commit()
+	if errCommit != nil {
+		return nil, errCommit
+	}
+
+	return programEvaluationQuestion, nil
+}
+
+func reorderProgramEvaluationQuestions(context context.Context, transaction *database.Transaction, programEvaluationQuestions []*ProgramEvaluationQuestion, numbers []uint8) error {
+	questionsByNumber := make(map[uint8]*ProgramEvaluationQuestion, len(programEvaluationQuestions))
+	for _, programEvaluationQuestion := range programEvaluationQuestions {
+		questionsByNumber[programEvaluationQuestion.model.Number] = programEvaluationQuestion
+	}
+
+	validation := newValidationError()
+	if len(numbers) != len(programEvaluationQuestions) {
+		validation.add("numbers", "must list every active question exactly once")
+	}
+	seen := make(map[uint8]bool, len(numbers))
+	for _, number := range numbers {
+		if _, active := questionsByNumber[number]; !active || seen[number] {
+			validation.add("numbers", "must list every active question exactly once")
+		}
+		seen[number] = true
+	}
+	if !validation.empty() {
+		return validation
+	}
+
+	for index, number := range numbers {
+		errUpdatePosition := questionsByNumber[number].model.updatePosition(context, transaction, uint8(index+1))
+		if errUpdatePosition != nil {
+			return errUpdatePosition
+		}
+	}
+
+	return nil
+}
+
+func (programEvaluationQuestion *ProgramEvaluationQuestion) update(context context.Context, transaction *database.Transaction, question string) error {
+	question = strings.TrimSpace(question)
+	if question == "" {
+		validation := newValidationError()
+		validation.add("question", "must not be empty")
+		return validation
+	}
+	if programEvaluationQuestion.model.retired() {
+		return ErrProgramEvaluationQuestionRetired
+	}
+
+	// Submitted responses show the current wording, so answered questions must be retired and replaced instead.
+	responseCount, errCountResponses := countSupervisorProgramEvaluationResponseModelsByQuestion(context, transaction, programEvaluationQuestion.model.ProgramID, programEvaluationQuestion.model.Number)
+	if errCountResponses != nil {
+		return errCountResponses
+	}
+	if responseCount > 0 {
+		return ErrProgramEvaluationQuestionAnswered
+	}
+
+	errUpdateModel := programEvaluationQuestion.model.updateQuestion(context, transaction, question)
+	if errUpdateModel != nil {
+		return errUpdateModel
+	}
+
+	return nil
+}
+
+func (programEvaluationQuestion *ProgramEvaluationQuestion) retire(context context.Context, transaction *database.Transaction) error {
+	if programEvaluationQuestion.model.retired() {
+		return ErrProgramEvaluationQuestionRetired
+	}
+
+	errUpdateModel := programEvaluationQuestion.model.updateRetiredOn(context, transaction)
+	if errUpdateModel != nil {
+		return errUpdateModel
+	}
+
+	return nil
+}
+
+func (programEvaluationQuestion *ProgramEvaluationQuestion) MarshalJSON() ([]byte, error) {
+	if !programEvaluationQuestion.valid {
+		panic(ErrProgramEvaluationQuestionInvalid)
+	}
+
+	programEvaluationQuestionMap := map[string]any{
+		"programID": programEvaluationQuestion.model.ProgramID,
+		"number":    programEvaluationQuestion.model.Number,
+		"position":  programEvaluationQuestion.model.Position,
+	}
+	if programEvaluationQuestion.model.Question.Valid {
+		programEvaluationQuestionMap["question"] = programEvaluationQuestion.model.Question.String
+	}
+	if programEvaluationQuestion.model.RetiredOn.Valid {
+		programEvaluationQuestionMap["retiredOn"] = programEvaluationQuestion.model.RetiredOn.Time
+	}
+
+	return json.Marshal(programEvaluationQuestionMap)
+}
+
+func programEvaluationResponseField(number uint8) string {
+	return "programResponses." + strconv.FormatUint(uint64(number), 10)
+}
@@ -0,0 +1,461 @@
+package samuel
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/mail"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/sorucoder/samuel/internal/configuration"
+	"github.com/sorucoder/samuel/internal/database"
+	"github.com/sorucoder/samuel/internal/ldap"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	userIdentityMaximumLength         int = 254
+	userEmailMaximumLength            int = 254
+	userNameMaximumLength             int = 64
+	supervisorTitleMaximumLength      int = 64
+	supervisorOnboardingPasswordBytes int = 32
+)
+
+var (
+	provisionableRoles []string = []string{"administrator", "instructor", "supervisor", "student"}
+	directoryRoles     []string = []string{"administrator", "instructor", "student"}
+)
+
+var (
+	ErrUserNotProvisionable error = errors.New("user not provisionable")
+)
+
+type UserProfileContent struct {
+	FirstName   string
+	LastName    string
+	Email       string
+	Phone       string
+	Title       string
+	CompanyUUID uuid.UUID
+	CampusID    string
+	ProgramID   string
+	Address     string
+	Unit        *string
+	City        string
+	State       string
+	ZIP         string
+}
+
+func (content *UserProfileContent) normalize() {
+	content.FirstName = strings.TrimSpace(content.FirstName)
+	content.LastName = strings.TrimSpace(content.LastName)
+	content.Email = strings.TrimSpace(content.Email)
+	content.Phone = strings.TrimSpace(content.Phone)
+	content.Title = strings.TrimSpace(content.Title)
+	content.CampusID = strings.TrimSpace(content.CampusID)
+	content.ProgramID = strings.TrimSpace(content.ProgramID)
+	content.Address = strings.TrimSpace(content.Address)
+	if content.Unit != nil {
+		unit := strings.TrimSpace(*content.Unit)
+		if unit == "" {
+			content.Unit = nil
+		} else {
+			content.Unit = &unit
+		}
+	}
+	content.City = strings.TrimSpace(content.City)
+	content.State = strings.ToUpper(strings.TrimSpace(content.State))
+	content.ZIP = strings.TrimSpace(content.ZIP)
+}
+
+func (content *UserProfileContent) validate(validation *ValidationError, roleID string) {
+	content.normalize()
+
+	validateRequiredText(validation, "firstName", content.FirstName, userNameMaximumLength)
+	validateRequiredText(validation, "lastName", content.LastName, userNameMaximumLength)
+	if content.Email == "" {
+		validation.add("email", "must not be empty")
+	} else if utf8.RuneCountInString(content.Email) > userEmailMaximumLength {
+		validation.add("email", fmt.Sprintf("must be at most %d characters", userEmailMaximumLength))
+	} else if address, errParse := mail.ParseAddress(content.Email); errParse != nil || address.Address != content.Email {
+		validation.add("email", "must be a valid email address")
+	}
+	if !addressPhonePattern.MatchString(content.Phone) {
+		validation.add("phone", "must be ten digits")
+	}
+
+	switch roleID {
+	case "instructor":
+		if content.CampusID == "" {
+			validation.add("campusID", "must not be empty")
+		}
+	case "supervisor":
+		validateRequiredText(validation, "title", content.Title, supervisorTitleMaximumLength)
+		if content.CompanyUUID == uuid.Nil {
+			validation.add("companyUUID", "must not be empty")
+		}
+	case "student":
+		if content.CampusID == "" {
+			validation.add("campusID", "must not be empty")
+		}
+		if content.ProgramID == "" {
+			validation.add("programID", "must not be empty")
+		}
+		if content.Address == "" {
+			validation.add("address", "must not be empty")
+		}
+		validateRequiredText(validation, "city", content.City, addressCityMaximumLength)
+		if !slices.Contains(addressStates, content.State) {
+			validation.add("state", "must be a two letter state abbreviation")
+		}
+		if !addressZIPPattern.MatchString(content.ZIP) {
+			validation.add("zip", "must be a five or nine digit zip code")
+		}
+	}
+}
+
+func validateProfileReferences(context context.Context, transaction *database.Transaction, validation *ValidationError, roleID string, content *UserProfileContent) error {
+	if roleID == "instructor" || roleID == "student" {
+		campusModel, errGetCampusModel := getCampusModelByID(context, transaction, content.CampusID)
+		if errors.Is(errGetCampusModel, sql.ErrNoRows) || (errGetCampusModel == nil && campusModel.retired()) {
+			validation.add("campusID", "must be an active campus")
+		} else if errGetCampusModel != nil {
+			return errGetCampusModel
+		}
+	}
+	if roleID == "student" {
+		programModel, errGetProgramModel := getProgramModelByID(context, transaction, content.ProgramID)
+		if errors.Is(errGetProgramModel, sql.ErrNoRows) || (errGetProgramModel == nil && programModel.retired()) {
+			validation.add("programID", "must be an active program")
+		} else if errGetProgramModel != nil {
+			return errGetProgramModel
+		}
+	}
+	if roleID == "supervisor" {
+		companyModel, errGetCompanyModel := getCompanyModelByUUID(context, transaction, content.CompanyUUID)
+		if errors.Is(errGetCompanyModel, sql.ErrNoRows) || (errGetCompanyModel == nil && companyModel.archived()) {
+			validation.add("companyUUID", "must be an active company")
+		} else if errGetCompanyModel != nil {
+			return errGetCompanyModel
+		}
+	}
+
+	return nil
+}
+
+func unusablePasswordHash() ([]byte, error) {
+	password := make([]byte, supervisorOnboardingPasswordBytes)
+	_, errRead := rand.Read(password)
+	if errRead != nil {
+		return nil, errRead
+	}
+
+	return bcrypt.GenerateFromPassword([]byte(hex.EncodeToString(password)), 0)
+}
+
+func defaultProvisionProvider(roleID string) string {
+	if roleID == "supervisor" {
+		return "password"
+	}
+
+	return "ldap"
+}
+
+func newProvisionedUser(context context.Context, transaction *database.Transaction, identity string, provider string, roleID string, content *UserProfileContent) (*User, error) {
+	authenticator, errGetAuthenticator := getAuthenticator(provider)
+	if errGetAuthenticator != nil {
+		return nil, errGetAuthenticator
+	}
+
+	var passwordHash []byte
+	if authenticator.UsesPasswordHash() {
+		var errHash error
+		passwordHash, errHash = unusablePasswordHash()
+		if errHash != nil {
+			return nil, errHash
+		}
+	}
+
+	model, errInsertModel := insertUserModel(context, transaction, identity, provider, passwordHash, roleID)
+	if errInsertModel != nil {
+		return nil, errInsertModel
+	}
+
+	errInsertRoleModel := insertUserRoleModel(context, transaction, model.UUID, roleID)
+	if errInsertRoleModel != nil {
+		return nil, errInsertRoleModel
+	}
+
+	var errInsertProfile error
+	switch roleID {
+	case "administrator":
+		_, errInsertProfile = insertAdministratorModel(context, transaction, model.UUID, content)
+	case "instructor":
+		_, errInsertProfile = insertInstructorModel(context, transaction, model.UUID, content)
+	case "supervisor":
+		_, errInsertProfile = insertSupervisorModel(context, transaction, model.UUID, content)
+	case "student":
+		_, errInsertProfile = insertStudentModel(context, transaction, model.UUID, content)
+	}
+	if errInsertProfile != nil {
+		return nil, errInsertProfile
+	}
+
+	if !authenticator.UsesPasswordHash() {
+		errActivate := model.updateActivatedOn(context, transaction)
+		if errActivate != nil {
+			return nil, errActivate
+		}
+	}
+
+	return getUserByUUID(context, transaction, model.UUID)
+}
+
+type firstLoginProvisioningRule struct {
+	roleID   string
+	campusID string
+	group    string
+}
+
+func firstLoginProvisioningRules() []*firstLoginProvisioningRule {
+	enabledRoles := configuration.LDAP.GetStringSlice("provisionRoles")
+
+	rules := make([]*firstLoginProvisioningRule, 0)
+	for _, rawRule := range configuration.LDAP.GetStringSlice("provisionRules") {
+		parts := strings.SplitN(rawRule, ":", 3)
+		if len(parts) != 3 {
+			continue
+		}
+
+		rule := &firstLoginProvisioningRule{
+			roleID:   strings.TrimSpace(parts[0]),
+			campusID: strings.TrimSpace(parts[1]),
+			group:    strings.TrimSpace(parts[2]),
+		}
+		if !slices.Contains(directoryRoles, rule.roleID) || !slices.Contains(enabledRoles, rule.roleID) {
+			continue
+		}
+
+		rules = append(rules, rule)
+	}
+
+	return rules
+}
+
+func matchFirstLoginProvisioningRule(rules []*firstLoginProvisioningRule, groups []string) *firstLoginProvisioningRule {
+	for _, rule := range rules {
+		for _, group := range groups {
+			if strings.EqualFold(rule.group, group) {
+				return rule
+			}
+		}
+	}
+
+	return nil
+}
+
+func provisionUserOnFirstLogin(context context.Context, transaction *database.Transaction, identity string, password string) (*User, error) {
+	rules := firstLoginProvisioningRules()
+	if len(rules) == 0 {
+		return nil, ErrUserNotProvisionable
+	}
+
+	authenticator, errGetAuthenticator := getAuthenticator("ldap")
+	if errGetAuthenticator != nil {
+		return nil, errGetAuthenticator
+	}
+
+	errAuthenticate := authenticator.Authenticate(context, &Credentials{
+		Identity: identity,
+		Password: password,
+	})
+	if errAuthenticate != nil {
+		return nil, errors.Join(ErrUserCredentialsInvalid, errAuthenticate)
+	}
+
+	entry, errLookup := ldap.Lookup(context, identity)
+	if errLookup != nil {
+		return nil, errLookup
+	}
+
+	rule := matchFirstLoginProvisioningRule(rules, entry.Groups)
+	if rule == nil {
+		return nil, ErrUserNotProvisionable
+	}
+
+	content := &UserProfileContent{
+		FirstName: entry.GivenName,
+		LastName:  entry.Surname,
+		Email:     entry.Mail,
+		Phone:     normalizeDirectoryPhone(entry.TelephoneNumber),
+		CampusID:  rule.campusID,
+		ProgramID: strings.ToLower(entry.Department),
+		Address:   entry.Street,
+		City:      entry.Locality,
+		State:     entry.State,
+		ZIP:       entry.PostalCode,
+	}
+
+	validation := newValidationError()
+	content.validate(validation, rule.roleID)
+	if validation.empty() {
+		errValidateReferences := validateProfileReferences(context, transaction, validation, rule.roleID, content)
+		if errValidateReferences != nil {
+			return nil, errValidateReferences
+		}
+	}
+	if !validation.empty() {
+		return nil, errors.Join(ErrUserNotProvisionable, validation)
+	}
+
+	user, errNewUser := newProvisionedUser(context, transaction, entry.Identity, "ldap", rule.roleID, content)
+	if errNewUser != nil {
+		return nil, errNewUser
+	}
+
+	errRecord := recordAudit(context, transaction, fmt.Sprintf("Provisioned %s user on first login.", rule.roleID), user)
+	if errRecord != nil {
+		return nil, errRecord
+	}
+
+	return user, nil
+}
+
+func ProvisionUser(context context.Context, actor *User, identity string, provider string, roleID string, content *UserProfileContent) (*User, error) {
+	if !actor.valid {
+		panic(ErrUserInvalid)
+	}
+	if !actor.is("administrator") {
+		return nil, ErrUserNotAdministrator
+	}
+
+	identity = strings.TrimSpace(identity)
+	provider = strings.TrimSpace(provider)
+	roleID = strings.TrimSpace(roleID)
+	if roleID == "supervisor" && identity == "" {
+		identity = strings.TrimSpace(content.Email)
+	}
+	if provider == "" {
+		provider = defaultProvisionProvider(roleID)
+	}
+
+	validation := newValidationError()
+	validateRequiredText(validation, "identity", identity, userIdentityMaximumLength)
+	if !slices.Contains(provisionableRoles, roleID) {
+		validation.add("roleID", "must be administrator, instructor, supervisor, or student")
+	} else {
+		content.validate(validation, roleID)
+	}
+	authenticator, errGetAuthenticator := getAuthenticator(provider)
+	if errGetAuthenticator != nil {
+		validation.add("provider", "must be a registered provider")
+	} else if authenticator.UsesPasswordHash() && roleID != "supervisor" {
+		// Local passwords are set by accepting an invitation, which only supervisors receive.
+		validation.add("provider", "must not use local passwords for this role")
+	}
+	if !validation.empty() {
+		return nil, validation
+	}
+
+	if provider == "ldap" {
+		exists, errExists := ldap.Exists(context, identity)
+		if errExists != nil {
+			return nil, errExists
+		}
+		if !exists {
+			validation.add("identity", "must exist in the directory")
+			return nil, validation
+		}
+	}
+
+	errPing := database.Ping(context)
+	if errPing != nil {
+		return nil, errPing
+	}
+
+	transaction, errBegin := database.Begin(context)
+	if errBegin != nil {
+		return nil, errBegin
+	}
+
+	identityCount, errCountIdentities := countUserModelsByIdentity(context, transaction, identity)
+	if errCountIdentities != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errCountIdentities, errRollback)
+		}
+
+		return nil, errCountIdentities
+	}
+	if identityCount > 0 {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(ErrUserExists, errRollback)
+		}
+
+		return nil, ErrUserExists
+	}
+
+	errValidateReferences := validateProfileReferences(context, transaction, validation, roleID, content)
+	if errValidateReferences != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errValidateReferences, errRollback)
+		}
+
+		return nil, errValidateReferences
+	}
+	if !validation.empty() {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(validation, errRollback)
+		}
+
+		return nil, validation
+	}
+
+	user, errNewUser := newProvisionedUser(context, transaction, identity, provider, roleID, content)
+	if errNewUser != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errNewUser, errRollback)
+		}
+
+		return nil, errNewUser
+	}
+
+	if authenticator.UsesPasswordHash() {
+		_, errInvite := inviteSupervisor(context, transaction, actor, user)
+		if errInvite != nil {
+			errRollback := transaction.Rollback()
+			if errRollback != nil {
+				return nil, errors.Join(errInvite, errRollback)
+			}
+
+			return nil, errInvite
+		}
+	}
+
+	errRecord := recordAudit(context, transaction, fmt.Sprintf("Provisioned %s user %s with %s.", roleID, user.model.UUID, provider), actor)
+	if errRecord != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errRecord, errRollback)
+		}
+
+		return nil, errRecord
+	}
+
+	errCommit := transaction.Commit()
+	if errCommit != nil {
+		return nil, errCommit
+	}
+
+	return user, nil
+}
@@ -0,0 +1,28 @@
+package samuel
+
+type Rating string
+
+const (
+	RatingUnacceptable Rating = "unacceptable"
+	RatingPoor         Rating = "poor"
+	RatingSatisfactory Rating = "satisfactory"
+	RatingGood         Rating = "good"
+	RatingSuperior     Rating = "superior"
+)
+
+func (rating Rating) valid() bool {
+	switch rating {
+	case RatingUnacceptable, RatingPoor, RatingSatisfactory, RatingGood, RatingSuperior:
+		return true
+	default:
+		return false
+	}
+}
+
+func validateRatings(validation *ValidationError, ratings map[string]*Rating) {
+	for field, rating := range ratings {
+		if rating != nil && !rating.valid() {
+			validation.add(field, "must be unacceptable, poor, satisfactory, good, or superior")
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package samuel
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+type ReferenceError struct {
+	resource   string
+	references map[string]int64
+}
+
+func newReferenceError(resource string) *ReferenceError {
+	return &ReferenceError{
+		resource:   resource,
+		references: make(map[string]int64),
+	}
+}
+
+func (referenceError *ReferenceError) add(reference string, count int64) {
+	if count <= 0 {
+		return
+	}
+
+	referenceError.references[reference] = count
+}
+
+func (referenceError *ReferenceError) empty() bool {
+	return len(referenceError.references) == 0
+}
+
+func (referenceError *ReferenceError) References() map[string]int64 {
+	return referenceError.references
+}
+
+func (referenceError *ReferenceError) Error() string {
+	references := make([]string, 0, len(referenceError.references))
+	for reference := range referenceError.references {
+		references = append(references, reference)
+	}
+	sort.Strings(references)
+
+	var messageBuilder strings.Builder
+	messageBuilder.WriteString(fmt.Sprintf("%s still referenced", referenceError.resource))
+	for index, reference := range references {
+		if index == 0 {
+			messageBuilder.WriteString(" by ")
+		} else {
+			messageBuilder.WriteString(", ")
+		}
+		messageBuilder.WriteString(fmt.Sprintf("%d %s", referenceError.references[reference], reference))
+	}
+
+	return messageBuilder.String()
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 
+	"github.com/google/uuid"
 	"github.com/sorucoder/samuel/internal/database"
 )
 
@@ -25,6 +26,17 @@ func getRoleModelByID(context context.Context, transaction *database.Transaction
 	return model, nil
 }
 
+func selectRoleModelsByUserUUID(context context.Context, transaction *database.Transaction, userUUID uuid.UUID) ([]*roleModel, error) {
+	models := make([]*roleModel, 0)
+
+	errSelect := transaction.Select(context, &models, "SELECT `roles`.* FROM `roles` JOIN `user_roles` ON `user_roles`.`role_id` = `roles`.`id` WHERE `user_roles`.`user_uuid` = ? ORDER BY `roles`.`priority`", userUUID)
+	if errSelect != nil {
+		return nil, errSelect
+	}
+
+	return models, nil
+}
+
 type Role struct {
 	model *roleModel
 	valid bool
@@ -2,6 +2,7 @@ package samuel
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
 	"errors"
 	"time"
@@ -11,10 +12,11 @@ import (
 )
 
 type sessionModel struct {
-	Token     uuid.UUID `db:"token"`
-	UserUUID  uuid.UUID `db:"user_uuid"`
-	StartedOn time.Time `db:"started_on"`
-	ExpiresOn time.Time `db:"expires_on"`
+	Token        uuid.UUID      `db:"token"`
+	UserUUID     uuid.UUID      `db:"user_uuid"`
+	StartedOn    time.Time      `db:"started_on"`
+	ExpiresOn    time.Time      `db:"expires_on"`
+	ActiveRoleID sql.NullString `db:"active_role_id"`
 }
 
 func insertSessionModel(context context.Context, transaction *database.Transaction, sessionUserUUID uuid.UUID) (*sessionModel, error) {
@@ -73,6 +75,17 @@ func (model *sessionModel) updateExpiresOn(context context.Context, transaction
 	return nil
 }
 
+func (model *sessionModel) updateActiveRoleID(context context.Context, transaction *database.Transaction, activeRoleID string) error {
+	_, errUpdate := transaction.Execute(context, "UPDATE `sessions` SET `active_role_id` = ? WHERE `token` = ?", activeRoleID, model.Token)
+	if errUpdate != nil {
+		return errUpdate
+	}
+
+	model.ActiveRoleID = sql.NullString{String: activeRoleID, Valid: true}
+
+	return nil
+}
+
 func (model *sessionModel) delete(context context.Context, transaction *database.Transaction) error {
 	_, errDelete := transaction.Execute(context, "DELETE FROM `sessions` WHERE `token` = ?", model.Token)
 	if errDelete != nil {
@@ -183,6 +196,14 @@ func continueSession(context context.Context, transaction *database.Transaction,
 	return session, nil
 }
 
+func (session *Session) Token() uuid.UUID {
+	return session.model.Token
+}
+
+func (session *Session) ExpiresOn() time.Time {
+	return session.model.ExpiresOn
+}
+
 func (session *Session) expired() bool {
 	return session.model.expired()
 }
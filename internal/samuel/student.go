@@ -25,6 +25,15 @@ type studentModel struct {
 	ProgramID string          `db:"program_id"`
 }
 
+func insertStudentModel(context context.Context, transaction *database.Transaction, studentUserUUID uuid.UUID, studentProfile *UserProfileContent) (*studentModel, error) {
+	_, errInsert := transaction.Execute(context, "INSERT INTO `students` (`user_uuid`, `first_name`, `last_name`, `address`, `unit`, `city`, `state`, `zip`, `email`, `phone`, `campus_id`, `program_id`) VALUE (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)", studentUserUUID, studentProfile.FirstName, studentProfile.LastName, studentProfile.Address, studentProfile.Unit, studentProfile.City, studentProfile.State, studentProfile.ZIP, studentProfile.Email, studentProfile.Phone, studentProfile.CampusID, studentProfile.ProgramID)
+	if errInsert != nil {
+		return nil, errInsert
+	}
+
+	return getStudentModelByUserUUID(context, transaction, studentUserUUID)
+}
+
 func getStudentModelByUserUUID(context context.Context, transaction *database.Transaction, studentUserUUID uuid.UUID) (*studentModel, error) {
 	model := new(studentModel)
 
@@ -36,6 +45,54 @@ func getStudentModelByUserUUID(context context.Context, transaction *database.Tr
 	return model, nil
 }
 
+func countStudentModelsByEmail(context context.Context, transaction *database.Transaction, studentEmail string) (int64, error) {
+	var count int64
+
+	errGet := transaction.Get(context, &count, "SELECT COUNT(*) FROM `students` WHERE `email` = ?", studentEmail)
+	if errGet != nil {
+		return 0, errGet
+	}
+
+	return count, nil
+}
+
+func countActiveStudentModelsByCampusID(context context.Context, transaction *database.Transaction, studentCampusID string) (int64, error) {
+	var count int64
+
+	errGet := transaction.Get(context, &count, "SELECT COUNT(*) FROM `students` WHERE `campus_id` = ? AND (NOT EXISTS (SELECT 1 FROM `internships` WHERE `internships`.`student_uuid` = `students`.`user_uuid`) OR EXISTS (SELECT 1 FROM `internships` WHERE `internships`.`student_uuid` = `students`.`user_uuid` AND `internships`.`closed_on` IS NULL))", studentCampusID)
+	if errGet != nil {
+		return 0, errGet
+	}
+
+	return count, nil
+}
+
+func countActiveStudentModelsByProgramID(context context.Context, transaction *database.Transaction, studentProgramID string) (int64, error) {
+	var count int64
+
+	errGet := transaction.Get(context, &count, "SELECT COUNT(*) FROM `students` WHERE `program_id` = ? AND (NOT EXISTS (SELECT 1 FROM `internships` WHERE `internships`.`student_uuid` = `students`.`user_uuid`) OR EXISTS (SELECT 1 FROM `internships` WHERE `internships`.`student_uuid` = `students`.`user_uuid` AND `internships`.`closed_on` IS NULL))", studentProgramID)
+	if errGet != nil {
+		return 0, errGet
+	}
+
+	return count, nil
+}
+
+func (model *studentModel) updateDirectoryProfile(context context.Context, transaction *database.Transaction, profile *directoryProfile) error {
+	_, errUpdate := transaction.Execute(context, "UPDATE `students` SET `first_name` = ?, `last_name` = ?, `email` = ?, `phone` = ?, `program_id` = ? WHERE `user_uuid` = ?", profile.FirstName, profile.LastName, profile.Email, profile.Phone, profile.ProgramID, model.UserUUID)
+	if errUpdate != nil {
+		return errUpdate
+	}
+
+	model.FirstName = profile.FirstName
+	model.LastName = profile.LastName
+	model.Email = profile.Email
+	model.Phone = profile.Phone
+	model.ProgramID = profile.ProgramID
+
+	return nil
+}
+
 type Student struct {
 	model   *studentModel
 	campus  *Campus
@@ -77,7 +134,7 @@ func GetStudentByUser(context context.Context, user *User) (*Student, error) {
 	if !user.valid {
 		panic(ErrUserInvalid)
 	}
-	if !user.model.is("student") {
+	if !user.holds("student") {
 		panic(ErrUserNotStudent)
 	}
 
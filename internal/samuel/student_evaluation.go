@@ -0,0 +1,473 @@
+package samuel
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sorucoder/samuel/internal/database"
+)
+
+const (
+	studentEvaluationOpeningDays int = 7
+)
+
+type StudentEvaluationContent struct {
+	CompanyInformationResponse           *string `db:"company_information_response"`
+	MajorResponsibilitiesResponse        *string `db:"major_responsibilities_response"`
+	AccomplishmentResponse               *string `db:"accomplishment_response"`
+	AcademicTrainingBenefitsResponse     *string `db:"academic_training_benefits_response"`
+	AcademicTrainingImprovementsResponse *string `db:"academic_training_improvements_response"`
+	SkillDevelopmentResponse             *string `db:"skill_development_response"`
+	AttitudeChangeResponse               *string `db:"attitude_change_response"`
+	CommentsResponse                     *string `db:"comments_response"`
+}
+
+func (content *StudentEvaluationContent) responses() map[string]*string {
+	return map[string]*string{
+		"companyInformationResponse":           content.CompanyInformationResponse,
+		"majorResponsibilitiesResponse":        content.MajorResponsibilitiesResponse,
+		"accomplishmentResponse":               content.AccomplishmentResponse,
+		"academicTrainingBenefitsResponse":     content.AcademicTrainingBenefitsResponse,
+		"academicTrainingImprovementsResponse": content.AcademicTrainingImprovementsResponse,
+		"skillDevelopmentResponse":             content.SkillDevelopmentResponse,
+		"attitudeChangeResponse":               content.AttitudeChangeResponse,
+		"commentsResponse":                     content.CommentsResponse,
+	}
+}
+
+type studentEvaluationModel struct {
+	InternshipUUID uuid.UUID `db:"internship_uuid"`
+	SubmittedOn    time.Time `db:"submitted_on"`
+	StudentEvaluationContent
+}
+
+func insertStudentEvaluationModel(context context.Context, transaction *database.Transaction, studentEvaluationInternshipUUID uuid.UUID, studentEvaluationContent *StudentEvaluationContent) (*studentEvaluationModel, error) {
+	_, errInsert := transaction.Execute(
+		context,
+		"INSERT INTO `student_evaluations` (`internship_uuid`, `submitted_on`, `company_information_response`, `major_responsibilities_response`, `accomplishment_response`, `academic_training_benefits_response`, `academic_training_improvements_response`, `skill_development_response`, `attitude_change_response`, `comments_response`) VALUE (?, NOW(), ?, ?, ?, ?, ?, ?, ?, ?)",
+		studentEvaluationInternshipUUID,
+		studentEvaluationContent.CompanyInformationResponse,
+		studentEvaluationContent.MajorResponsibilitiesResponse,
+		studentEvaluationContent.AccomplishmentResponse,
+		studentEvaluationContent.AcademicTrainingBenefitsResponse,
+		studentEvaluationContent.AcademicTrainingImprovementsResponse,
+		studentEvaluationContent.SkillDevelopmentResponse,
+		studentEvaluationContent.AttitudeChangeResponse,
+		studentEvaluationContent.CommentsResponse,
+	)
+	if errInsert != nil {
+		return nil, errInsert
+	}
+
+	return getStudentEvaluationModelByInternshipUUID(context, transaction, studentEvaluationInternshipUUID)
+}
+
+func getStudentEvaluationModelByInternshipUUID(context context.Context, transaction *database.Transaction, studentEvaluationInternshipUUID uuid.UUID) (*studentEvaluationModel, error) {
+	model := new(studentEvaluationModel)
+
+	errGet := transaction.Get(context, model, "SELECT * FROM `student_evaluations` WHERE `internship_uuid` = ?", studentEvaluationInternshipUUID)
+	if errGet != nil {
+		return nil, errGet
+	}
+
+	return model, nil
+}
+
+func writeStudentEvaluationCompanyFilter(queryBuilder *strings.Builder, companyUUID uuid.UUID, instructorUUID uuid.NullUUID) []any {
+	queryBuilder.WriteString(" FROM `student_evaluations` JOIN `internships` ON `student_evaluations`.`internship_uuid` = `internships`.`uuid` JOIN `supervisors` ON `internships`.`supervisor_uuid` = `supervisors`.`user_uuid` JOIN `students` ON `internships`.`student_uuid` = `students`.`user_uuid` WHERE `supervisors`.`company_uuid` = ?")
+	arguments := []any{companyUUID}
+	if instructorUUID.Valid {
+		queryBuilder.WriteString(" AND (`internships`.`instructor_uuid` = ? OR EXISTS (SELECT 1 FROM `coordinators` WHERE `coordinators`.`instructor_uuid` = ? AND `coordinators`.`campus_id` = `students`.`campus_id` AND `coordinators`.`program_id` = `students`.`program_id`))")
+		arguments = append(arguments, instructorUUID.UUID, instructorUUID.UUID)
+	}
+
+	return arguments
+}
+
+func selectStudentEvaluationModelsByCompanyUUID(context context.Context, transaction *database.Transaction, companyUUID uuid.UUID, instructorUUID uuid.NullUUID, number int, limit int) ([]*studentEvaluationModel, error) {
+	offset := number * limit
+
+	var queryBuilder strings.Builder
+	queryBuilder.WriteString("SELECT `student_evaluations`.*")
+	arguments := writeStudentEvaluationCompanyFilter(&queryBuilder, companyUUID, instructorUUID)
+	queryBuilder.WriteString(" ORDER BY `student_evaluations`.`submitted_on` DESC LIMIT ? OFFSET ?")
+	arguments = append(arguments, limit, offset)
+
+	models := make([]*studentEvaluationModel, 0, limit)
+
+	errSelect := transaction.Select(context, &models, queryBuilder.String(), arguments...)
+	if errSelect != nil {
+		return nil, errSelect
+	}
+
+	return models, nil
+}
+
+func countStudentEvaluationModelsByCompanyUUID(context context.Context, transaction *database.Transaction, companyUUID uuid.UUID, instructorUUID uuid.NullUUID) (int64, error) {
+	var queryBuilder strings.Builder
+	queryBuilder.WriteString("SELECT COUNT(*)")
+	arguments := writeStudentEvaluationCompanyFilter(&queryBuilder, companyUUID, instructorUUID)
+
+	var count int64
+
+	errGet := transaction.Get(context, &count, queryBuilder.String(), arguments...)
+	if errGet != nil {
+		return 0, errGet
+	}
+
+	return count, nil
+}
+
+func selectStudentEvaluationContentsByCompanyUUID(context context.Context, transaction *database.Transaction, companyUUID uuid.UUID, instructorUUID uuid.NullUUID) ([]*StudentEvaluationContent, error) {
+	var queryBuilder strings.Builder
+	queryBuilder.WriteString("SELECT `student_evaluations`.`company_information_response`, `student_evaluations`.`major_responsibilities_response`, `student_evaluations`.`accomplishment_response`, `student_evaluations`.`academic_training_benefits_response`, `student_evaluations`.`academic_training_improvements_response`, `student_evaluations`.`skill_development_response`, `student_evaluations`.`attitude_change_response`, `student_evaluations`.`comments_response`")
+	arguments := writeStudentEvaluationCompanyFilter(&queryBuilder, companyUUID, instructorUUID)
+	queryBuilder.WriteString(" ORDER BY `student_evaluations`.`submitted_on` DESC")
+
+	contents := make([]*StudentEvaluationContent, 0)
+
+	errSelect := transaction.Select(context, &contents, queryBuilder.String(), arguments...)
+	if errSelect != nil {
+		return nil, errSelect
+	}
+
+	return contents, nil
+}
+
+type StudentEvaluation struct {
+	model      *studentEvaluationModel
+	internship *Internship
+	valid      bool
+}
+
+type StudentEvaluationAggregate struct {
+	evaluations int
+	answers     map[string][]string
+}
+
+var (
+	ErrStudentEvaluationInvalid    error = errors.New("student evaluation invalid")
+	ErrStudentEvaluationExists     error = errors.New("student evaluation exists")
+	ErrStudentEvaluationNotOpen    error = errors.New("student evaluation not open")
+	ErrStudentEvaluationNotStudent error = errors.New("student evaluation not student")
+	ErrStudentEvaluationNotVisible error = errors.New("student evaluation not visible")
+)
+
+func studentEvaluationOpen(internship *Internship) bool {
+	return !time.Now().Before(internship.model.EndOn.AddDate(0, 0, -studentEvaluationOpeningDays))
+}
+
+func studentEvaluationVisibleTo(internship *Internship, user *User) bool {
+	return internship.visibleTo(user) && !user.is("supervisor")
+}
+
+func canAggregateStudentEvaluations(user *User) bool {
+	return user.is("administrator") || len(user.coordinators) > 0
+}
+
+func studentEvaluationInstructorFilter(user *User) uuid.NullUUID {
+	if user.is("administrator") {
+		return uuid.NullUUID{}
+	}
+
+	return uuid.NullUUID{UUID: user.model.UUID, Valid: true}
+}
+
+func newStudentEvaluation(context context.Context, transaction *database.Transaction, student *User, internship *Internship, content *StudentEvaluationContent) (*StudentEvaluation, error) {
+	if internship.model.StudentUUID != student.model.UUID {
+		return nil, ErrStudentEvaluationNotStudent
+	}
+	if !studentEvaluationOpen(internship) {
+		return nil, ErrStudentEvaluationNotOpen
+	}
+
+	_, errGetExisting := getStudentEvaluationModelByInternshipUUID(context, transaction, internship.model.UUID)
+	if errGetExisting == nil {
+		return nil, ErrStudentEvaluationExists
+	} else if !errors.Is(errGetExisting, sql.ErrNoRows) {
+		return nil, errGetExisting
+	}
+
+	studentEvaluation := new(StudentEvaluation)
+
+	var errInsertModel error
+	studentEvaluation.model, errInsertModel = insertStudentEvaluationModel(context, transaction, internship.model.UUID, content)
+	if errInsertModel != nil {
+		return nil, errInsertModel
+	}
+
+	studentEvaluation.internship = internship
+
+	studentEvaluation.valid = true
+
+	return studentEvaluation, nil
+}
+
+func getStudentEvaluationByInternship(context context.Context, transaction *database.Transaction, internship *Internship) (*StudentEvaluation, error) {
+	studentEvaluation := new(StudentEvaluation)
+
+	var errGetModel error
+	studentEvaluation.model, errGetModel = getStudentEvaluationModelByInternshipUUID(context, transaction, internship.model.UUID)
+	if errGetModel != nil {
+		return nil, errGetModel
+	}
+
+	studentEvaluation.internship = internship
+
+	studentEvaluation.valid = true
+
+	return studentEvaluation, nil
+}
+
+func getStudentEvaluationBatchByCompany(context context.Context, transaction *database.Transaction, user *User, companyUUID uuid.UUID, page int, count int) (*Batch[*StudentEvaluation], error) {
+	instructorUUID := studentEvaluationInstructorFilter(user)
+
+	studentEvaluations := make([]*StudentEvaluation, 0, count)
+
+	studentEvaluationModelCount, errCountModels := countStudentEvaluationModelsByCompanyUUID(context, transaction, companyUUID, instructorUUID)
+	if errCountModels != nil {
+		return nil, errCountModels
+	}
+
+	studentEvaluationModels, errGetModels := selectStudentEvaluationModelsByCompanyUUID(context, transaction, companyUUID, instructorUUID, page, count)
+	if errGetModels != nil {
+		return nil, errGetModels
+	}
+	for _, studentEvaluationModel := range studentEvaluationModels {
+		internship, errGetInternship := getInternshipByUUID(context, transaction, studentEvaluationModel.InternshipUUID)
+		if errGetInternship != nil {
+			return nil, errGetInternship
+		}
+
+		studentEvaluations = append(studentEvaluations, &StudentEvaluation{
+			model:      studentEvaluationModel,
+			internship: internship,
+			valid:      true,
+		})
+	}
+
+	return newBatch(page, count, studentEvaluationModelCount, "studentEvaluations", studentEvaluations...), nil
+}
+
+func getStudentEvaluationAggregateByCompany(context context.Context, transaction *database.Transaction, user *User, companyUUID uuid.UUID) (*StudentEvaluationAggregate, error) {
+	contents, errSelectContents := selectStudentEvaluationContentsByCompanyUUID(context, transaction, companyUUID, studentEvaluationInstructorFilter(user))
+	if errSelectContents != nil {
+		return nil, errSelectContents
+	}
+
+	aggregate := &StudentEvaluationAggregate{
+		evaluations: len(contents),
+		answers:     make(map[string][]string),
+	}
+	for _, content := range contents {
+		for field, response := range content.responses() {
+			if response == nil {
+				continue
+			}
+
+			answer := strings.TrimSpace(*response)
+			if answer != "" {
+				aggregate.answers[field] = append(aggregate.answers[field], answer)
+			}
+		}
+	}
+
+	return aggregate, nil
+}
+
+func SubmitStudentEvaluation(context context.Context, student *User, internshipUUID uuid.UUID, content *StudentEvaluationContent) (*StudentEvaluation, error) {
+	if !student.valid {
+		panic(ErrUserInvalid)
+	}
+	if !student.is("student") {
+		return nil, ErrUserNotStudent
+	}
+
+	errPing := database.Ping(context)
+	if errPing != nil {
+		return nil, errPing
+	}
+
+	transaction, errBegin := database.Begin(context)
+	if errBegin != nil {
+		return nil, errBegin
+	}
+
+	internship, errGetInternship := getInternshipByUUID(context, transaction, internshipUUID)
+	if errGetInternship != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errGetInternship, errRollback)
+		}
+
+		return nil, errGetInternship
+	}
+
+	studentEvaluation, errNewStudentEvaluation := newStudentEvaluation(context, transaction, student, internship, content)
+	if errNewStudentEvaluation != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errNewStudentEvaluation, errRollback)
+		}
+
+		return nil, errNewStudentEvaluation
+	}
+
+	errRecord := recordAudit(context, transaction, fmt.Sprintf("Submitted student evaluation on internship %s.", internship.model.UUID), student)
+	if errRecord != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errRecord, errRollback)
+		}
+
+		return nil, errRecord
+	}
+
+	errCommit := transaction.Commit()
+	if errCommit != nil {
+		return nil, errCommit
+	}
+
+	return studentEvaluation, nil
+}
+
+func GetStudentEvaluation(context context.Context, user *User, internshipUUID uuid.UUID) (*StudentEvaluation, error) {
+	if !user.valid {
+		panic(ErrUserInvalid)
+	}
+
+	errPing := database.Ping(context)
+	if errPing != nil {
+		return nil, errPing
+	}
+
+	transaction, errBegin := database.Begin(context)
+	if errBegin != nil {
+		return nil, errBegin
+	}
+
+	internship, errGetInternship := getInternshipByUUID(context, transaction, internshipUUID)
+	if errGetInternship != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errGetInternship, errRollback)
+		}
+
+		return nil, errGetInternship
+	}
+
+	if !studentEvaluationVisibleTo(internship, user) {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(ErrStudentEvaluationNotVisible, errRollback)
+		}
+
+		return nil, ErrStudentEvaluationNotVisible
+	}
+
+	studentEvaluation, errGetStudentEvaluation := getStudentEvaluationByInternship(context, transaction, internship)
+	if errGetStudentEvaluation != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errGetStudentEvaluation, errRollback)
+		}
+
+		return nil, errGetStudentEvaluation
+	}
+
+	errCommit := transaction.Commit()
+	if errCommit != nil {
+		return nil, errCommit
+	}
+
+	return studentEvaluation, nil
+}
+
+func GetStudentEvaluationBatchByCompany(context context.Context, user *User, companyUUID uuid.UUID, batchNumber int, batchSize int) (*Batch[*StudentEvaluation], *StudentEvaluationAggregate, error) {
+	if !user.valid {
+		panic(ErrUserInvalid)
+	}
+	if !canAggregateStudentEvaluations(user) {
+		return nil, nil, ErrStudentEvaluationNotVisible
+	}
+
+	errPing := database.Ping(context)
+	if errPing != nil {
+		return nil, nil, errPing
+	}
+
+	transaction, errBegin := database.Begin(context)
+	if errBegin != nil {
+		return nil, nil, errBegin
+	}
+
+	_, errGetCompany := getCompanyModelByUUID(context, transaction, companyUUID)
+	if errGetCompany != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, nil, errors.Join(errGetCompany, errRollback)
+		}
+
+		return nil, nil, errGetCompany
+	}
+
+	studentEvaluationBatch, errGetStudentEvaluationBatch := getStudentEvaluationBatchByCompany(context, transaction, user, companyUUID, batchNumber, batchSize)
+	if errGetStudentEvaluationBatch != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, nil, errors.Join(errGetStudentEvaluationBatch, errRollback)
+		}
+
+		return nil, nil, errGetStudentEvaluationBatch
+	}
+
+	studentEvaluationAggregate, errGetStudentEvaluationAggregate := getStudentEvaluationAggregateByCompany(context, transaction, user, companyUUID)
+	if errGetStudentEvaluationAggregate != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, nil, errors.Join(errGetStudentEvaluationAggregate, errRollback)
+		}
+
+		return nil, nil, errGetStudentEvaluationAggregate
+	}
+
+	errCommit := transaction.Commit()
+	if errCommit != nil {
+		return nil, nil, errCommit
+	}
+
+	return studentEvaluationBatch, studentEvaluationAggregate, nil
+}
+
+func (studentEvaluation *StudentEvaluation) MarshalJSON() ([]byte, error) {
+	if !studentEvaluation.valid {
+		panic(ErrStudentEvaluationInvalid)
+	}
+
+	studentEvaluationMap := map[string]any{
+		"internshipUUID": studentEvaluation.model.InternshipUUID,
+		"submittedOn":    studentEvaluation.model.SubmittedOn,
+		"startOn":        studentEvaluation.internship.model.StartOn,
+		"endOn":          studentEvaluation.internship.model.EndOn,
+	}
+	for field, response := range studentEvaluation.model.responses() {
+		if response != nil {
+			studentEvaluationMap[field] = *response
+		}
+	}
+
+	return json.Marshal(studentEvaluationMap)
+}
+
+func (aggregate *StudentEvaluationAggregate) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]any{
+		"evaluations": aggregate.evaluations,
+		"answers":     aggregate.answers,
+	})
+}
@@ -0,0 +1,320 @@
+package samuel
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/sorucoder/samuel/internal/database"
+	"github.com/sorucoder/samuel/internal/ldap"
+)
+
+var (
+	studentImportColumns         []string = []string{"identity", "first_name", "last_name", "email", "phone", "address", "unit", "city", "state", "zip", "campus_id", "program_id"}
+	studentImportOptionalColumns []string = []string{"unit"}
+)
+
+type studentImportRow struct {
+	line       int
+	identity   string
+	content    *UserProfileContent
+	validation *ValidationError
+	user       *User
+}
+
+func (row *studentImportRow) status() string {
+	switch {
+	case !row.validation.empty():
+		return "invalid"
+	case row.user != nil:
+		return "imported"
+	default:
+		return "valid"
+	}
+}
+
+type StudentImport struct {
+	rows      []*studentImportRow
+	committed bool
+	valid     bool
+}
+
+var (
+	ErrStudentImportInvalid   error = errors.New("student import invalid")
+	ErrStudentImportMalformed error = errors.New("student import malformed")
+)
+
+func parseStudentImportRows(reader io.Reader) ([]*studentImportRow, error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.TrimLeadingSpace = true
+
+	header, errReadHeader := csvReader.Read()
+	if errReadHeader != nil {
+		return nil, errors.Join(ErrStudentImportMalformed, errReadHeader)
+	}
+
+	columnIndices := make(map[string]int, len(header))
+	for index, column := range header {
+		columnIndices[strings.ToLower(strings.TrimSpace(column))] = index
+	}
+	for _, column := range studentImportColumns {
+		if _, exists := columnIndices[column]; !exists && !slices.Contains(studentImportOptionalColumns, column) {
+			return nil, fmt.Errorf("%w: missing column %s", ErrStudentImportMalformed, column)
+		}
+	}
+
+	field := func(record []string, column string) string {
+		index, exists := columnIndices[column]
+		if !exists || index >= len(record) {
+			return ""
+		}
+
+		return record[index]
+	}
+
+	rows := make([]*studentImportRow, 0)
+	for {
+		record, errReadRecord := csvReader.Read()
+		if errors.Is(errReadRecord, io.EOF) {
+			break
+		} else if errReadRecord != nil {
+			return nil, errors.Join(ErrStudentImportMalformed, errReadRecord)
+		}
+
+		line, _ := csvReader.FieldPos(0)
+
+		unit := field(record, "unit")
+		rows = append(rows, &studentImportRow{
+			line:     line,
+			identity: strings.TrimSpace(field(record, "identity")),
+			content: &UserProfileContent{
+				FirstName: field(record, "first_name"),
+				LastName:  field(record, "last_name"),
+				Email:     field(record, "email"),
+				Phone:     field(record, "phone"),
+				Address:   field(record, "address"),
+				Unit:      &unit,
+				City:      field(record, "city"),
+				State:     field(record, "state"),
+				ZIP:       field(record, "zip"),
+				CampusID:  field(record, "campus_id"),
+				ProgramID: field(record, "program_id"),
+			},
+			validation: newValidationError(),
+		})
+	}
+
+	return rows, nil
+}
+
+func validateStudentImportRows(context context.Context, rows []*studentImportRow) error {
+	identityLines := make(map[string]int, len(rows))
+	emailLines := make(map[string]int, len(rows))
+	for _, row := range rows {
+		validateRequiredText(row.validation, "identity", row.identity, userIdentityMaximumLength)
+		row.content.validate(row.validation, "student")
+
+		if row.identity != "" {
+			identityKey := strings.ToLower(row.identity)
+			if line, exists := identityLines[identityKey]; exists {
+				row.validation.add("identity", fmt.Sprintf("duplicates line %d", line))
+			} else {
+				identityLines[identityKey] = row.line
+			}
+		}
+		if row.content.Email != "" {
+			emailKey := strings.ToLower(row.content.Email)
+			if line, exists := emailLines[emailKey]; exists {
+				row.validation.add("email", fmt.Sprintf("duplicates line %d", line))
+			} else {
+				emailLines[emailKey] = row.line
+			}
+		}
+	}
+
+	identities := make([]string, 0, len(rows))
+	for _, row := range rows {
+		if _, invalidIdentity := row.validation.Fields()["identity"]; !invalidIdentity {
+			identities = append(identities, row.identity)
+		}
+	}
+
+	existingIdentities, errExistingIdentities := ldap.ExistingIdentities(context, identities)
+	if errExistingIdentities != nil {
+		return errExistingIdentities
+	}
+	for _, row := range rows {
+		if _, invalidIdentity := row.validation.Fields()["identity"]; !invalidIdentity && !existingIdentities[strings.ToLower(row.identity)] {
+			row.validation.add("identity", "must exist in the directory")
+		}
+	}
+
+	return nil
+}
+
+func validateStudentImportRowReferences(context context.Context, transaction *database.Transaction, rows []*studentImportRow) error {
+	for _, row := range rows {
+		if !row.validation.empty() {
+			continue
+		}
+
+		identityCount, errCountIdentities := countUserModelsByIdentity(context, transaction, row.identity)
+		if errCountIdentities != nil {
+			return errCountIdentities
+		}
+		if identityCount > 0 {
+			row.validation.add("identity", "must not already be in use")
+		}
+
+		emailCount, errCountEmails := countStudentModelsByEmail(context, transaction, row.content.Email)
+		if errCountEmails != nil {
+			return errCountEmails
+		}
+		if emailCount > 0 {
+			row.validation.add("email", "must not already be in use")
+		}
+
+		errValidateReferences := validateProfileReferences(context, transaction, row.validation, "student", row.content)
+		if errValidateReferences != nil {
+			return errValidateReferences
+		}
+	}
+
+	return nil
+}
+
+func (studentImport *StudentImport) count(status string) int {
+	count := 0
+	for _, row := range studentImport.rows {
+		if row.status() == status {
+			count++
+		}
+	}
+
+	return count
+}
+
+func ImportStudents(context context.Context, actor *User, reader io.Reader, commit bool) (*StudentImport, error) {
+	if !actor.valid {
+		panic(ErrUserInvalid)
+	}
+	if !actor.is("administrator") {
+		return nil, ErrUserNotAdministrator
+	}
+
+	rows, errParseRows := parseStudentImportRows(reader)
+	if errParseRows != nil {
+		return nil, errParseRows
+	}
+
+	errValidateRows := validateStudentImportRows(context, rows)
+	if errValidateRows != nil {
+		return nil, errValidateRows
+	}
+
+	errPing := database.Ping(context)
+	if errPing != nil {
+		return nil, errPing
+	}
+
+	transaction, errBegin := database.Begin(context)
+	if errBegin != nil {
+		return nil, errBegin
+	}
+
+	errValidateReferences := validateStudentImportRowReferences(context, transaction, rows)
+	if errValidateReferences != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errValidateReferences, errRollback)
+		}
+
+		return nil, errValidateReferences
+	}
+
+	studentImport := &StudentImport{
+		rows:  rows,
+		valid: true,
+	}
+
+	if !commit {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errRollback
+		}
+
+		return studentImport, nil
+	}
+
+	for _, row := range rows {
+		if !row.validation.empty() {
+			continue
+		}
+
+		var errNewUser error
+		row.user, errNewUser = newProvisionedUser(context, transaction, row.identity, "ldap", "student", row.content)
+		if errNewUser != nil {
+			errRollback := transaction.Rollback()
+			if errRollback != nil {
+				return nil, errors.Join(errNewUser, errRollback)
+			}
+
+			return nil, errNewUser
+		}
+	}
+
+	errRecord := recordAudit(context, transaction, fmt.Sprintf("Imported %d of %d students.", studentImport.count("imported"), len(rows)), actor)
+	if errRecord != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errRecord, errRollback)
+		}
+
+		return nil, errRecord
+	}
+
+	errCommit := transaction.Commit()
+	if errCommit != nil {
+		return nil, errCommit
+	}
+
+	studentImport.committed = true
+
+	return studentImport, nil
+}
+
+func (studentImport *StudentImport) MarshalJSON() ([]byte, error) {
+	if !studentImport.valid {
+		panic(ErrStudentImportInvalid)
+	}
+
+	rowMaps := make([]map[string]any, 0, len(studentImport.rows))
+	for _, row := range studentImport.rows {
+		rowMap := map[string]any{
+			"line":     row.line,
+			"identity": row.identity,
+			"status":   row.status(),
+		}
+		if !row.validation.empty() {
+			rowMap["fields"] = row.validation.Fields()
+		}
+		if row.user != nil {
+			rowMap["userUUID"] = row.user.model.UUID
+		}
+
+		rowMaps = append(rowMaps, rowMap)
+	}
+
+	return json.Marshal(map[string]any{
+		"committed": studentImport.committed,
+		"total":     len(studentImport.rows),
+		"invalid":   studentImport.count("invalid"),
+		"imported":  studentImport.count("imported"),
+		"rows":      rowMaps,
+	})
+}
@@ -0,0 +1,540 @@
+package samuel
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sorucoder/samuel/internal/database"
+)
+
+type StudentReportContent struct {
+	MajorObjectivesResponse           *string `db:"major_objectives_response"`
+	AdditionalAccomplishmentsResponse *string `db:"additional_accomplishments_response"`
+	UnassignedTasksResponse           *string `db:"unassigned_tasks_response"`
+	WellHandledActivityResponse       *string `db:"well_handled_activity_response"`
+	HelpfulnessAndIssuesResponse      *string `db:"helpfulness_and_issues_response"`
+	ProblemSolvingResponse            *string `db:"problem_solving_response"`
+	LearningResponse                  *string `db:"learning_response"`
+}
+
+func (content *StudentReportContent) responses() map[string]*string {
+	return map[string]*string{
+		"majorObjectivesResponse":           content.MajorObjectivesResponse,
+		"additionalAccomplishmentsResponse": content.AdditionalAccomplishmentsResponse,
+		"unassignedTasksResponse":           content.UnassignedTasksResponse,
+		"wellHandledActivityResponse":       content.WellHandledActivityResponse,
+		"helpfulnessAndIssuesResponse":      content.HelpfulnessAndIssuesResponse,
+		"problemSolvingResponse":            content.ProblemSolvingResponse,
+		"learningResponse":                  content.LearningResponse,
+	}
+}
+
+type studentReportModel struct {
+	InternshipUUID    uuid.UUID      `db:"internship_uuid"`
+	WeekOf            time.Time      `db:"week_of"`
+	SubmittedOn       time.Time      `db:"submitted_on"`
+	InstructorComment sql.NullString `db:"instructor_comment"`
+	CommentedOn       sql.NullTime   `db:"commented_on"`
+	StudentReportContent
+}
+
+func insertStudentReportModel(context context.Context, transaction *database.Transaction, studentReportInternshipUUID uuid.UUID, studentReportWeekOf time.Time, studentReportContent *StudentReportContent) (*studentReportModel, error) {
+	_, errInsert := transaction.Execute(
+		context,
+		"INSERT INTO `student_reports` (`internship_uuid`, `week_of`, `submitted_on`, `major_objectives_response`, `additional_accomplishments_response`, `unassigned_tasks_response`, `well_handled_activity_response`, `helpfulness_and_issues_response`, `problem_solving_response`, `learning_response`) VALUE (?, ?, NOW(), ?, ?, ?, ?, ?, ?, ?)",
+		studentReportInternshipUUID,
+		studentReportWeekOf,
+		studentReportContent.MajorObjectivesResponse,
+		studentReportContent.AdditionalAccomplishmentsResponse,
+		studentReportContent.UnassignedTasksResponse,
+		studentReportContent.WellHandledActivityResponse,
+		studentReportContent.HelpfulnessAndIssuesResponse,
+		studentReportContent.ProblemSolvingResponse,
+		studentReportContent.LearningResponse,
+	)
+	if errInsert != nil {
+		return nil, errInsert
+	}
+
+	return getStudentReportModelByInternshipUUIDAndWeekOf(context, transaction, studentReportInternshipUUID, studentReportWeekOf)
+}
+
+func getStudentReportModelByInternshipUUIDAndWeekOf(context context.Context, transaction *database.Transaction, studentReportInternshipUUID uuid.UUID, studentReportWeekOf time.Time) (*studentReportModel, error) {
+	model := new(studentReportModel)
+
+	errGet := transaction.Get(context, model, "SELECT * FROM `student_reports` WHERE `internship_uuid` = ? AND `week_of` = DATE(?)", studentReportInternshipUUID, studentReportWeekOf)
+	if errGet != nil {
+		return nil, errGet
+	}
+
+	return model, nil
+}
+
+func selectStudentReportModelsByInternshipUUID(context context.Context, transaction *database.Transaction, studentReportInternshipUUID uuid.UUID, number int, limit int) ([]*studentReportModel, error) {
+	offset := number * limit
+
+	models := make([]*studentReportModel, 0, limit)
+
+	errSelect := transaction.Select(context, &models, "SELECT * FROM `student_reports` WHERE `internship_uuid` = ? ORDER BY `week_of` DESC LIMIT ? OFFSET ?", studentReportInternshipUUID, limit, offset)
+	if errSelect != nil {
+		return nil, errSelect
+	}
+
+	return models, nil
+}
+
+func countStudentReportModelsByInternshipUUID(context context.Context, transaction *database.Transaction, studentReportInternshipUUID uuid.UUID) (int64, error) {
+	var count int64
+
+	errGet := transaction.Get(context, &count, "SELECT COUNT(*) FROM `student_reports` WHERE `internship_uuid` = ?", studentReportInternshipUUID)
+	if errGet != nil {
+		return 0, errGet
+	}
+
+	return count, nil
+}
+
+func (model *studentReportModel) updateInstructorComment(context context.Context, transaction *database.Transaction, newInstructorComment string) error {
+	_, errUpdate := transaction.Execute(context, "UPDATE `student_reports` SET `instructor_comment` = ?, `commented_on` = NOW() WHERE `internship_uuid` = ? AND `week_of` = DATE(?)", newInstructorComment, model.InternshipUUID, model.WeekOf)
+	if errUpdate != nil {
+		return errUpdate
+	}
+
+	errGet := transaction.Get(context, model, "SELECT * FROM `student_reports` WHERE `internship_uuid` = ? AND `week_of` = DATE(?)", model.InternshipUUID, model.WeekOf)
+	if errGet != nil {
+		return errGet
+	}
+
+	return nil
+}
+
+type StudentReport struct {
+	model      *studentReportModel
+	internship *Internship
+	valid      bool
+}
+
+var (
+	ErrStudentReportInvalid       error = errors.New("student report invalid")
+	ErrStudentReportExists        error = errors.New("student report exists")
+	ErrStudentReportNotStudent    error = errors.New("student report not student")
+	ErrStudentReportNotVisible    error = errors.New("student report not visible")
+	ErrStudentReportNotInstructor error = errors.New("student report not instructor")
+)
+
+func validateStudentReport(internship *Internship, weekOf time.Time) error {
+	validation := newValidationError()
+
+	if weekOf.Weekday() != time.Sunday {
+		validation.add("weekOf", "must be a sunday")
+	} else if !internship.contains(weekOf) {
+		validation.add("weekOf", "must be within the internship")
+	}
+
+	if !validation.empty() {
+		return validation
+	}
+
+	return nil
+}
+
+func studentReportsVisibleTo(internship *Internship, user *User) bool {
+	return internship.visibleTo(user) && !user.is("supervisor")
+}
+
+func newStudentReport(context context.Context, transaction *database.Transaction, student *User, internship *Internship, weekOf time.Time, content *StudentReportContent) (*StudentReport, error) {
+	if internship.model.StudentUUID != student.model.UUID {
+		return nil, ErrStudentReportNotStudent
+	}
+	if internship.model.closed() {
+		return nil, ErrInternshipClosed
+	}
+
+	errValidate := validateStudentReport(internship, weekOf)
+	if errValidate != nil {
+		return nil, errValidate
+	}
+
+	_, errGetExisting := getStudentReportModelByInternshipUUIDAndWeekOf(context, transaction, internship.model.UUID, weekOf)
+	if errGetExisting == nil {
+		return nil, ErrStudentReportExists
+	} else if !errors.Is(errGetExisting, sql.ErrNoRows) {
+		return nil, errGetExisting
+	}
+
+	studentReport := new(StudentReport)
+
+	var errInsertModel error
+	studentReport.model, errInsertModel = insertStudentReportModel(context, transaction, internship.model.UUID, weekOf, content)
+	if errInsertModel != nil {
+		return nil, errInsertModel
+	}
+
+	studentReport.internship = internship
+
+	studentReport.valid = true
+
+	errPublish := publishEvent(transaction, internship.model.InstructorUUID, "studentReport", studentReport)
+	if errPublish != nil {
+		return nil, errPublish
+	}
+
+	return studentReport, nil
+}
+
+func getStudentReportByInternship(context context.Context, transaction *database.Transaction, internship *Internship, weekOf time.Time) (*StudentReport, error) {
+	studentReport := new(StudentReport)
+
+	var errGetModel error
+	studentReport.model, errGetModel = getStudentReportModelByInternshipUUIDAndWeekOf(context, transaction, internship.model.UUID, weekOf)
+	if errGetModel != nil {
+		return nil, errGetModel
+	}
+
+	studentReport.internship = internship
+
+	studentReport.valid = true
+
+	return studentReport, nil
+}
+
+func getStudentReportBatchByInternship(context context.Context, transaction *database.Transaction, internship *Internship, page int, count int) (*Batch[*StudentReport], error) {
+	studentReports := make([]*StudentReport, 0, count)
+
+	studentReportModelCount, errCountModels := countStudentReportModelsByInternshipUUID(context, transaction, internship.model.UUID)
+	if errCountModels != nil {
+		return nil, errCountModels
+	}
+
+	studentReportModels, errGetModels := selectStudentReportModelsByInternshipUUID(context, transaction, internship.model.UUID, page, count)
+	if errGetModels != nil {
+		return nil, errGetModels
+	}
+	for _, studentReportModel := range studentReportModels {
+		studentReports = append(studentReports, &StudentReport{
+			model:      studentReportModel,
+			internship: internship,
+			valid:      true,
+		})
+	}
+
+	return newBatch(page, count, studentReportModelCount, "studentReports", studentReports...), nil
+}
+
+func SubmitStudentReport(context context.Context, student *User, internshipUUID uuid.UUID, weekOf time.Time, content *StudentReportContent) (*StudentReport, error) {
+	if !student.valid {
+		panic(ErrUserInvalid)
+	}
+	if !student.is("student") {
+		return nil, ErrUserNotStudent
+	}
+
+	errPing := database.Ping(context)
+	if errPing != nil {
+		return nil, errPing
+	}
+
+	transaction, errBegin := database.Begin(context)
+	if errBegin != nil {
+		return nil, errBegin
+	}
+
+	internship, errGetInternship := getInternshipByUUID(context, transaction, internshipUUID)
+	if errGetInternship != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errGetInternship, errRollback)
+		}
+
+		return nil, errGetInternship
+	}
+
+	studentReport, errNewStudentReport := newStudentReport(context, transaction, student, internship, weekOf, content)
+	if errNewStudentReport != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errNewStudentReport, errRollback)
+		}
+
+		return nil, errNewStudentReport
+	}
+
+	instructorUser, errGetInstructorUser := getUserByUUID(context, transaction, internship.model.InstructorUUID)
+	if errGetInstructorUser != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errGetInstructorUser, errRollback)
+		}
+
+		return nil, errGetInstructorUser
+	}
+
+	studentName := fmt.Sprintf("%s %s", internship.student.model.FirstName, internship.student.model.LastName)
+	errNotify := notifyUser(context, transaction, fmt.Sprintf("%s submitted a weekly report for the week of %s.", studentName, weekOf.Format(time.DateOnly)), student, instructorUser)
+	if errNotify != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errNotify, errRollback)
+		}
+
+		return nil, errNotify
+	}
+
+	errRecord := recordAudit(context, transaction, fmt.Sprintf("Submitted student report for week of %s on internship %s.", weekOf.Format(time.DateOnly), internship.model.UUID), student)
+	if errRecord != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errRecord, errRollback)
+		}
+
+		return nil, errRecord
+	}
+
+	errCommit := transaction.Commit()
+	if errCommit != nil {
+		return nil, errCommit
+	}
+
+	return studentReport, nil
+}
+
+func GetStudentReportBatchByInternship(context context.Context, user *User, internshipUUID uuid.UUID, batchNumber int, batchSize int) (*Batch[*StudentReport], error) {
+	if !user.valid {
+		panic(ErrUserInvalid)
+	}
+
+	errPing := database.Ping(context)
+	if errPing != nil {
+		return nil, errPing
+	}
+
+	transaction, errBegin := database.Begin(context)
+	if errBegin != nil {
+		return nil, errBegin
+	}
+
+	internship, errGetInternship := getInternshipByUUID(context, transaction, internshipUUID)
+	if errGetInternship != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errGetInternship, errRollback)
+		}
+
+		return nil, errGetInternship
+	}
+
+	if !studentReportsVisibleTo(internship, user) {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(ErrStudentReportNotVisible, errRollback)
+		}
+
+		return nil, ErrStudentReportNotVisible
+	}
+
+	studentReportBatch, errGetStudentReportBatch := getStudentReportBatchByInternship(context, transaction, internship, batchNumber, batchSize)
+	if errGetStudentReportBatch != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errGetStudentReportBatch, errRollback)
+		}
+
+		return nil, errGetStudentReportBatch
+	}
+
+	errCommit := transaction.Commit()
+	if errCommit != nil {
+		return nil, errCommit
+	}
+
+	return studentReportBatch, nil
+}
+
+func GetStudentReport(context context.Context, user *User, internshipUUID uuid.UUID, weekOf time.Time) (*StudentReport, error) {
+	if !user.valid {
+		panic(ErrUserInvalid)
+	}
+
+	errPing := database.Ping(context)
+	if errPing != nil {
+		return nil, errPing
+	}
+
+	transaction, errBegin := database.Begin(context)
+	if errBegin != nil {
+		return nil, errBegin
+	}
+
+	internship, errGetInternship := getInternshipByUUID(context, transaction, internshipUUID)
+	if errGetInternship != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errGetInternship, errRollback)
+		}
+
+		return nil, errGetInternship
+	}
+
+	if !studentReportsVisibleTo(internship, user) {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(ErrStudentReportNotVisible, errRollback)
+		}
+
+		return nil, ErrStudentReportNotVisible
+	}
+
+	studentReport, errGetStudentReport := getStudentReportByInternship(context, transaction, internship, weekOf)
+	if errGetStudentReport != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errGetStudentReport, errRollback)
+		}
+
+		return nil, errGetStudentReport
+	}
+
+	errCommit := transaction.Commit()
+	if errCommit != nil {
+		return nil, errCommit
+	}
+
+	return studentReport, nil
+}
+
+func CommentStudentReport(context context.Context, instructor *User, internshipUUID uuid.UUID, weekOf time.Time, comment string) (*StudentReport, error) {
+	if !instructor.valid {
+		panic(ErrUserInvalid)
+	}
+	if !instructor.is("instructor") {
+		return nil, ErrUserNotInstructor
+	}
+
+	errPing := database.Ping(context)
+	if errPing != nil {
+		return nil, errPing
+	}
+
+	transaction, errBegin := database.Begin(context)
+	if errBegin != nil {
+		return nil, errBegin
+	}
+
+	internship, errGetInternship := getInternshipByUUID(context, transaction, internshipUUID)
+	if errGetInternship != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errGetInternship, errRollback)
+		}
+
+		return nil, errGetInternship
+	}
+
+	studentReport, errGetStudentReport := getStudentReportByInternship(context, transaction, internship, weekOf)
+	if errGetStudentReport != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errGetStudentReport, errRollback)
+		}
+
+		return nil, errGetStudentReport
+	}
+
+	errComment := studentReport.comment(context, transaction, instructor, comment)
+	if errComment != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errComment, errRollback)
+		}
+
+		return nil, errComment
+	}
+
+	studentUser, errGetStudentUser := getUserByUUID(context, transaction, internship.model.StudentUUID)
+	if errGetStudentUser != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errGetStudentUser, errRollback)
+		}
+
+		return nil, errGetStudentUser
+	}
+
+	errNotify := notifyUser(context, transaction, fmt.Sprintf("Your instructor commented on your weekly report for the week of %s.", weekOf.Format(time.DateOnly)), instructor, studentUser)
+	if errNotify != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errNotify, errRollback)
+		}
+
+		return nil, errNotify
+	}
+
+	errRecord := recordAudit(context, transaction, fmt.Sprintf("Commented on student report for week of %s on internship %s.", weekOf.Format(time.DateOnly), internship.model.UUID), instructor)
+	if errRecord != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errRecord, errRollback)
+		}
+
+		return nil, errRecord
+	}
+
+	errCommit := transaction.Commit()
+	if errCommit != nil {
+		return nil, errCommit
+	}
+
+	return studentReport, nil
+}
+
+func (studentReport *StudentReport) comment(context context.Context, transaction *database.Transaction, instructor *User, comment string) error {
+	if studentReport.internship.model.InstructorUUID != instructor.model.UUID {
+		return ErrStudentReportNotInstructor
+	}
+
+	comment = strings.TrimSpace(comment)
+	if comment == "" {
+		validation := newValidationError()
+		validation.add("comment", "must not be empty")
+		return validation
+	}
+
+	errUpdateModel := studentReport.model.updateInstructorComment(context, transaction, comment)
+	if errUpdateModel != nil {
+		return errUpdateModel
+	}
+
+	return nil
+}
+
+func (studentReport *StudentReport) MarshalJSON() ([]byte, error) {
+	if !studentReport.valid {
+		panic(ErrStudentReportInvalid)
+	}
+
+	studentReportMap := map[string]any{
+		"internshipUUID": studentReport.model.InternshipUUID,
+		"weekOf":         studentReport.model.WeekOf,
+		"submittedOn":    studentReport.model.SubmittedOn,
+	}
+	if studentReport.model.InstructorComment.Valid {
+		studentReportMap["instructorComment"] = studentReport.model.InstructorComment.String
+	}
+	if studentReport.model.CommentedOn.Valid {
+		studentReportMap["commentedOn"] = studentReport.model.CommentedOn.Time
+	}
+	for field, response := range studentReport.model.responses() {
+		if response != nil {
+			studentReportMap[field] = *response
+		}
+	}
+
+	return json.Marshal(studentReportMap)
+}
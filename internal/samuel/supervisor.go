@@ -20,6 +20,15 @@ type supervisorModel struct {
 	CompanyUUID uuid.UUID `db:"company_uuid"`
 }
 
+func insertSupervisorModel(context context.Context, transaction *database.Transaction, supervisorUserUUID uuid.UUID, supervisorProfile *UserProfileContent) (*supervisorModel, error) {
+	_, errInsert := transaction.Execute(context, "INSERT INTO `supervisors` (`user_uuid`, `first_name`, `last_name`, `title`, `email`, `phone`, `company_uuid`) VALUE (?, ?, ?, ?, ?, ?, ?)", supervisorUserUUID, supervisorProfile.FirstName, supervisorProfile.LastName, supervisorProfile.Title, supervisorProfile.Email, supervisorProfile.Phone, supervisorProfile.CompanyUUID)
+	if errInsert != nil {
+		return nil, errInsert
+	}
+
+	return getSupervisorModelByUserUUID(context, transaction, supervisorUserUUID)
+}
+
 func getSupervisorModelByUserUUID(context context.Context, transaction *database.Transaction, supervisorUserUUID uuid.UUID) (*supervisorModel, error) {
 	model := new(supervisorModel)
 
@@ -42,6 +51,17 @@ func getSupervisorModelByEmail(context context.Context, transaction *database.Tr
 	return model, nil
 }
 
+func selectSupervisorModelsByCompanyUUID(context context.Context, transaction *database.Transaction, supervisorCompanyUUID uuid.UUID) ([]*supervisorModel, error) {
+	models := make([]*supervisorModel, 0)
+
+	errSelect := transaction.Select(context, &models, "SELECT * FROM `supervisors` WHERE `company_uuid` = ? ORDER BY `last_name`, `first_name`", supervisorCompanyUUID)
+	if errSelect != nil {
+		return nil, errSelect
+	}
+
+	return models, nil
+}
+
 func (model *supervisorModel) address() *email.Address {
 	return email.NewAddress(model.FirstName, model.LastName, model.Email)
 }
@@ -100,7 +120,7 @@ func GetSupervisorByUser(context context.Context, user *User) (*Supervisor, erro
 	if !user.valid {
 		panic(ErrUserInvalid)
 	}
-	if !user.model.is("supervisor") {
+	if !user.holds("supervisor") {
 		panic(ErrUserNotSupervisor)
 	}
 
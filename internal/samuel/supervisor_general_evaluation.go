@@ -0,0 +1,527 @@
+package samuel
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sorucoder/samuel/internal/database"
+)
+
+type SupervisorGeneralEvaluationContent struct {
+	KnowledgeRating             *Rating           `db:"knowledge_rating"`
+	QualityRating               *Rating           `db:"quality_rating"`
+	EfficiencyRating            *Rating           `db:"efficiency_rating"`
+	CommunicationRating         *Rating           `db:"communication_rating"`
+	AptitudeRating              *Rating           `db:"aptitude_rating"`
+	InitiativeRating            *Rating           `db:"initiative_rating"`
+	AttitudeRating              *Rating           `db:"attitude_rating"`
+	AttendanceRating            *Rating           `db:"attendance_rating"`
+	ProfessionalismRating       *Rating           `db:"professionalism_rating"`
+	OverallRating               *Rating           `db:"overall_rating"`
+	StrengthsResponse           *string           `db:"strengths_response"`
+	WeaknessesResponse          *string           `db:"weaknesses_response"`
+	AcademicSuggestionsResponse *string           `db:"academic_suggestions_response"`
+	ValueResponse               *string           `db:"value_response"`
+	RecommendsEmployment        bool              `db:"recommends_employment"`
+	RecommendationResponse      *string           `db:"recommendation_response"`
+	ProgramResponses            map[uint8]*string `db:"-"`
+}
+
+func (content *SupervisorGeneralEvaluationContent) ratings() map[string]*Rating {
+	return map[string]*Rating{
+		"knowledgeRating":       content.KnowledgeRating,
+		"qualityRating":         content.QualityRating,
+		"efficiencyRating":      content.EfficiencyRating,
+		"communicationRating":   content.CommunicationRating,
+		"aptitudeRating":        content.AptitudeRating,
+		"initiativeRating":      content.InitiativeRating,
+		"attitudeRating":        content.AttitudeRating,
+		"attendanceRating":      content.AttendanceRating,
+		"professionalismRating": content.ProfessionalismRating,
+		"overallRating":         content.OverallRating,
+	}
+}
+
+func (content *SupervisorGeneralEvaluationContent) responses() map[string]*string {
+	return map[string]*string{
+		"strengthsResponse":           content.StrengthsResponse,
+		"weaknessesResponse":          content.WeaknessesResponse,
+		"academicSuggestionsResponse": content.AcademicSuggestionsResponse,
+		"valueResponse":               content.ValueResponse,
+		"recommendationResponse":      content.RecommendationResponse,
+	}
+}
+
+type supervisorGeneralEvaluationModel struct {
+	InternshipUUID   uuid.UUID `db:"internship_uuid"`
+	SubmittedOn      time.Time `db:"submitted_on"`
+	VisibleToStudent bool      `db:"visible_to_student"`
+	SupervisorGeneralEvaluationContent
+}
+
+func insertSupervisorGeneralEvaluationModel(context context.Context, transaction *database.Transaction, supervisorGeneralEvaluationInternshipUUID uuid.UUID, supervisorGeneralEvaluationContent *SupervisorGeneralEvaluationContent) (*supervisorGeneralEvaluationModel, error) {
+	_, errInsert := transaction.Execute(
+		context,
+		"INSERT INTO `supervisor_general_evaluations` (`internship_uuid`, `submitted_on`, `knowledge_rating`, `quality_rating`, `efficiency_rating`, `communication_rating`, `aptitude_rating`, `initiative_rating`, `attitude_rating`, `attendance_rating`, `professionalism_rating`, `overall_rating`, `strengths_response`, `weaknesses_response`, `academic_suggestions_response`, `value_response`, `recommends_employment`, `recommendation_response`) VALUE (?, NOW(), ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+		supervisorGeneralEvaluationInternshipUUID,
+		supervisorGeneralEvaluationContent.KnowledgeRating,
+		supervisorGeneralEvaluationContent.QualityRating,
+		supervisorGeneralEvaluationContent.EfficiencyRating,
+		supervisorGeneralEvaluationContent.CommunicationRating,
+		supervisorGeneralEvaluationContent.AptitudeRating,
+		supervisorGeneralEvaluationContent.InitiativeRating,
+		supervisorGeneralEvaluationContent.AttitudeRating,
+		supervisorGeneralEvaluationContent.AttendanceRating,
+		supervisorGeneralEvaluationContent.ProfessionalismRating,
+		supervisorGeneralEvaluationContent.OverallRating,
+		supervisorGeneralEvaluationContent.StrengthsResponse,
+		supervisorGeneralEvaluationContent.WeaknessesResponse,
+		supervisorGeneralEvaluationContent.AcademicSuggestionsResponse,
+		supervisorGeneralEvaluationContent.ValueResponse,
+		supervisorGeneralEvaluationContent.RecommendsEmployment,
+		supervisorGeneralEvaluationContent.RecommendationResponse,
+	)
+	if errInsert != nil {
+		return nil, errInsert
+	}
+
+	return getSupervisorGeneralEvaluationModelByInternshipUUID(context, transaction, supervisorGeneralEvaluationInternshipUUID)
+}
+
+func getSupervisorGeneralEvaluationModelByInternshipUUID(context context.Context, transaction *database.Transaction, supervisorGeneralEvaluationInternshipUUID uuid.UUID) (*supervisorGeneralEvaluationModel, error) {
+	model := new(supervisorGeneralEvaluationModel)
+
+	errGet := transaction.Get(context, model, "SELECT * FROM `supervisor_general_evaluations` WHERE `internship_uuid` = ?", supervisorGeneralEvaluationInternshipUUID)
+	if errGet != nil {
+		return nil, errGet
+	}
+
+	return model, nil
+}
+
+func (model *supervisorGeneralEvaluationModel) updateVisibleToStudent(context context.Context, transaction *database.Transaction, newVisibleToStudent bool) error {
+	_, errUpdate := transaction.Execute(context, "UPDATE `supervisor_general_evaluations` SET `visible_to_student` = ? WHERE `internship_uuid` = ?", newVisibleToStudent, model.InternshipUUID)
+	if errUpdate != nil {
+		return errUpdate
+	}
+
+	model.VisibleToStudent = newVisibleToStudent
+
+	return nil
+}
+
+type SupervisorGeneralEvaluation struct {
+	model            *supervisorGeneralEvaluationModel
+	internship       *Internship
+	programResponses []*supervisorProgramEvaluationResponseModel
+	valid            bool
+}
+
+var (
+	ErrSupervisorGeneralEvaluationInvalid       error = errors.New("supervisor general evaluation invalid")
+	ErrSupervisorGeneralEvaluationExists        error = errors.New("supervisor general evaluation exists")
+	ErrSupervisorGeneralEvaluationTooEarly      error = errors.New("supervisor general evaluation too early")
+	ErrSupervisorGeneralEvaluationNotSupervisor error = errors.New("supervisor general evaluation not supervisor")
+	ErrSupervisorGeneralEvaluationNotInstructor error = errors.New("supervisor general evaluation not instructor")
+	ErrSupervisorGeneralEvaluationNotVisible    error = errors.New("supervisor general evaluation not visible")
+	ErrSupervisorGeneralEvaluationReleased      error = errors.New("supervisor general evaluation released")
+)
+
+func newSupervisorGeneralEvaluation(context context.Context, transaction *database.Transaction, supervisor *User, internship *Internship, content *SupervisorGeneralEvaluationContent) (*SupervisorGeneralEvaluation, error) {
+	if internship.model.SupervisorUUID != supervisor.model.UUID {
+		return nil, ErrSupervisorGeneralEvaluationNotSupervisor
+	}
+	if !internship.ended() {
+		return nil, ErrSupervisorGeneralEvaluationTooEarly
+	}
+
+	programEvaluationQuestions, errGetProgramEvaluationQuestions := getProgramEvaluationQuestionsByProgramID(context, transaction, internship.student.model.ProgramID, false)
+	if errGetProgramEvaluationQuestions != nil {
+		return nil, errGetProgramEvaluationQuestions
+	}
+
+	validation := newValidationError()
+	validateRatings(validation, content.ratings())
+	validateProgramResponses(validation, programEvaluationQuestions, content.ProgramResponses)
+	if !validation.empty() {
+		return nil, validation
+	}
+
+	_, errGetExisting := getSupervisorGeneralEvaluationModelByInternshipUUID(context, transaction, internship.model.UUID)
+	if errGetExisting == nil {
+		return nil, ErrSupervisorGeneralEvaluationExists
+	} else if !errors.Is(errGetExisting, sql.ErrNoRows) {
+		return nil, errGetExisting
+	}
+
+	supervisorGeneralEvaluation := new(SupervisorGeneralEvaluation)
+
+	var errInsertModel error
+	supervisorGeneralEvaluation.model, errInsertModel = insertSupervisorGeneralEvaluationModel(context, transaction, internship.model.UUID, content)
+	if errInsertModel != nil {
+		return nil, errInsertModel
+	}
+
+	for _, programEvaluationQuestion := range programEvaluationQuestions {
+		errInsertResponse := insertSupervisorProgramEvaluationResponseModel(context, transaction, internship.model.UUID, programEvaluationQuestion.model.ProgramID, programEvaluationQuestion.model.Number, content.ProgramResponses[programEvaluationQuestion.model.Number])
+		if errInsertResponse != nil {
+			return nil, errInsertResponse
+		}
+	}
+
+	var errSelectResponses error
+	supervisorGeneralEvaluation.programResponses, errSelectResponses = selectSupervisorProgramEvaluationResponseModelsByInternshipUUID(context, transaction, internship.model.UUID)
+	if errSelectResponses != nil {
+		return nil, errSelectResponses
+	}
+
+	supervisorGeneralEvaluation.internship = internship
+
+	supervisorGeneralEvaluation.valid = true
+
+	return supervisorGeneralEvaluation, nil
+}
+
+func getSupervisorGeneralEvaluationByInternship(context context.Context, transaction *database.Transaction, internship *Internship) (*SupervisorGeneralEvaluation, error) {
+	supervisorGeneralEvaluation := new(SupervisorGeneralEvaluation)
+
+	var errGetModel error
+	supervisorGeneralEvaluation.model, errGetModel = getSupervisorGeneralEvaluationModelByInternshipUUID(context, transaction, internship.model.UUID)
+	if errGetModel != nil {
+		return nil, errGetModel
+	}
+
+	var errSelectResponses error
+	supervisorGeneralEvaluation.programResponses, errSelectResponses = selectSupervisorProgramEvaluationResponseModelsByInternshipUUID(context, transaction, internship.model.UUID)
+	if errSelectResponses != nil {
+		return nil, errSelectResponses
+	}
+
+	supervisorGeneralEvaluation.internship = internship
+
+	supervisorGeneralEvaluation.valid = true
+
+	return supervisorGeneralEvaluation, nil
+}
+
+func validateProgramResponses(validation *ValidationError, programEvaluationQuestions []*ProgramEvaluationQuestion, programResponses map[uint8]*string) {
+	active := make(map[uint8]bool, len(programEvaluationQuestions))
+	for _, programEvaluationQuestion := range programEvaluationQuestions {
+		active[programEvaluationQuestion.model.Number] = true
+	}
+
+	for number := range programResponses {
+		if !active[number] {
+			validation.add(programEvaluationResponseField(number), "is not an active question for this program")
+		}
+	}
+}
+
+func GetSupervisorGeneralEvaluationQuestions(context context.Context, user *User, internshipUUID uuid.UUID) ([]*ProgramEvaluationQuestion, error) {
+	if !user.valid {
+		panic(ErrUserInvalid)
+	}
+
+	errPing := database.Ping(context)
+	if errPing != nil {
+		return nil, errPing
+	}
+
+	transaction, errBegin := database.Begin(context)
+	if errBegin != nil {
+		return nil, errBegin
+	}
+
+	internship, errGetInternship := getInternshipByUUID(context, transaction, internshipUUID)
+	if errGetInternship != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errGetInternship, errRollback)
+		}
+
+		return nil, errGetInternship
+	}
+
+	if !internship.visibleTo(user) {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(ErrInternshipNotInvolved, errRollback)
+		}
+
+		return nil, ErrInternshipNotInvolved
+	}
+
+	programEvaluationQuestions, errGetProgramEvaluationQuestions := getProgramEvaluationQuestionsByProgramID(context, transaction, internship.student.model.ProgramID, false)
+	if errGetProgramEvaluationQuestions != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errGetProgramEvaluationQuestions, errRollback)
+		}
+
+		return nil, errGetProgramEvaluationQuestions
+	}
+
+	errCommit := transaction.Commit()
+	if errCommit != nil {
+		return nil, errCommit
+	}
+
+	return programEvaluationQuestions, nil
+}
+
+func SubmitSupervisorGeneralEvaluation(context context.Context, supervisor *User, internshipUUID uuid.UUID, content *SupervisorGeneralEvaluationContent) (*SupervisorGeneralEvaluation, error) {
+	if !supervisor.valid {
+		panic(ErrUserInvalid)
+	}
+	if !supervisor.is("supervisor") {
+		return nil, ErrUserNotSupervisor
+	}
+
+	errPing := database.Ping(context)
+	if errPing != nil {
+		return nil, errPing
+	}
+
+	transaction, errBegin := database.Begin(context)
+	if errBegin != nil {
+		return nil, errBegin
+	}
+
+	internship, errGetInternship := getInternshipByUUID(context, transaction, internshipUUID)
+	if errGetInternship != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errGetInternship, errRollback)
+		}
+
+		return nil, errGetInternship
+	}
+
+	supervisorGeneralEvaluation, errNewSupervisorGeneralEvaluation := newSupervisorGeneralEvaluation(context, transaction, supervisor, internship, content)
+	if errNewSupervisorGeneralEvaluation != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errNewSupervisorGeneralEvaluation, errRollback)
+		}
+
+		return nil, errNewSupervisorGeneralEvaluation
+	}
+
+	errRecord := recordAudit(context, transaction, fmt.Sprintf("Submitted supervisor general evaluation on internship %s.", internship.model.UUID), supervisor)
+	if errRecord != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errRecord, errRollback)
+		}
+
+		return nil, errRecord
+	}
+
+	errCommit := transaction.Commit()
+	if errCommit != nil {
+		return nil, errCommit
+	}
+
+	return supervisorGeneralEvaluation, nil
+}
+
+func GetSupervisorGeneralEvaluation(context context.Context, user *User, internshipUUID uuid.UUID) (*SupervisorGeneralEvaluation, error) {
+	if !user.valid {
+		panic(ErrUserInvalid)
+	}
+
+	errPing := database.Ping(context)
+	if errPing != nil {
+		return nil, errPing
+	}
+
+	transaction, errBegin := database.Begin(context)
+	if errBegin != nil {
+		return nil, errBegin
+	}
+
+	internship, errGetInternship := getInternshipByUUID(context, transaction, internshipUUID)
+	if errGetInternship != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errGetInternship, errRollback)
+		}
+
+		return nil, errGetInternship
+	}
+
+	if !internship.visibleTo(user) {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(ErrInternshipNotInvolved, errRollback)
+		}
+
+		return nil, ErrInternshipNotInvolved
+	}
+
+	supervisorGeneralEvaluation, errGetSupervisorGeneralEvaluation := getSupervisorGeneralEvaluationByInternship(context, transaction, internship)
+	if errGetSupervisorGeneralEvaluation != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errGetSupervisorGeneralEvaluation, errRollback)
+		}
+
+		return nil, errGetSupervisorGeneralEvaluation
+	}
+
+	if !supervisorGeneralEvaluation.visibleTo(user) {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(ErrSupervisorGeneralEvaluationNotVisible, errRollback)
+		}
+
+		return nil, ErrSupervisorGeneralEvaluationNotVisible
+	}
+
+	errCommit := transaction.Commit()
+	if errCommit != nil {
+		return nil, errCommit
+	}
+
+	return supervisorGeneralEvaluation, nil
+}
+
+func ReleaseSupervisorGeneralEvaluation(context context.Context, instructor *User, internshipUUID uuid.UUID) (*SupervisorGeneralEvaluation, error) {
+	if !instructor.valid {
+		panic(ErrUserInvalid)
+	}
+	if !instructor.is("instructor") {
+		return nil, ErrUserNotInstructor
+	}
+
+	errPing := database.Ping(context)
+	if errPing != nil {
+		return nil, errPing
+	}
+
+	transaction, errBegin := database.Begin(context)
+	if errBegin != nil {
+		return nil, errBegin
+	}
+
+	internship, errGetInternship := getInternshipByUUID(context, transaction, internshipUUID)
+	if errGetInternship != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errGetInternship, errRollback)
+		}
+
+		return nil, errGetInternship
+	}
+
+	supervisorGeneralEvaluation, errGetSupervisorGeneralEvaluation := getSupervisorGeneralEvaluationByInternship(context, transaction, internship)
+	if errGetSupervisorGeneralEvaluation != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errGetSupervisorGeneralEvaluation, errRollback)
+		}
+
+		return nil, errGetSupervisorGeneralEvaluation
+	}
+
+	errRelease := supervisorGeneralEvaluation.release(context, transaction, instructor)
+	if errRelease != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errRelease, errRollback)
+		}
+
+		return nil, errRelease
+	}
+
+	studentUser, errGetStudentUser := getUserByUUID(context, transaction, internship.model.StudentUUID)
+	if errGetStudentUser != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errGetStudentUser, errRollback)
+		}
+
+		return nil, errGetStudentUser
+	}
+
+	errNotify := notifyUser(context, transaction, "Your supervisor's final evaluation of your internship is now available.", instructor, studentUser)
+	if errNotify != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errNotify, errRollback)
+		}
+
+		return nil, errNotify
+	}
+
+	errRecord := recordAudit(context, transaction, fmt.Sprintf("Released supervisor general evaluation on internship %s.", internship.model.UUID), instructor)
+	if errRecord != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errRecord, errRollback)
+		}
+
+		return nil, errRecord
+	}
+
+	errCommit := transaction.Commit()
+	if errCommit != nil {
+		return nil, errCommit
+	}
+
+	return supervisorGeneralEvaluation, nil
+}
+
+func (supervisorGeneralEvaluation *SupervisorGeneralEvaluation) visibleTo(user *User) bool {
+	if user.is("student") {
+		return supervisorGeneralEvaluation.model.VisibleToStudent
+	}
+	return true
+}
+
+func (supervisorGeneralEvaluation *SupervisorGeneralEvaluation) release(context context.Context, transaction *database.Transaction, instructor *User) error {
+	if supervisorGeneralEvaluation.internship.model.InstructorUUID != instructor.model.UUID {
+		return ErrSupervisorGeneralEvaluationNotInstructor
+	}
+	if supervisorGeneralEvaluation.model.VisibleToStudent {
+		return ErrSupervisorGeneralEvaluationReleased
+	}
+
+	errUpdateModel := supervisorGeneralEvaluation.model.updateVisibleToStudent(context, transaction, true)
+	if errUpdateModel != nil {
+		return errUpdateModel
+	}
+
+	return nil
+}
+
+func (supervisorGeneralEvaluation *SupervisorGeneralEvaluation) MarshalJSON() ([]byte, error) {
+	if !supervisorGeneralEvaluation.valid {
+		panic(ErrSupervisorGeneralEvaluationInvalid)
+	}
+
+	supervisorGeneralEvaluationMap := map[string]any{
+		"internshipUUID":       supervisorGeneralEvaluation.model.InternshipUUID,
+		"submittedOn":          supervisorGeneralEvaluation.model.SubmittedOn,
+		"recommendsEmployment": supervisorGeneralEvaluation.model.RecommendsEmployment,
+		"visibleToStudent":     supervisorGeneralEvaluation.model.VisibleToStudent,
+		"programResponses":     supervisorGeneralEvaluation.programResponses,
+	}
+	for field, rating := range supervisorGeneralEvaluation.model.ratings() {
+		if rating != nil {
+			supervisorGeneralEvaluationMap[field] = *rating
+		}
+	}
+	for field, response := range supervisorGeneralEvaluation.model.responses() {
+		if response != nil {
+			supervisorGeneralEvaluationMap[field] = *response
+		}
+	}
+
+	return json.Marshal(supervisorGeneralEvaluationMap)
+}
@@ -0,0 +1,440 @@
+package samuel
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sorucoder/samuel/internal/configuration"
+	"github.com/sorucoder/samuel/internal/database"
+	"github.com/sorucoder/samuel/internal/email"
+)
+
+type supervisorInvitationModel struct {
+	Token          uuid.UUID    `db:"token"`
+	SupervisorUUID uuid.UUID    `db:"supervisor_uuid"`
+	SentOn         time.Time    `db:"sent_on"`
+	ExpiresOn      time.Time    `db:"expires_on"`
+	AcceptedOn     sql.NullTime `db:"accepted_on"`
+}
+
+func upsertSupervisorInvitationModel(context context.Context, transaction *database.Transaction, supervisorInvitationSupervisorUUID uuid.UUID, supervisorInvitationLifetime time.Duration) (*supervisorInvitationModel, error) {
+	_, errUpsert := transaction.Execute(
+		context,
+		"INSERT INTO `supervisor_invitations` (`token`, `supervisor_uuid`, `expires_on`) VALUE (?, ?, DATE_ADD(NOW(), INTERVAL ? SECOND)) ON DUPLICATE KEY UPDATE `token` = VALUES(`token`), `sent_on` = NOW(), `expires_on` = VALUES(`expires_on`), `accepted_on` = NULL",
+		uuid.New(),
+		supervisorInvitationSupervisorUUID,
+		int64(supervisorInvitationLifetime.Seconds()),
+	)
+	if errUpsert != nil {
+		return nil, errUpsert
+	}
+
+	return getSupervisorInvitationModelBySupervisorUUID(context, transaction, supervisorInvitationSupervisorUUID)
+}
+
+func getSupervisorInvitationModelByTokenForUpdate(context context.Context, transaction *database.Transaction, supervisorInvitationToken uuid.UUID) (*supervisorInvitationModel, error) {
+	model := new(supervisorInvitationModel)
+
+	errGet := transaction.Get(context, model, "SELECT * FROM `supervisor_invitations` WHERE `token` = ? FOR UPDATE", supervisorInvitationToken)
+	if errGet != nil {
+		return nil, errGet
+	}
+
+	return model, nil
+}
+
+func getSupervisorInvitationModelBySupervisorUUID(context context.Context, transaction *database.Transaction, supervisorInvitationSupervisorUUID uuid.UUID) (*supervisorInvitationModel, error) {
+	model := new(supervisorInvitationModel)
+
+	errGet := transaction.Get(context, model, "SELECT * FROM `supervisor_invitations` WHERE `supervisor_uuid` = ?", supervisorInvitationSupervisorUUID)
+	if errGet != nil {
+		return nil, errGet
+	}
+
+	return model, nil
+}
+
+func writeSupervisorInvitationModelStatusFilter(queryBuilder *strings.Builder, status string) {
+	switch status {
+	case "pending":
+		queryBuilder.WriteString(" WHERE `accepted_on` IS NULL AND `expires_on` > NOW()")
+	case "expired":
+		queryBuilder.WriteString(" WHERE `accepted_on` IS NULL AND `expires_on` <= NOW()")
+	case "accepted":
+		queryBuilder.WriteString(" WHERE `accepted_on` IS NOT NULL")
+	}
+}
+
+func selectSupervisorInvitationModelsByStatus(context context.Context, transaction *database.Transaction, status string, number int, limit int) ([]*supervisorInvitationModel, error) {
+	var queryBuilder strings.Builder
+	queryBuilder.WriteString("SELECT * FROM `supervisor_invitations`")
+	writeSupervisorInvitationModelStatusFilter(&queryBuilder, status)
+	queryBuilder.WriteString(" ORDER BY `sent_on` DESC LIMIT ? OFFSET ?")
+
+	offset := number * limit
+
+	models := make([]*supervisorInvitationModel, 0, limit)
+
+	errSelect := transaction.Select(context, &models, queryBuilder.String(), limit, offset)
+	if errSelect != nil {
+		return nil, errSelect
+	}
+
+	return models, nil
+}
+
+func countSupervisorInvitationModelsByStatus(context context.Context, transaction *database.Transaction, status string) (int64, error) {
+	var queryBuilder strings.Builder
+	queryBuilder.WriteString("SELECT COUNT(*) FROM `supervisor_invitations`")
+	writeSupervisorInvitationModelStatusFilter(&queryBuilder, status)
+
+	var count int64
+
+	errGet := transaction.Get(context, &count, queryBuilder.String())
+	if errGet != nil {
+		return 0, errGet
+	}
+
+	return count, nil
+}
+
+func (model *supervisorInvitationModel) accepted() bool {
+	return model.AcceptedOn.Valid
+}
+
+func (model *supervisorInvitationModel) expired() bool {
+	return !model.ExpiresOn.After(time.Now())
+}
+
+func (model *supervisorInvitationModel) status() string {
+	switch {
+	case model.accepted():
+		return "accepted"
+	case model.expired():
+		return "expired"
+	default:
+		return "pending"
+	}
+}
+
+func (model *supervisorInvitationModel) updateAcceptedOn(context context.Context, transaction *database.Transaction) error {
+	_, errUpdate := transaction.Execute(context, "UPDATE `supervisor_invitations` SET `accepted_on` = NOW() WHERE `token` = ?", model.Token)
+	if errUpdate != nil {
+		return errUpdate
+	}
+
+	errGetAcceptedOn := transaction.Get(context, &model.AcceptedOn, "SELECT `accepted_on` FROM `supervisor_invitations` WHERE `token` = ?", model.Token)
+	if errGetAcceptedOn != nil {
+		return errGetAcceptedOn
+	}
+
+	return nil
+}
+
+type SupervisorInvitation struct {
+	model      *supervisorInvitationModel
+	supervisor *Supervisor
+	valid      bool
+}
+
+var (
+	ErrSupervisorInvitationInvalid       error = errors.New("supervisor invitation invalid")
+	ErrSupervisorInvitationExpired       error = errors.New("supervisor invitation expired")
+	ErrSupervisorInvitationAccepted      error = errors.New("supervisor invitation accepted")
+	ErrSupervisorInvitationStatusUnknown error = errors.New("supervisor invitation status unknown")
+)
+
+func supervisorInvitationLifetime() time.Duration {
+	return configuration.Application.GetDuration("invitationLifetime")
+}
+
+func loadSupervisorInvitation(context context.Context, transaction *database.Transaction, model *supervisorInvitationModel) (*SupervisorInvitation, error) {
+	supervisorInvitation := new(SupervisorInvitation)
+	supervisorInvitation.model = model
+
+	supervisorUser, errGetSupervisorUser := getUserByUUID(context, transaction, model.SupervisorUUID)
+	if errGetSupervisorUser != nil {
+		return nil, errGetSupervisorUser
+	}
+
+	var errGetSupervisor error
+	supervisorInvitation.supervisor, errGetSupervisor = getSupervisorByUser(context, transaction, supervisorUser)
+	if errGetSupervisor != nil {
+		return nil, errGetSupervisor
+	}
+
+	supervisorInvitation.valid = true
+
+	return supervisorInvitation, nil
+}
+
+func inviteSupervisor(context context.Context, transaction *database.Transaction, actor *User, supervisorUser *User) (*SupervisorInvitation, error) {
+	if !supervisorUser.holds("supervisor") {
+		return nil, ErrUserNotSupervisor
+	}
+	if supervisorUser.model.activated() {
+		return nil, ErrSupervisorInvitationAccepted
+	}
+
+	model, errUpsertModel := upsertSupervisorInvitationModel(context, transaction, supervisorUser.model.UUID, supervisorInvitationLifetime())
+	if errUpsertModel != nil {
+		return nil, errUpsertModel
+	}
+
+	supervisorInvitation, errLoad := loadSupervisorInvitation(context, transaction, model)
+	if errLoad != nil {
+		return nil, errLoad
+	}
+
+	supervisor := supervisorInvitation.supervisor
+	sendEmailOnCommit(transaction, actor, fmt.Sprintf("the invitation to supervisor %s", supervisorUser.model.UUID), supervisor.model.address(), email.SupervisorOnboardingTemplate, map[string]any{
+		"firstName":     supervisor.model.FirstName,
+		"companyName":   supervisor.company.model.Name,
+		"identity":      supervisorUser.model.Identity,
+		"invitationURL": email.GenerateLink("invitation", model.Token),
+		"expiresOn":     model.ExpiresOn.Format("January 2, 2006 at 3:04 PM"),
+	})
+
+	return supervisorInvitation, nil
+}
+
+func getSupervisorInvitationBatchByStatus(context context.Context, transaction *database.Transaction, status string, page int, count int) (*Batch[*SupervisorInvitation], error) {
+	supervisorInvitations := make([]*SupervisorInvitation, 0, count)
+
+	supervisorInvitationModelCount, errCountModels := countSupervisorInvitationModelsByStatus(context, transaction, status)
+	if errCountModels != nil {
+		return nil, errCountModels
+	}
+
+	supervisorInvitationModels, errGetModels := selectSupervisorInvitationModelsByStatus(context, transaction, status, page, count)
+	if errGetModels != nil {
+		return nil, errGetModels
+	}
+	for _, supervisorInvitationModel := range supervisorInvitationModels {
+		supervisorInvitation, errLoad := loadSupervisorInvitation(context, transaction, supervisorInvitationModel)
+		if errLoad != nil {
+			return nil, errLoad
+		}
+
+		supervisorInvitations = append(supervisorInvitations, supervisorInvitation)
+	}
+
+	return newBatch(page, count, supervisorInvitationModelCount, "invitations", supervisorInvitations...), nil
+}
+
+func GetSupervisorInvitationBatch(context context.Context, actor *User, status string, batchNumber int, batchSize int) (*Batch[*SupervisorInvitation], error) {
+	if !actor.valid {
+		panic(ErrUserInvalid)
+	}
+	if !actor.is("administrator") {
+		return nil, ErrUserNotAdministrator
+	}
+	switch status {
+	case "", "pending", "expired", "accepted":
+	default:
+		return nil, ErrSupervisorInvitationStatusUnknown
+	}
+
+	errPing := database.Ping(context)
+	if errPing != nil {
+		return nil, errPing
+	}
+
+	transaction, errBegin := database.Begin(context)
+	if errBegin != nil {
+		return nil, errBegin
+	}
+
+	supervisorInvitationBatch, errGetSupervisorInvitationBatch := getSupervisorInvitationBatchByStatus(context, transaction, status, batchNumber, batchSize)
+	if errGetSupervisorInvitationBatch != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errGetSupervisorInvitationBatch, errRollback)
+		}
+
+		return nil, errGetSupervisorInvitationBatch
+	}
+
+	errCommit := transaction.Commit()
+	if errCommit != nil {
+		return nil, errCommit
+	}
+
+	return supervisorInvitationBatch, nil
+}
+
+func ResendSupervisorInvitation(context context.Context, actor *User, supervisorUUID uuid.UUID) (*SupervisorInvitation, error) {
+	if !actor.valid {
+		panic(ErrUserInvalid)
+	}
+	if !actor.is("administrator") {
+		return nil, ErrUserNotAdministrator
+	}
+
+	errPing := database.Ping(context)
+	if errPing != nil {
+		return nil, errPing
+	}
+
+	transaction, errBegin := database.Begin(context)
+	if errBegin != nil {
+		return nil, errBegin
+	}
+
+	supervisorUser, errGetSupervisorUser := getUserByUUID(context, transaction, supervisorUUID)
+	if errGetSupervisorUser != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errGetSupervisorUser, errRollback)
+		}
+
+		return nil, errGetSupervisorUser
+	}
+
+	supervisorInvitation, errInvite := inviteSupervisor(context, transaction, actor, supervisorUser)
+	if errInvite != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errInvite, errRollback)
+		}
+
+		return nil, errInvite
+	}
+
+	errRecord := recordAudit(context, transaction, fmt.Sprintf("Resent invitation to supervisor %s.", supervisorUser.model.UUID), actor)
+	if errRecord != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errRecord, errRollback)
+		}
+
+		return nil, errRecord
+	}
+
+	errCommit := transaction.Commit()
+	if errCommit != nil {
+		return nil, errCommit
+	}
+
+	return supervisorInvitation, nil
+}
+
+func AcceptSupervisorInvitation(context context.Context, supervisorInvitationToken uuid.UUID, password string) error {
+	errPing := database.Ping(context)
+	if errPing != nil {
+		return errPing
+	}
+
+	transaction, errBegin := database.Begin(context)
+	if errBegin != nil {
+		return errBegin
+	}
+
+	model, errGetModel := getSupervisorInvitationModelByTokenForUpdate(context, transaction, supervisorInvitationToken)
+	if errGetModel != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return errors.Join(errGetModel, errRollback)
+		}
+
+		return errGetModel
+	}
+	if model.accepted() {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return errors.Join(ErrSupervisorInvitationAccepted, errRollback)
+		}
+
+		return ErrSupervisorInvitationAccepted
+	}
+	if model.expired() {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return errors.Join(ErrSupervisorInvitationExpired, errRollback)
+		}
+
+		return ErrSupervisorInvitationExpired
+	}
+
+	user, errGetUser := getUserByUUID(context, transaction, model.SupervisorUUID)
+	if errGetUser != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return errors.Join(errGetUser, errRollback)
+		}
+
+		return errGetUser
+	}
+
+	errChangePassword := user.changePassword(context, transaction, password)
+	if errChangePassword != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return errors.Join(errChangePassword, errRollback)
+		}
+
+		return errChangePassword
+	}
+
+	errActivate := user.model.updateActivatedOn(context, transaction)
+	if errActivate != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return errors.Join(errActivate, errRollback)
+		}
+
+		return errActivate
+	}
+
+	errAccept := model.updateAcceptedOn(context, transaction)
+	if errAccept != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return errors.Join(errAccept, errRollback)
+		}
+
+		return errAccept
+	}
+
+	errRecord := recordAudit(context, transaction, "Accepted invitation.", user)
+	if errRecord != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return errors.Join(errRecord, errRollback)
+		}
+
+		return errRecord
+	}
+
+	errCommit := transaction.Commit()
+	if errCommit != nil {
+		return errCommit
+	}
+
+	return nil
+}
+
+func (supervisorInvitation *SupervisorInvitation) MarshalJSON() ([]byte, error) {
+	if !supervisorInvitation.valid {
+		panic(ErrSupervisorInvitationInvalid)
+	}
+
+	supervisorInvitationMap := map[string]any{
+		"supervisorUUID": supervisorInvitation.model.SupervisorUUID,
+		"supervisor":     supervisorInvitation.supervisor,
+		"sentOn":         supervisorInvitation.model.SentOn,
+		"expiresOn":      supervisorInvitation.model.ExpiresOn,
+		"status":         supervisorInvitation.model.status(),
+	}
+	if supervisorInvitation.model.AcceptedOn.Valid {
+		supervisorInvitationMap["acceptedOn"] = supervisorInvitation.model.AcceptedOn.Time
+	}
+
+	return json.Marshal(supervisorInvitationMap)
+}
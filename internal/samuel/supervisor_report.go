@@ -0,0 +1,748 @@
+package samuel
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sorucoder/samuel/internal/database"
+	"github.com/sorucoder/samuel/internal/email"
+)
+
+type SupervisorReportContent struct {
+	KnowledgeRating         *Rating `db:"knowledge_rating"`
+	KnowledgeResponse       *string `db:"knowledge_response"`
+	QualityRating           *Rating `db:"quality_rating"`
+	PrioritizationRating    *Rating `db:"prioritization_rating"`
+	QualityResponse         *string `db:"quality_response"`
+	EfficiencyRating        *Rating `db:"efficiency_rating"`
+	MultitaskingRating      *Rating `db:"multitasking_rating"`
+	EfficiencyResponse      *string `db:"efficiency_response"`
+	CommunicationRating     *Rating `db:"communication_rating"`
+	ListeningRating         *Rating `db:"listening_rating"`
+	CommunicationResponse   *string `db:"communication_response"`
+	AptitudeRating          *Rating `db:"aptitude_rating"`
+	InquisitivenessRating   *Rating `db:"inquisitiveness_rating"`
+	AptitudeResponse        *string `db:"aptitude_response"`
+	InitiativeRating        *Rating `db:"initiative_rating"`
+	InitiativeResponse      *string `db:"initiative_response"`
+	CooperationRating       *Rating `db:"cooperation_rating"`
+	AttitudeRating          *Rating `db:"attitude_rating"`
+	CooperationResponse     *string `db:"cooperation_response"`
+	AttendanceRating        *Rating `db:"attendance_rating"`
+	NotificationRating      *Rating `db:"notification_rating"`
+	AttendanceResponse      *string `db:"attendance_response"`
+	ProfessionalismRating   *Rating `db:"professionalism_rating"`
+	AppearanceRating        *Rating `db:"apperance_rating"`
+	ProfessionalismResponse *string `db:"professionalism_response"`
+	OverallRating           *Rating `db:"overall_rating"`
+	OverallResponse         *string `db:"overall_response"`
+	AccomplishmentResponse  *string `db:"accomplishment_response"`
+	RequestsPhoneCall       bool    `db:"requests_phone_call"`
+	VisibleToStudent        bool    `db:"visible_to_student"`
+}
+
+func (content *SupervisorReportContent) ratings() map[string]*Rating {
+	return map[string]*Rating{
+		"knowledgeRating":       content.KnowledgeRating,
+		"qualityRating":         content.QualityRating,
+		"prioritizationRating":  content.PrioritizationRating,
+		"efficiencyRating":      content.EfficiencyRating,
+		"multitaskingRating":    content.MultitaskingRating,
+		"communicationRating":   content.CommunicationRating,
+		"listeningRating":       content.ListeningRating,
+		"aptitudeRating":        content.AptitudeRating,
+		"inquisitivenessRating": content.InquisitivenessRating,
+		"initiativeRating":      content.InitiativeRating,
+		"cooperationRating":     content.CooperationRating,
+		"attitudeRating":        content.AttitudeRating,
+		"attendanceRating":      content.AttendanceRating,
+		"notificationRating":    content.NotificationRating,
+		"professionalismRating": content.ProfessionalismRating,
+		"appearanceRating":      content.AppearanceRating,
+		"overallRating":         content.OverallRating,
+	}
+}
+
+func (content *SupervisorReportContent) responses() map[string]*string {
+	return map[string]*string{
+		"knowledgeResponse":       content.KnowledgeResponse,
+		"qualityResponse":         content.QualityResponse,
+		"efficiencyResponse":      content.EfficiencyResponse,
+		"communicationResponse":   content.CommunicationResponse,
+		"aptitudeResponse":        content.AptitudeResponse,
+		"initiativeResponse":      content.InitiativeResponse,
+		"cooperationResponse":     content.CooperationResponse,
+		"attendanceResponse":      content.AttendanceResponse,
+		"professionalismResponse": content.ProfessionalismResponse,
+		"overallResponse":         content.OverallResponse,
+		"accomplishmentResponse":  content.AccomplishmentResponse,
+	}
+}
+
+type supervisorReportModel struct {
+	InternshipUUID      uuid.UUID    `db:"internship_uuid"`
+	WeekOf              time.Time    `db:"week_of"`
+	SubmittedOn         time.Time    `db:"submitted_on"`
+	PhoneCallResolvedOn sql.NullTime `db:"phone_call_resolved_on"`
+	SupervisorReportContent
+}
+
+func insertSupervisorReportModel(context context.Context, transaction *database.Transaction, supervisorReportInternshipUUID uuid.UUID, supervisorReportWeekOf time.Time, supervisorReportContent *SupervisorReportContent) (*supervisorReportModel, error) {
+	_, errInsert := transaction.Execute(
+		context,
+		"INSERT INTO `supervisor_reports` (`internship_uuid`, `week_of`, `submitted_on`, `knowledge_rating`, `knowledge_response`, `quality_rating`, `prioritization_rating`, `quality_response`, `efficiency_rating`, `multitasking_rating`, `efficiency_response`, `communication_rating`, `listening_rating`, `communication_response`, `aptitude_rating`, `inquisitiveness_rating`, `aptitude_response`, `initiative_rating`, `initiative_response`, `cooperation_rating`, `attitude_rating`, `cooperation_response`, `attendance_rating`, `notification_rating`, `attendance_response`, `professionalism_rating`, `apperance_rating`, `professionalism_response`, `overall_rating`, `overall_response`, `accomplishment_response`, `requests_phone_call`, `visible_to_student`) VALUE (?, ?, NOW(), ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+		supervisorReportInternshipUUID,
+		supervisorReportWeekOf,
+		supervisorReportContent.KnowledgeRating,
+		supervisorReportContent.KnowledgeResponse,
+		supervisorReportContent.QualityRating,
+		supervisorReportContent.PrioritizationRating,
+		supervisorReportContent.QualityResponse,
+		supervisorReportContent.EfficiencyRating,
+		supervisorReportContent.MultitaskingRating,
+		supervisorReportContent.EfficiencyResponse,
+		supervisorReportContent.CommunicationRating,
+		supervisorReportContent.ListeningRating,
+		supervisorReportContent.CommunicationResponse,
+		supervisorReportContent.AptitudeRating,
+		supervisorReportContent.InquisitivenessRating,
+		supervisorReportContent.AptitudeResponse,
+		supervisorReportContent.InitiativeRating,
+		supervisorReportContent.InitiativeResponse,
+		supervisorReportContent.CooperationRating,
+		supervisorReportContent.AttitudeRating,
+		supervisorReportContent.CooperationResponse,
+		supervisorReportContent.AttendanceRating,
+		supervisorReportContent.NotificationRating,
+		supervisorReportContent.AttendanceResponse,
+		supervisorReportContent.ProfessionalismRating,
+		supervisorReportContent.AppearanceRating,
+		supervisorReportContent.ProfessionalismResponse,
+		supervisorReportContent.OverallRating,
+		supervisorReportContent.OverallResponse,
+		supervisorReportContent.AccomplishmentResponse,
+		supervisorReportContent.RequestsPhoneCall,
+		supervisorReportContent.VisibleToStudent,
+	)
+	if errInsert != nil {
+		return nil, errInsert
+	}
+
+	return getSupervisorReportModelByInternshipUUIDAndWeekOf(context, transaction, supervisorReportInternshipUUID, supervisorReportWeekOf)
+}
+
+func getSupervisorReportModelByInternshipUUIDAndWeekOf(context context.Context, transaction *database.Transaction, supervisorReportInternshipUUID uuid.UUID, supervisorReportWeekOf time.Time) (*supervisorReportModel, error) {
+	model := new(supervisorReportModel)
+
+	errGet := transaction.Get(context, model, "SELECT * FROM `supervisor_reports` WHERE `internship_uuid` = ? AND `week_of` = DATE(?)", supervisorReportInternshipUUID, supervisorReportWeekOf)
+	if errGet != nil {
+		return nil, errGet
+	}
+
+	return model, nil
+}
+
+func selectSupervisorReportModelsByInternshipUUID(context context.Context, transaction *database.Transaction, supervisorReportInternshipUUID uuid.UUID, visibleToStudentOnly bool, number int, limit int) ([]*supervisorReportModel, error) {
+	var queryBuilder strings.Builder
+	queryBuilder.WriteString("SELECT * FROM `supervisor_reports` WHERE `internship_uuid` = ?")
+	if visibleToStudentOnly {
+		queryBuilder.WriteString(" AND `visible_to_student` = TRUE")
+	}
+	queryBuilder.WriteString(" ORDER BY `week_of` DESC LIMIT ? OFFSET ?")
+
+	offset := number * limit
+
+	models := make([]*supervisorReportModel, 0, limit)
+
+	errSelect := transaction.Select(context, &models, queryBuilder.String(), supervisorReportInternshipUUID, limit, offset)
+	if errSelect != nil {
+		return nil, errSelect
+	}
+
+	return models, nil
+}
+
+func countSupervisorReportModelsByInternshipUUID(context context.Context, transaction *database.Transaction, supervisorReportInternshipUUID uuid.UUID, visibleToStudentOnly bool) (int64, error) {
+	var queryBuilder strings.Builder
+	queryBuilder.WriteString("SELECT COUNT(*) FROM `supervisor_reports` WHERE `internship_uuid` = ?")
+	if visibleToStudentOnly {
+		queryBuilder.WriteString(" AND `visible_to_student` = TRUE")
+	}
+
+	var count int64
+
+	errGet := transaction.Get(context, &count, queryBuilder.String(), supervisorReportInternshipUUID)
+	if errGet != nil {
+		return 0, errGet
+	}
+
+	return count, nil
+}
+
+func selectOpenPhoneCallSupervisorReportModelsByInstructorUUID(context context.Context, transaction *database.Transaction, instructorUUID uuid.UUID, number int, limit int) ([]*supervisorReportModel, error) {
+	offset := number * limit
+
+	models := make([]*supervisorReportModel, 0, limit)
+
+	errSelect := transaction.Select(context, &models, "SELECT `supervisor_reports`.* FROM `supervisor_reports` JOIN `internships` ON `supervisor_reports`.`internship_uuid` = `internships`.`uuid` WHERE `internships`.`instructor_uuid` = ? AND `supervisor_reports`.`requests_phone_call` = TRUE AND `supervisor_reports`.`phone_call_resolved_on` IS NULL ORDER BY `supervisor_reports`.`submitted_on` ASC LIMIT ? OFFSET ?", instructorUUID, limit, offset)
+	if errSelect != nil {
+		return nil, errSelect
+	}
+
+	return models, nil
+}
+
+func countOpenPhoneCallSupervisorReportModelsByInstructorUUID(context context.Context, transaction *database.Transaction, instructorUUID uuid.UUID) (int64, error) {
+	var count int64
+
+	errGet := transaction.Get(context, &count, "SELECT COUNT(*) FROM `supervisor_reports` JOIN `internships` ON `supervisor_reports`.`internship_uuid` = `internships`.`uuid` WHERE `internships`.`instructor_uuid` = ? AND `supervisor_reports`.`requests_phone_call` = TRUE AND `supervisor_reports`.`phone_call_resolved_on` IS NULL", instructorUUID)
+	if errGet != nil {
+		return 0, errGet
+	}
+
+	return count, nil
+}
+
+func (model *supervisorReportModel) updatePhoneCallResolvedOn(context context.Context, transaction *database.Transaction) error {
+	_, errUpdate := transaction.Execute(context, "UPDATE `supervisor_reports` SET `phone_call_resolved_on` = NOW() WHERE `internship_uuid` = ? AND `week_of` = DATE(?)", model.InternshipUUID, model.WeekOf)
+	if errUpdate != nil {
+		return errUpdate
+	}
+
+	errGetPhoneCallResolvedOn := transaction.Get(context, &model.PhoneCallResolvedOn, "SELECT `phone_call_resolved_on` FROM `supervisor_reports` WHERE `internship_uuid` = ? AND `week_of` = DATE(?)", model.InternshipUUID, model.WeekOf)
+	if errGetPhoneCallResolvedOn != nil {
+		return errGetPhoneCallResolvedOn
+	}
+
+	return nil
+}
+
+type SupervisorReport struct {
+	model      *supervisorReportModel
+	internship *Internship
+	valid      bool
+}
+
+var (
+	ErrSupervisorReportInvalid       error = errors.New("supervisor report invalid")
+	ErrSupervisorReportExists        error = errors.New("supervisor report exists")
+	ErrSupervisorReportNotSupervisor error = errors.New("supervisor report not supervisor")
+	ErrSupervisorReportNotVisible    error = errors.New("supervisor report not visible")
+	ErrSupervisorReportNotInstructor error = errors.New("supervisor report not instructor")
+	ErrSupervisorReportNoPhoneCall   error = errors.New("supervisor report no phone call")
+	ErrSupervisorReportCallResolved  error = errors.New("supervisor report call resolved")
+)
+
+func validateSupervisorReport(internship *Internship, weekOf time.Time, content *SupervisorReportContent) error {
+	validation := newValidationError()
+
+	if weekOf.Weekday() != time.Sunday {
+		validation.add("weekOf", "must be a sunday")
+	} else if !internship.contains(weekOf) {
+		validation.add("weekOf", "must be within the internship")
+	}
+
+	validateRatings(validation, content.ratings())
+
+	if !validation.empty() {
+		return validation
+	}
+
+	return nil
+}
+
+func newSupervisorReport(context context.Context, transaction *database.Transaction, supervisor *User, internship *Internship, weekOf time.Time, content *SupervisorReportContent) (*SupervisorReport, error) {
+	if internship.model.SupervisorUUID != supervisor.model.UUID {
+		return nil, ErrSupervisorReportNotSupervisor
+	}
+	if internship.model.closed() {
+		return nil, ErrInternshipClosed
+	}
+
+	errValidate := validateSupervisorReport(internship, weekOf, content)
+	if errValidate != nil {
+		return nil, errValidate
+	}
+
+	_, errGetExisting := getSupervisorReportModelByInternshipUUIDAndWeekOf(context, transaction, internship.model.UUID, weekOf)
+	if errGetExisting == nil {
+		return nil, ErrSupervisorReportExists
+	} else if !errors.Is(errGetExisting, sql.ErrNoRows) {
+		return nil, errGetExisting
+	}
+
+	supervisorReport := new(SupervisorReport)
+
+	var errInsertModel error
+	supervisorReport.model, errInsertModel = insertSupervisorReportModel(context, transaction, internship.model.UUID, weekOf, content)
+	if errInsertModel != nil {
+		return nil, errInsertModel
+	}
+
+	supervisorReport.internship = internship
+
+	supervisorReport.valid = true
+
+	errPublishInstructor := publishEvent(transaction, internship.model.InstructorUUID, "supervisorReport", supervisorReport)
+	if errPublishInstructor != nil {
+		return nil, errPublishInstructor
+	}
+	if supervisorReport.model.VisibleToStudent {
+		errPublishStudent := publishEvent(transaction, internship.model.StudentUUID, "supervisorReport", supervisorReport)
+		if errPublishStudent != nil {
+			return nil, errPublishStudent
+		}
+	}
+
+	return supervisorReport, nil
+}
+
+func getSupervisorReportByInternship(context context.Context, transaction *database.Transaction, internship *Internship, weekOf time.Time) (*SupervisorReport, error) {
+	supervisorReport := new(SupervisorReport)
+
+	var errGetModel error
+	supervisorReport.model, errGetModel = getSupervisorReportModelByInternshipUUIDAndWeekOf(context, transaction, internship.model.UUID, weekOf)
+	if errGetModel != nil {
+		return nil, errGetModel
+	}
+
+	supervisorReport.internship = internship
+
+	supervisorReport.valid = true
+
+	return supervisorReport, nil
+}
+
+func getSupervisorReportBatchByInternship(context context.Context, transaction *database.Transaction, internship *Internship, visibleToStudentOnly bool, page int, count int) (*Batch[*SupervisorReport], error) {
+	supervisorReports := make([]*SupervisorReport, 0, count)
+
+	supervisorReportModelCount, errCountModels := countSupervisorReportModelsByInternshipUUID(context, transaction, internship.model.UUID, visibleToStudentOnly)
+	if errCountModels != nil {
+		return nil, errCountModels
+	}
+
+	supervisorReportModels, errGetModels := selectSupervisorReportModelsByInternshipUUID(context, transaction, internship.model.UUID, visibleToStudentOnly, page, count)
+	if errGetModels != nil {
+		return nil, errGetModels
+	}
+	for _, supervisorReportModel := range supervisorReportModels {
+		supervisorReports = append(supervisorReports, &SupervisorReport{
+			model:      supervisorReportModel,
+			internship: internship,
+			valid:      true,
+		})
+	}
+
+	return newBatch(page, count, supervisorReportModelCount, "supervisorReports", supervisorReports...), nil
+}
+
+func getOpenPhoneCallSupervisorReportBatchByInstructor(context context.Context, transaction *database.Transaction, instructor *User, page int, count int) (*Batch[*SupervisorReport], error) {
+	supervisorReports := make([]*SupervisorReport, 0, count)
+
+	supervisorReportModelCount, errCountModels := countOpenPhoneCallSupervisorReportModelsByInstructorUUID(context, transaction, instructor.model.UUID)
+	if errCountModels != nil {
+		return nil, errCountModels
+	}
+
+	supervisorReportModels, errGetModels := selectOpenPhoneCallSupervisorReportModelsByInstructorUUID(context, transaction, instructor.model.UUID, page, count)
+	if errGetModels != nil {
+		return nil, errGetModels
+	}
+
+	internships := make(map[uuid.UUID]*Internship)
+	for _, supervisorReportModel := range supervisorReportModels {
+		internship, loaded := internships[supervisorReportModel.InternshipUUID]
+		if !loaded {
+			var errGetInternship error
+			internship, errGetInternship = getInternshipByUUID(context, transaction, supervisorReportModel.InternshipUUID)
+			if errGetInternship != nil {
+				return nil, errGetInternship
+			}
+			internships[supervisorReportModel.InternshipUUID] = internship
+		}
+
+		supervisorReports = append(supervisorReports, &SupervisorReport{
+			model:      supervisorReportModel,
+			internship: internship,
+			valid:      true,
+		})
+	}
+
+	return newBatch(page, count, supervisorReportModelCount, "supervisorReports", supervisorReports...), nil
+}
+
+func SubmitSupervisorReport(context context.Context, supervisor *User, internshipUUID uuid.UUID, weekOf time.Time, content *SupervisorReportContent) (*SupervisorReport, error) {
+	if !supervisor.valid {
+		panic(ErrUserInvalid)
+	}
+	if !supervisor.is("supervisor") {
+		return nil, ErrUserNotSupervisor
+	}
+
+	errPing := database.Ping(context)
+	if errPing != nil {
+		return nil, errPing
+	}
+
+	transaction, errBegin := database.Begin(context)
+	if errBegin != nil {
+		return nil, errBegin
+	}
+
+	internship, errGetInternship := getInternshipByUUID(context, transaction, internshipUUID)
+	if errGetInternship != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errGetInternship, errRollback)
+		}
+
+		return nil, errGetInternship
+	}
+
+	supervisorReport, errNewSupervisorReport := newSupervisorReport(context, transaction, supervisor, internship, weekOf, content)
+	if errNewSupervisorReport != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errNewSupervisorReport, errRollback)
+		}
+
+		return nil, errNewSupervisorReport
+	}
+
+	if supervisorReport.model.RequestsPhoneCall {
+		errRequestPhoneCall := supervisorReport.requestPhoneCall(context, transaction, supervisor)
+		if errRequestPhoneCall != nil {
+			errRollback := transaction.Rollback()
+			if errRollback != nil {
+				return nil, errors.Join(errRequestPhoneCall, errRollback)
+			}
+
+			return nil, errRequestPhoneCall
+		}
+	}
+
+	errRecord := recordAudit(context, transaction, fmt.Sprintf("Submitted supervisor report for week of %s on internship %s.", weekOf.Format(time.DateOnly), internship.model.UUID), supervisor)
+	if errRecord != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errRecord, errRollback)
+		}
+
+		return nil, errRecord
+	}
+
+	errCommit := transaction.Commit()
+	if errCommit != nil {
+		return nil, errCommit
+	}
+
+	return supervisorReport, nil
+}
+
+func GetSupervisorReportBatchByInternship(context context.Context, user *User, internshipUUID uuid.UUID, batchNumber int, batchSize int) (*Batch[*SupervisorReport], error) {
+	if !user.valid {
+		panic(ErrUserInvalid)
+	}
+
+	errPing := database.Ping(context)
+	if errPing != nil {
+		return nil, errPing
+	}
+
+	transaction, errBegin := database.Begin(context)
+	if errBegin != nil {
+		return nil, errBegin
+	}
+
+	internship, errGetInternship := getInternshipByUUID(context, transaction, internshipUUID)
+	if errGetInternship != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errGetInternship, errRollback)
+		}
+
+		return nil, errGetInternship
+	}
+
+	if !internship.visibleTo(user) {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(ErrInternshipNotInvolved, errRollback)
+		}
+
+		return nil, ErrInternshipNotInvolved
+	}
+
+	supervisorReportBatch, errGetSupervisorReportBatch := getSupervisorReportBatchByInternship(context, transaction, internship, user.is("student"), batchNumber, batchSize)
+	if errGetSupervisorReportBatch != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errGetSupervisorReportBatch, errRollback)
+		}
+
+		return nil, errGetSupervisorReportBatch
+	}
+
+	errCommit := transaction.Commit()
+	if errCommit != nil {
+		return nil, errCommit
+	}
+
+	return supervisorReportBatch, nil
+}
+
+func GetSupervisorReport(context context.Context, user *User, internshipUUID uuid.UUID, weekOf time.Time) (*SupervisorReport, error) {
+	if !user.valid {
+		panic(ErrUserInvalid)
+	}
+
+	errPing := database.Ping(context)
+	if errPing != nil {
+		return nil, errPing
+	}
+
+	transaction, errBegin := database.Begin(context)
+	if errBegin != nil {
+		return nil, errBegin
+	}
+
+	internship, errGetInternship := getInternshipByUUID(context, transaction, internshipUUID)
+	if errGetInternship != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errGetInternship, errRollback)
+		}
+
+		return nil, errGetInternship
+	}
+
+	if !internship.visibleTo(user) {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(ErrInternshipNotInvolved, errRollback)
+		}
+
+		return nil, ErrInternshipNotInvolved
+	}
+
+	supervisorReport, errGetSupervisorReport := getSupervisorReportByInternship(context, transaction, internship, weekOf)
+	if errGetSupervisorReport != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errGetSupervisorReport, errRollback)
+		}
+
+		return nil, errGetSupervisorReport
+	}
+
+	if !supervisorReport.visibleTo(user) {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(ErrSupervisorReportNotVisible, errRollback)
+		}
+
+		return nil, ErrSupervisorReportNotVisible
+	}
+
+	errCommit := transaction.Commit()
+	if errCommit != nil {
+		return nil, errCommit
+	}
+
+	return supervisorReport, nil
+}
+
+func GetOpenPhoneCallSupervisorReportBatch(context context.Context, instructor *User, batchNumber int, batchSize int) (*Batch[*SupervisorReport], error) {
+	if !instructor.valid {
+		panic(ErrUserInvalid)
+	}
+	if !instructor.is("instructor") {
+		return nil, ErrUserNotInstructor
+	}
+
+	errPing := database.Ping(context)
+	if errPing != nil {
+		return nil, errPing
+	}
+
+	transaction, errBegin := database.Begin(context)
+	if errBegin != nil {
+		return nil, errBegin
+	}
+
+	supervisorReportBatch, errGetSupervisorReportBatch := getOpenPhoneCallSupervisorReportBatchByInstructor(context, transaction, instructor, batchNumber, batchSize)
+	if errGetSupervisorReportBatch != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errGetSupervisorReportBatch, errRollback)
+		}
+
+		return nil, errGetSupervisorReportBatch
+	}
+
+	errCommit := transaction.Commit()
+	if errCommit != nil {
+		return nil, errCommit
+	}
+
+	return supervisorReportBatch, nil
+}
+
+func ResolveSupervisorReportPhoneCall(context context.Context, instructor *User, internshipUUID uuid.UUID, weekOf time.Time) (*SupervisorReport, error) {
+	if !instructor.valid {
+		panic(ErrUserInvalid)
+	}
+	if !instructor.is("instructor") {
+		return nil, ErrUserNotInstructor
+	}
+
+	errPing := database.Ping(context)
+	if errPing != nil {
+		return nil, errPing
+	}
+
+	transaction, errBegin := database.Begin(context)
+	if errBegin != nil {
+		return nil, errBegin
+	}
+
+	internship, errGetInternship := getInternshipByUUID(context, transaction, internshipUUID)
+	if errGetInternship != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errGetInternship, errRollback)
+		}
+
+		return nil, errGetInternship
+	}
+
+	supervisorReport, errGetSupervisorReport := getSupervisorReportByInternship(context, transaction, internship, weekOf)
+	if errGetSupervisorReport != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errGetSupervisorReport, errRollback)
+		}
+
+		return nil, errGetSupervisorReport
+	}
+
+	errResolve := supervisorReport.resolvePhoneCall(context, transaction, instructor)
+	if errResolve != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errResolve, errRollback)
+		}
+
+		return nil, errResolve
+	}
+
+	errRecord := recordAudit(context, transaction, fmt.Sprintf("Resolved phone call request for week of %s on internship %s.", weekOf.Format(time.DateOnly), internship.model.UUID), instructor)
+	if errRecord != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errRecord, errRollback)
+		}
+
+		return nil, errRecord
+	}
+
+	errCommit := transaction.Commit()
+	if errCommit != nil {
+		return nil, errCommit
+	}
+
+	return supervisorReport, nil
+}
+
+func (supervisorReport *SupervisorReport) requestPhoneCall(context context.Context, transaction *database.Transaction, supervisor *User) error {
+	internship := supervisorReport.internship
+
+	instructorUser, errGetInstructorUser := getUserByUUID(context, transaction, internship.model.InstructorUUID)
+	if errGetInstructorUser != nil {
+		return errGetInstructorUser
+	}
+
+	weekOf := supervisorReport.model.WeekOf.Format(time.DateOnly)
+	studentName := fmt.Sprintf("%s %s", internship.student.model.FirstName, internship.student.model.LastName)
+	supervisorName := fmt.Sprintf("%s %s", internship.supervisor.model.FirstName, internship.supervisor.model.LastName)
+
+	errNotify := notifyUser(context, transaction, fmt.Sprintf("%s requested a phone call about %s for the week of %s.", supervisorName, studentName, weekOf), supervisor, instructorUser)
+	if errNotify != nil {
+		return errNotify
+	}
+
+	// The report stands even if the instructor cannot be emailed; the notification above still reaches them.
+	sendEmailOnCommit(transaction, supervisor, fmt.Sprintf("the phone call request about %s to %s", studentName, internship.instructor.model.Email), internship.instructor.model.address(), email.PhoneCallRequestTemplate, map[string]any{
+		"firstName":           internship.instructor.model.FirstName,
+		"studentName":         studentName,
+		"supervisorName":      supervisorName,
+		"supervisorPhone":     internship.supervisor.model.Phone,
+		"supervisorEmail":     internship.supervisor.model.Email,
+		"companyName":         internship.supervisor.company.model.Name,
+		"weekOf":              weekOf,
+		"supervisorReportURL": email.GenerateLink("supervisor_reports", internship.model.UUID, weekOf),
+	})
+
+	return nil
+}
+
+func (supervisorReport *SupervisorReport) resolvePhoneCall(context context.Context, transaction *database.Transaction, instructor *User) error {
+	if supervisorReport.internship.model.InstructorUUID != instructor.model.UUID {
+		return ErrSupervisorReportNotInstructor
+	}
+	if !supervisorReport.model.RequestsPhoneCall {
+		return ErrSupervisorReportNoPhoneCall
+	}
+	if supervisorReport.model.PhoneCallResolvedOn.Valid {
+		return ErrSupervisorReportCallResolved
+	}
+
+	errUpdateModel := supervisorReport.model.updatePhoneCallResolvedOn(context, transaction)
+	if errUpdateModel != nil {
+		return errUpdateModel
+	}
+
+	return nil
+}
+
+func (supervisorReport *SupervisorReport) visibleTo(user *User) bool {
+	if user.is("student") {
+		return supervisorReport.model.VisibleToStudent
+	}
+	return true
+}
+
+func (supervisorReport *SupervisorReport) MarshalJSON() ([]byte, error) {
+	if !supervisorReport.valid {
+		panic(ErrSupervisorReportInvalid)
+	}
+
+	supervisorReportMap := map[string]any{
+		"internshipUUID":    supervisorReport.model.InternshipUUID,
+		"weekOf":            supervisorReport.model.WeekOf,
+		"submittedOn":       supervisorReport.model.SubmittedOn,
+		"requestsPhoneCall": supervisorReport.model.RequestsPhoneCall,
+		"visibleToStudent":  supervisorReport.model.VisibleToStudent,
+	}
+	if supervisorReport.model.PhoneCallResolvedOn.Valid {
+		supervisorReportMap["phoneCallResolvedOn"] = supervisorReport.model.PhoneCallResolvedOn.Time
+	}
+	for field, rating := range supervisorReport.model.ratings() {
+		if rating != nil {
+			supervisorReportMap[field] = *rating
+		}
+	}
+	for field, response := range supervisorReport.model.responses() {
+		if response != nil {
+			supervisorReportMap[field] = *response
+		}
+	}
+
+	return json.Marshal(supervisorReportMap)
+}
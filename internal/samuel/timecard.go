@@ -0,0 +1,707 @@
+package samuel
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sorucoder/samuel/internal/database"
+)
+
+const (
+	timecardMaximumDailyHours  float64 = 12.00
+	timecardMaximumWeeklyHours float64 = 72.00
+)
+
+var timecardHourFields [7]string = [7]string{
+	"sundayHours",
+	"mondayHours",
+	"tuesdayHours",
+	"wednesdayHours",
+	"thursdayHours",
+	"fridayHours",
+	"saturdayHours",
+}
+
+type timecardModel struct {
+	InternshipUUID  uuid.UUID      `db:"internship_uuid"`
+	WeekOf          time.Time      `db:"week_of"`
+	SundayHours     float64        `db:"sunday_hours"`
+	MondayHours     float64        `db:"monday_hours"`
+	TuesdayHours    float64        `db:"tuesday_hours"`
+	WednesdayHours  float64        `db:"wednesday_hours"`
+	ThursdayHours   float64        `db:"thursday_hours"`
+	FridayHours     float64        `db:"friday_hours"`
+	SaturdayHours   float64        `db:"saturday_hours"`
+	Status          sql.NullString `db:"status"`
+	StatusChangedOn sql.NullTime   `db:"status_changed_on"`
+	StatusReason    sql.NullString `db:"status_reason"`
+}
+
+func insertTimecardModel(context context.Context, transaction *database.Transaction, timecardInternshipUUID uuid.UUID, timecardWeekOf time.Time, timecardHours [7]float64) (*timecardModel, error) {
+	_, errInsert := transaction.Execute(context, "INSERT INTO `timecards` (`internship_uuid`, `week_of`, `sunday_hours`, `monday_hours`, `tuesday_hours`, `wednesday_hours`, `thursday_hours`, `friday_hours`, `saturday_hours`) VALUE (?, ?, ?, ?, ?, ?, ?, ?, ?)", timecardInternshipUUID, timecardWeekOf, timecardHours[0], timecardHours[1], timecardHours[2], timecardHours[3], timecardHours[4], timecardHours[5], timecardHours[6])
+	if errInsert != nil {
+		return nil, errInsert
+	}
+
+	return getTimecardModelByInternshipUUIDAndWeekOf(context, transaction, timecardInternshipUUID, timecardWeekOf)
+}
+
+func getTimecardModelByInternshipUUIDAndWeekOf(context context.Context, transaction *database.Transaction, timecardInternshipUUID uuid.UUID, timecardWeekOf time.Time) (*timecardModel, error) {
+	model := new(timecardModel)
+
+	errGet := transaction.Get(context, model, "SELECT * FROM `timecards` WHERE `internship_uuid` = ? AND `week_of` = DATE(?)", timecardInternshipUUID, timecardWeekOf)
+	if errGet != nil {
+		return nil, errGet
+	}
+
+	return model, nil
+}
+
+func selectTimecardModelsByInternshipUUID(context context.Context, transaction *database.Transaction, timecardInternshipUUID uuid.UUID, number int, limit int) ([]*timecardModel, error) {
+	offset := number * limit
+
+	models := make([]*timecardModel, 0, limit)
+
+	errSelect := transaction.Select(context, &models, "SELECT * FROM `timecards` WHERE `internship_uuid` = ? ORDER BY `week_of` DESC LIMIT ? OFFSET ?", timecardInternshipUUID, limit, offset)
+	if errSelect != nil {
+		return nil, errSelect
+	}
+
+	return models, nil
+}
+
+func countTimecardModelsByInternshipUUID(context context.Context, transaction *database.Transaction, timecardInternshipUUID uuid.UUID) (int64, error) {
+	var count int64
+
+	errGet := transaction.Get(context, &count, "SELECT COUNT(*) FROM `timecards` WHERE `internship_uuid` = ?", timecardInternshipUUID)
+	if errGet != nil {
+		return 0, errGet
+	}
+
+	return count, nil
+}
+
+func (model *timecardModel) hours() [7]float64 {
+	return [7]float64{
+		model.SundayHours,
+		model.MondayHours,
+		model.TuesdayHours,
+		model.WednesdayHours,
+		model.ThursdayHours,
+		model.FridayHours,
+		model.SaturdayHours,
+	}
+}
+
+func (model *timecardModel) totalHours() float64 {
+	var total float64
+	for _, dayHours := range model.hours() {
+		total += dayHours
+	}
+	return total
+}
+
+func (model *timecardModel) status() string {
+	if !model.Status.Valid {
+		return "draft"
+	}
+	return model.Status.String
+}
+
+func (model *timecardModel) updateHours(context context.Context, transaction *database.Transaction, newTimecardHours [7]float64) error {
+	_, errUpdate := transaction.Execute(context, "UPDATE `timecards` SET `sunday_hours` = ?, `monday_hours` = ?, `tuesday_hours` = ?, `wednesday_hours` = ?, `thursday_hours` = ?, `friday_hours` = ?, `saturday_hours` = ? WHERE `internship_uuid` = ? AND `week_of` = DATE(?)", newTimecardHours[0], newTimecardHours[1], newTimecardHours[2], newTimecardHours[3], newTimecardHours[4], newTimecardHours[5], newTimecardHours[6], model.InternshipUUID, model.WeekOf)
+	if errUpdate != nil {
+		return errUpdate
+	}
+
+	model.SundayHours = newTimecardHours[0]
+	model.MondayHours = newTimecardHours[1]
+	model.TuesdayHours = newTimecardHours[2]
+	model.WednesdayHours = newTimecardHours[3]
+	model.ThursdayHours = newTimecardHours[4]
+	model.FridayHours = newTimecardHours[5]
+	model.SaturdayHours = newTimecardHours[6]
+
+	return nil
+}
+
+func (model *timecardModel) updateStatus(context context.Context, transaction *database.Transaction, newTimecardStatus sql.NullString, newTimecardStatusReason sql.NullString) error {
+	_, errUpdate := transaction.Execute(context, "UPDATE `timecards` SET `status` = ?, `status_reason` = ?, `status_changed_on` = NOW() WHERE `internship_uuid` = ? AND `week_of` = DATE(?)", newTimecardStatus, newTimecardStatusReason, model.InternshipUUID, model.WeekOf)
+	if errUpdate != nil {
+		return errUpdate
+	}
+
+	errGetStatusChangedOn := transaction.Get(context, &model.StatusChangedOn, "SELECT `status_changed_on` FROM `timecards` WHERE `internship_uuid` = ? AND `week_of` = DATE(?)", model.InternshipUUID, model.WeekOf)
+	if errGetStatusChangedOn != nil {
+		return errGetStatusChangedOn
+	}
+
+	model.Status = newTimecardStatus
+	model.StatusReason = newTimecardStatusReason
+
+	return nil
+}
+
+type Timecard struct {
+	model      *timecardModel
+	internship *Internship
+	valid      bool
+}
+
+var (
+	ErrTimecardInvalid       error = errors.New("timecard invalid")
+	ErrTimecardNotStudent    error = errors.New("timecard not student")
+	ErrTimecardNotEditable   error = errors.New("timecard not editable")
+	ErrTimecardNotDraft      error = errors.New("timecard not draft")
+	ErrTimecardNotSubmitted  error = errors.New("timecard not submitted")
+	ErrTimecardNotSupervisor error = errors.New("timecard not supervisor")
+	ErrTimecardReasonMissing error = errors.New("timecard reason missing")
+)
+
+func validateTimecard(internship *Internship, weekOf time.Time, hours [7]float64) error {
+	validation := newValidationError()
+
+	if weekOf.Weekday() != time.Sunday {
+		validation.add("weekOf", "must be a sunday")
+	} else if !internship.contains(weekOf) {
+		validation.add("weekOf", "must be within the internship")
+	}
+
+	var total float64
+	for day, dayHours := range hours {
+		if dayHours < 0 {
+			validation.add(timecardHourFields[day], "must not be negative")
+		} else if dayHours > timecardMaximumDailyHours {
+			validation.add(timecardHourFields[day], fmt.Sprintf("must not exceed %.2f hours", timecardMaximumDailyHours))
+		}
+		total += dayHours
+	}
+	if math.Round(total*100)/100 > timecardMaximumWeeklyHours {
+		validation.add("totalHours", fmt.Sprintf("must not exceed %.2f hours", timecardMaximumWeeklyHours))
+	}
+
+	if !validation.empty() {
+		return validation
+	}
+
+	return nil
+}
+
+func newTimecard(context context.Context, transaction *database.Transaction, internship *Internship, weekOf time.Time, hours [7]float64) (*Timecard, error) {
+	timecard := new(Timecard)
+
+	var errInsertModel error
+	timecard.model, errInsertModel = insertTimecardModel(context, transaction, internship.model.UUID, weekOf, hours)
+	if errInsertModel != nil {
+		return nil, errInsertModel
+	}
+
+	timecard.internship = internship
+
+	timecard.valid = true
+
+	return timecard, nil
+}
+
+func getTimecardByInternship(context context.Context, transaction *database.Transaction, internship *Internship, weekOf time.Time) (*Timecard, error) {
+	timecard := new(Timecard)
+
+	var errGetModel error
+	timecard.model, errGetModel = getTimecardModelByInternshipUUIDAndWeekOf(context, transaction, internship.model.UUID, weekOf)
+	if errGetModel != nil {
+		return nil, errGetModel
+	}
+
+	timecard.internship = internship
+
+	timecard.valid = true
+
+	return timecard, nil
+}
+
+func getTimecardBatchByInternship(context context.Context, transaction *database.Transaction, internship *Internship, page int, count int) (*Batch[*Timecard], error) {
+	timecards := make([]*Timecard, 0, count)
+
+	timecardModelCount, errCountModels := countTimecardModelsByInternshipUUID(context, transaction, internship.model.UUID)
+	if errCountModels != nil {
+		return nil, errCountModels
+	}
+
+	timecardModels, errGetModels := selectTimecardModelsByInternshipUUID(context, transaction, internship.model.UUID, page, count)
+	if errGetModels != nil {
+		return nil, errGetModels
+	}
+	for _, timecardModel := range timecardModels {
+		timecards = append(timecards, &Timecard{
+			model:      timecardModel,
+			internship: internship,
+			valid:      true,
+		})
+	}
+
+	return newBatch(page, count, timecardModelCount, "timecards", timecards...), nil
+}
+
+func draftTimecard(context context.Context, transaction *database.Transaction, student *User, internship *Internship, weekOf time.Time, hours [7]float64) (*Timecard, error) {
+	if internship.model.StudentUUID != student.model.UUID {
+		return nil, ErrTimecardNotStudent
+	}
+	if internship.model.closed() {
+		return nil, ErrInternshipClosed
+	}
+
+	errValidate := validateTimecard(internship, weekOf, hours)
+	if errValidate != nil {
+		return nil, errValidate
+	}
+
+	timecard, errGet := getTimecardByInternship(context, transaction, internship, weekOf)
+	if errors.Is(errGet, sql.ErrNoRows) {
+		return newTimecard(context, transaction, internship, weekOf, hours)
+	} else if errGet != nil {
+		return nil, errGet
+	}
+
+	switch timecard.model.status() {
+	case "draft":
+	case "denied":
+		errUpdateStatus := timecard.model.updateStatus(context, transaction, sql.NullString{}, sql.NullString{})
+		if errUpdateStatus != nil {
+			return nil, errUpdateStatus
+		}
+	default:
+		return nil, ErrTimecardNotEditable
+	}
+
+	errUpdateHours := timecard.model.updateHours(context, transaction, hours)
+	if errUpdateHours != nil {
+		return nil, errUpdateHours
+	}
+
+	return timecard, nil
+}
+
+func GetTimecardBatchByInternship(context context.Context, user *User, internshipUUID uuid.UUID, batchNumber int, batchSize int) (*Batch[*Timecard], error) {
+	if !user.valid {
+		panic(ErrUserInvalid)
+	}
+
+	errPing := database.Ping(context)
+	if errPing != nil {
+		return nil, errPing
+	}
+
+	transaction, errBegin := database.Begin(context)
+	if errBegin != nil {
+		return nil, errBegin
+	}
+
+	internship, errGetInternship := getInternshipByUUID(context, transaction, internshipUUID)
+	if errGetInternship != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errGetInternship, errRollback)
+		}
+
+		return nil, errGetInternship
+	}
+
+	if !internship.visibleTo(user) {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(ErrInternshipNotInvolved, errRollback)
+		}
+
+		return nil, ErrInternshipNotInvolved
+	}
+
+	timecardBatch, errGetTimecardBatch := getTimecardBatchByInternship(context, transaction, internship, batchNumber, batchSize)
+	if errGetTimecardBatch != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errGetTimecardBatch, errRollback)
+		}
+
+		return nil, errGetTimecardBatch
+	}
+
+	errCommit := transaction.Commit()
+	if errCommit != nil {
+		return nil, errCommit
+	}
+
+	return timecardBatch, nil
+}
+
+func GetTimecard(context context.Context, user *User, internshipUUID uuid.UUID, weekOf time.Time) (*Timecard, error) {
+	if !user.valid {
+		panic(ErrUserInvalid)
+	}
+
+	errPing := database.Ping(context)
+	if errPing != nil {
+		return nil, errPing
+	}
+
+	transaction, errBegin := database.Begin(context)
+	if errBegin != nil {
+		return nil, errBegin
+	}
+
+	internship, errGetInternship := getInternshipByUUID(context, transaction, internshipUUID)
+	if errGetInternship != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errGetInternship, errRollback)
+		}
+
+		return nil, errGetInternship
+	}
+
+	if !internship.visibleTo(user) {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(ErrInternshipNotInvolved, errRollback)
+		}
+
+		return nil, ErrInternshipNotInvolved
+	}
+
+	timecard, errGetTimecard := getTimecardByInternship(context, transaction, internship, weekOf)
+	if errGetTimecard != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errGetTimecard, errRollback)
+		}
+
+		return nil, errGetTimecard
+	}
+
+	errCommit := transaction.Commit()
+	if errCommit != nil {
+		return nil, errCommit
+	}
+
+	return timecard, nil
+}
+
+func DraftTimecard(context context.Context, student *User, internshipUUID uuid.UUID, weekOf time.Time, hours [7]float64) (*Timecard, error) {
+	if !student.valid {
+		panic(ErrUserInvalid)
+	}
+	if !student.is("student") {
+		return nil, ErrUserNotStudent
+	}
+
+	errPing := database.Ping(context)
+	if errPing != nil {
+		return nil, errPing
+	}
+
+	transaction, errBegin := database.Begin(context)
+	if errBegin != nil {
+		return nil, errBegin
+	}
+
+	internship, errGetInternship := getInternshipByUUID(context, transaction, internshipUUID)
+	if errGetInternship != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errGetInternship, errRollback)
+		}
+
+		return nil, errGetInternship
+	}
+
+	timecard, errDraft := draftTimecard(context, transaction, student, internship, weekOf, hours)
+	if errDraft != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errDraft, errRollback)
+		}
+
+		return nil, errDraft
+	}
+
+	errRecord := recordAudit(context, transaction, fmt.Sprintf("Drafted timecard for week of %s on internship %s.", weekOf.Format(time.DateOnly), internship.model.UUID), student)
+	if errRecord != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errRecord, errRollback)
+		}
+
+		return nil, errRecord
+	}
+
+	errCommit := transaction.Commit()
+	if errCommit != nil {
+		return nil, errCommit
+	}
+
+	return timecard, nil
+}
+
+func SubmitTimecard(context context.Context, student *User, internshipUUID uuid.UUID, weekOf time.Time) (*Timecard, error) {
+	if !student.valid {
+		panic(ErrUserInvalid)
+	}
+	if !student.is("student") {
+		return nil, ErrUserNotStudent
+	}
+
+	errPing := database.Ping(context)
+	if errPing != nil {
+		return nil, errPing
+	}
+
+	transaction, errBegin := database.Begin(context)
+	if errBegin != nil {
+		return nil, errBegin
+	}
+
+	internship, errGetInternship := getInternshipByUUID(context, transaction, internshipUUID)
+	if errGetInternship != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errGetInternship, errRollback)
+		}
+
+		return nil, errGetInternship
+	}
+
+	timecard, errGetTimecard := getTimecardByInternship(context, transaction, internship, weekOf)
+	if errGetTimecard != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errGetTimecard, errRollback)
+		}
+
+		return nil, errGetTimecard
+	}
+
+	errSubmit := timecard.submit(context, transaction, student)
+	if errSubmit != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errSubmit, errRollback)
+		}
+
+		return nil, errSubmit
+	}
+
+	errRecord := recordAudit(context, transaction, fmt.Sprintf("Submitted timecard for week of %s on internship %s.", weekOf.Format(time.DateOnly), internship.model.UUID), student)
+	if errRecord != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errRecord, errRollback)
+		}
+
+		return nil, errRecord
+	}
+
+	errCommit := transaction.Commit()
+	if errCommit != nil {
+		return nil, errCommit
+	}
+
+	return timecard, nil
+}
+
+func ApproveTimecard(context context.Context, supervisor *User, internshipUUID uuid.UUID, weekOf time.Time) (*Timecard, error) {
+	return reviewTimecard(context, supervisor, internshipUUID, weekOf, "approved", "")
+}
+
+func DenyTimecard(context context.Context, supervisor *User, internshipUUID uuid.UUID, weekOf time.Time, reason string) (*Timecard, error) {
+	return reviewTimecard(context, supervisor, internshipUUID, weekOf, "denied", reason)
+}
+
+func reviewTimecard(context context.Context, supervisor *User, internshipUUID uuid.UUID, weekOf time.Time, status string, reason string) (*Timecard, error) {
+	if !supervisor.valid {
+		panic(ErrUserInvalid)
+	}
+	if !supervisor.is("supervisor") {
+		return nil, ErrUserNotSupervisor
+	}
+
+	errPing := database.Ping(context)
+	if errPing != nil {
+		return nil, errPing
+	}
+
+	transaction, errBegin := database.Begin(context)
+	if errBegin != nil {
+		return nil, errBegin
+	}
+
+	internship, errGetInternship := getInternshipByUUID(context, transaction, internshipUUID)
+	if errGetInternship != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errGetInternship, errRollback)
+		}
+
+		return nil, errGetInternship
+	}
+
+	timecard, errGetTimecard := getTimecardByInternship(context, transaction, internship, weekOf)
+	if errGetTimecard != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errGetTimecard, errRollback)
+		}
+
+		return nil, errGetTimecard
+	}
+
+	errReview := timecard.review(context, transaction, supervisor, status, reason)
+	if errReview != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errReview, errRollback)
+		}
+
+		return nil, errReview
+	}
+
+	studentUser, errGetStudentUser := getUserByUUID(context, transaction, internship.model.StudentUUID)
+	if errGetStudentUser != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errGetStudentUser, errRollback)
+		}
+
+		return nil, errGetStudentUser
+	}
+
+	var notificationMessage string
+	if status == "denied" {
+		notificationMessage = fmt.Sprintf("Your timecard for the week of %s was denied: %s", weekOf.Format(time.DateOnly), reason)
+	} else {
+		notificationMessage = fmt.Sprintf("Your timecard for the week of %s was approved.", weekOf.Format(time.DateOnly))
+	}
+	errNotify := notifyUser(context, transaction, notificationMessage, supervisor, studentUser)
+	if errNotify != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errNotify, errRollback)
+		}
+
+		return nil, errNotify
+	}
+
+	var auditDescription string
+	if status == "denied" {
+		auditDescription = fmt.Sprintf("Denied timecard for week of %s on internship %s.", weekOf.Format(time.DateOnly), internship.model.UUID)
+	} else {
+		auditDescription = fmt.Sprintf("Approved timecard for week of %s on internship %s.", weekOf.Format(time.DateOnly), internship.model.UUID)
+	}
+	errRecord := recordAudit(context, transaction, auditDescription, supervisor)
+	if errRecord != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errRecord, errRollback)
+		}
+
+		return nil, errRecord
+	}
+
+	errCommit := transaction.Commit()
+	if errCommit != nil {
+		return nil, errCommit
+	}
+
+	return timecard, nil
+}
+
+func (timecard *Timecard) submit(context context.Context, transaction *database.Transaction, student *User) error {
+	if timecard.internship.model.StudentUUID != student.model.UUID {
+		return ErrTimecardNotStudent
+	}
+	if timecard.internship.model.closed() {
+		return ErrInternshipClosed
+	}
+	if timecard.model.status() != "draft" {
+		return ErrTimecardNotDraft
+	}
+
+	errValidate := validateTimecard(timecard.internship, timecard.model.WeekOf, timecard.model.hours())
+	if errValidate != nil {
+		return errValidate
+	}
+
+	errUpdateStatus := timecard.model.updateStatus(context, transaction, sql.NullString{String: "submitted", Valid: true}, sql.NullString{})
+	if errUpdateStatus != nil {
+		return errUpdateStatus
+	}
+
+	errPublish := publishEvent(transaction, timecard.internship.model.SupervisorUUID, "timecard", timecard)
+	if errPublish != nil {
+		return errPublish
+	}
+
+	return nil
+}
+
+func (timecard *Timecard) review(context context.Context, transaction *database.Transaction, supervisor *User, status string, reason string) error {
+	if timecard.internship.model.SupervisorUUID != supervisor.model.UUID {
+		return ErrTimecardNotSupervisor
+	}
+	if timecard.model.status() != "submitted" {
+		return ErrTimecardNotSubmitted
+	}
+
+	var statusReason sql.NullString
+	if status == "denied" {
+		if strings.TrimSpace(reason) == "" {
+			return ErrTimecardReasonMissing
+		}
+		statusReason = sql.NullString{String: reason, Valid: true}
+	}
+
+	errUpdateStatus := timecard.model.updateStatus(context, transaction, sql.NullString{String: status, Valid: true}, statusReason)
+	if errUpdateStatus != nil {
+		return errUpdateStatus
+	}
+
+	errPublish := publishEvent(transaction, timecard.internship.model.StudentUUID, "timecard", timecard)
+	if errPublish != nil {
+		return errPublish
+	}
+
+	return nil
+}
+
+func (timecard *Timecard) MarshalJSON() ([]byte, error) {
+	if !timecard.valid {
+		panic(ErrTimecardInvalid)
+	}
+
+	timecardMap := map[string]any{
+		"internshipUUID": timecard.model.InternshipUUID,
+		"weekOf":         timecard.model.WeekOf,
+		"sundayHours":    timecard.model.SundayHours,
+		"mondayHours":    timecard.model.MondayHours,
+		"tuesdayHours":   timecard.model.TuesdayHours,
+		"wednesdayHours": timecard.model.WednesdayHours,
+		"thursdayHours":  timecard.model.ThursdayHours,
+		"fridayHours":    timecard.model.FridayHours,
+		"saturdayHours":  timecard.model.SaturdayHours,
+		"totalHours":     timecard.model.totalHours(),
+		"status":         timecard.model.status(),
+	}
+	if timecard.model.StatusChangedOn.Valid {
+		timecardMap["statusChangedOn"] = timecard.model.StatusChangedOn.Time
+	}
+	if timecard.model.StatusReason.Valid {
+		timecardMap["statusReason"] = timecard.model.StatusReason.String
+	}
+
+	return json.Marshal(timecardMap)
+}
@@ -0,0 +1,1063 @@
+package samuel
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sorucoder/samuel/internal/configuration"
+	"github.com/sorucoder/samuel/internal/database"
+	"github.com/sorucoder/samuel/internal/totp"
+)
+
+const (
+	twoFactorRecoveryCodeCount        int = 10
+	twoFactorChallengeMaximumAttempts int = 5
+)
+
+type supervisorTwoFactorModel struct {
+	SupervisorUUID uuid.UUID     `db:"supervisor_uuid"`
+	Secret         string        `db:"secret"`
+	CreatedOn      time.Time     `db:"created_on"`
+	ConfirmedOn    sql.NullTime  `db:"confirmed_on"`
+	LastUsedStep   sql.NullInt64 `db:"last_used_step"`
+}
+
+func upsertSupervisorTwoFactorModel(context context.Context, transaction *database.Transaction, supervisorUUID uuid.UUID, sealedSecret string) (*supervisorTwoFactorModel, error) {
+	_, errUpsert := transaction.Execute(context, "INSERT INTO `supervisor_two_factors` (`supervisor_uuid`, `secret`) VALUE (?, ?) ON DUPLICATE KEY UPDATE `secret` = VALUES(`secret`), `created_on` = NOW(), `confirmed_on` = NULL, `last_used_step` = NULL", supervisorUUID, sealedSecret)
+	if errUpsert != nil {
+		return nil, errUpsert
+	}
+
+	return getSupervisorTwoFactorModelBySupervisorUUID(context, transaction, supervisorUUID)
+}
+
+func getSupervisorTwoFactorModelBySupervisorUUID(context context.Context, transaction *database.Transaction, supervisorUUID uuid.UUID) (*supervisorTwoFactorModel, error) {
+	model := new(supervisorTwoFactorModel)
+
+	errGet := transaction.Get(context, model, "SELECT * FROM `supervisor_two_factors` WHERE `supervisor_uuid` = ?", supervisorUUID)
+	if errGet != nil {
+		return nil, errGet
+	}
+
+	return model, nil
+}
+
+func (model *supervisorTwoFactorModel) secret() (string, error) {
+	key, errGetKey := twoFactorKey()
+	if errGetKey != nil {
+		return "", errGetKey
+	}
+
+	return totp.Open(key, model.Secret, model.SupervisorUUID.String())
+}
+
+func (model *supervisorTwoFactorModel) confirmed() bool {
+	return model.ConfirmedOn.Valid
+}
+
+func (model *supervisorTwoFactorModel) lastStep() int64 {
+	if !model.LastUsedStep.Valid {
+		return 0
+	}
+
+	return model.LastUsedStep.Int64
+}
+
+func (model *supervisorTwoFactorModel) updateConfirmedOn(context context.Context, transaction *database.Transaction) error {
+	_, errUpdate := transaction.Execute(context, "UPDATE `supervisor_two_factors` SET `confirmed_on` = NOW() WHERE `supervisor_uuid` = ?", model.SupervisorUUID)
+	if errUpdate != nil {
+		return errUpdate
+	}
+
+	errGetConfirmedOn := transaction.Get(context, &model.ConfirmedOn, "SELECT `confirmed_on` FROM `supervisor_two_factors` WHERE `supervisor_uuid` = ?", model.SupervisorUUID)
+	if errGetConfirmedOn != nil {
+		return errGetConfirmedOn
+	}
+
+	return nil
+}
+
+func (model *supervisorTwoFactorModel) updateLastUsedStep(context context.Context, transaction *database.Transaction, step int64) error {
+	_, errUpdate := transaction.Execute(context, "UPDATE `supervisor_two_factors` SET `last_used_step` = ? WHERE `supervisor_uuid` = ?", step, model.SupervisorUUID)
+	if errUpdate != nil {
+		return errUpdate
+	}
+
+	model.LastUsedStep = sql.NullInt64{Int64: step, Valid: true}
+
+	return nil
+}
+
+func (model *supervisorTwoFactorModel) delete(context context.Context, transaction *database.Transaction) error {
+	_, errDelete := transaction.Execute(context, "DELETE FROM `supervisor_two_factors` WHERE `supervisor_uuid` = ?", model.SupervisorUUID)
+	if errDelete != nil {
+		return errDelete
+	}
+
+	return nil
+}
+
+func insertSupervisorRecoveryCodeModel(context context.Context, transaction *database.Transaction, supervisorUUID uuid.UUID, codeHash string) error {
+	_, errInsert := transaction.Execute(context, "INSERT INTO `supervisor_recovery_codes` (`supervisor_uuid`, `code_hash`) VALUE (?, ?)", supervisorUUID, codeHash)
+	if errInsert != nil {
+		return errInsert
+	}
+
+	return nil
+}
+
+func updateSupervisorRecoveryCodeModelUsedOn(context context.Context, transaction *database.Transaction, supervisorUUID uuid.UUID, codeHash string) (bool, error) {
+	result, errUpdate := transaction.Execute(context, "UPDATE `supervisor_recovery_codes` SET `used_on` = NOW() WHERE `supervisor_uuid` = ? AND `code_hash` = ? AND `used_on` IS NULL", supervisorUUID, codeHash)
+	if errUpdate != nil {
+		return false, errUpdate
+	}
+
+	return result.RowsAffected() == 1, nil
+}
+
+func deleteSupervisorRecoveryCodeModels(context context.Context, transaction *database.Transaction, supervisorUUID uuid.UUID) error {
+	_, errDelete := transaction.Execute(context, "DELETE FROM `supervisor_recovery_codes` WHERE `supervisor_uuid` = ?", supervisorUUID)
+	if errDelete != nil {
+		return errDelete
+	}
+
+	return nil
+}
+
+type twoFactorChallengeModel struct {
+	Token          uuid.UUID `db:"token"`
+	SupervisorUUID uuid.UUID `db:"supervisor_uuid"`
+	Enrollment     bool      `db:"enrollment"`
+	Attempts       int       `db:"attempts"`
+	ExpiresOn      time.Time `db:"expires_on"`
+}
+
+func insertTwoFactorChallengeModel(context context.Context, transaction *database.Transaction, supervisorUUID uuid.UUID, enrollment bool) (*twoFactorChallengeModel, error) {
+	errDelete := deleteTwoFactorChallengeModelsBySupervisorUUID(context, transaction, supervisorUUID)
+	if errDelete != nil {
+		return nil, errDelete
+	}
+
+	_, errInsert := transaction.Execute(context, "INSERT INTO `two_factor_challenges` (`supervisor_uuid`, `enrollment`) VALUE (?, ?)", supervisorUUID, enrollment)
+	if errInsert != nil {
+		return nil, errInsert
+	}
+
+	model := new(twoFactorChallengeModel)
+
+	errGet := transaction.Get(context, model, "SELECT * FROM `two_factor_challenges` WHERE `supervisor_uuid` = ?", supervisorUUID)
+	if errGet != nil {
+		return nil, errGet
+	}
+
+	return model, nil
+}
+
+func getTwoFactorChallengeModelByToken(context context.Context, transaction *database.Transaction, challengeToken uuid.UUID) (*twoFactorChallengeModel, error) {
+	model := new(twoFactorChallengeModel)
+
+	errGet := transaction.Get(context, model, "SELECT * FROM `two_factor_challenges` WHERE `token` = ?", challengeToken)
+	if errGet != nil {
+		return nil, errGet
+	}
+
+	return model, nil
+}
+
+func deleteTwoFactorChallengeModelsBySupervisorUUID(context context.Context, transaction *database.Transaction, supervisorUUID uuid.UUID) error {
+	_, errDelete := transaction.Execute(context, "DELETE FROM `two_factor_challenges` WHERE `supervisor_uuid` = ?", supervisorUUID)
+	if errDelete != nil {
+		return errDelete
+	}
+
+	return nil
+}
+
+func (model *twoFactorChallengeModel) expired() bool {
+	return model.ExpiresOn.Before(time.Now())
+}
+
+func (model *twoFactorChallengeModel) updateAttempts(context context.Context, transaction *database.Transaction) error {
+	_, errUpdate := transaction.Execute(context, "UPDATE `two_factor_challenges` SET `attempts` = `attempts` + 1 WHERE `token` = ?", model.Token)
+	if errUpdate != nil {
+		return errUpdate
+	}
+
+	model.Attempts++
+
+	return nil
+}
+
+func (model *twoFactorChallengeModel) delete(context context.Context, transaction *database.Transaction) error {
+	_, errDelete := transaction.Execute(context, "DELETE FROM `two_factor_challenges` WHERE `token` = ?", model.Token)
+	if errDelete != nil {
+		return errDelete
+	}
+
+	return nil
+}
+
+type TwoFactorChallenge struct {
+	model *twoFactorChallengeModel
+	valid bool
+}
+
+type TwoFactorEnrollment struct {
+	model         *supervisorTwoFactorModel
+	secret        string
+	uri           string
+	recoveryCodes []string
+	valid         bool
+}
+
+type TwoFactorRequiredError struct {
+	challenge *TwoFactorChallenge
+}
+
+func (twoFactorRequiredError *TwoFactorRequiredError) Challenge() *TwoFactorChallenge {
+	return twoFactorRequiredError.challenge
+}
+
+func (twoFactorRequiredError *TwoFactorRequiredError) Error() string {
+	if twoFactorRequiredError.challenge.model.Enrollment {
+		return "two factor enrollment required"
+	}
+
+	return "two factor code required"
+}
+
+var (
+	ErrTwoFactorChallengeInvalid  error = errors.New("two factor challenge invalid")
+	ErrTwoFactorChallengeExpired  error = errors.New("two factor challenge expired")
+	ErrTwoFactorEnrollmentInvalid error = errors.New("two factor enrollment invalid")
+	ErrTwoFactorCodeInvalid       error = errors.New("two factor code invalid")
+	ErrTwoFactorEnrolled          error = errors.New("two factor enrolled")
+	ErrTwoFactorNotEnrolled       error = errors.New("two factor not enrolled")
+	ErrTwoFactorRequired          error = errors.New("two factor required")
+	ErrTwoFactorKeyInvalid        error = errors.New("two factor key invalid")
+)
+
+func newRecoveryCode() (string, error) {
+	raw := make([]byte, 10)
+	_, errRead := rand.Read(raw)
+	if errRead != nil {
+		return "", errRead
+	}
+
+	code := strings.ToLower(base32.StdEncoding.EncodeToString(raw))
+
+	return code[:8] + "-" + code[8:], nil
+}
+
+func hashRecoveryCode(code string) string {
+	normalized := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(code), "-", ""))
+	digest := sha256.Sum256([]byte(normalized))
+
+	return hex.EncodeToString(digest[:])
+}
+
+func getTwoFactorChallengeByToken(context context.Context, transaction *database.Transaction, challengeToken uuid.UUID) (*TwoFactorChallenge, error) {
+	challenge := new(TwoFactorChallenge)
+
+	var errGetModel error
+	challenge.model, errGetModel = getTwoFactorChallengeModelByToken(context, transaction, challengeToken)
+	if errGetModel != nil {
+		return nil, errGetModel
+	}
+
+	challenge.valid = true
+
+	return challenge, nil
+}
+
+func (challenge *TwoFactorChallenge) fail(context context.Context, transaction *database.Transaction) error {
+	errUpdateModel := challenge.model.updateAttempts(context, transaction)
+	if errUpdateModel != nil {
+		return errUpdateModel
+	}
+
+	if challenge.model.Attempts >= twoFactorChallengeMaximumAttempts {
+		return challenge.end(context, transaction)
+	}
+
+	return nil
+}
+
+func (challenge *TwoFactorChallenge) end(context context.Context, transaction *database.Transaction) error {
+	errDeleteModel := challenge.model.delete(context, transaction)
+	if errDeleteModel != nil {
+		return errDeleteModel
+	}
+
+	challenge.valid = false
+
+	return nil
+}
+
+func (user *User) challengeSecondFactor(context context.Context, transaction *database.Transaction) (*TwoFactorChallenge, error) {
+	if !user.usesPasswordHash() || !user.holds("supervisor") {
+		return nil, nil
+	}
+
+	enrollment := false
+	twoFactorModel, errGetTwoFactorModel := getSupervisorTwoFactorModelBySupervisorUUID(context, transaction, user.model.UUID)
+	if errGetTwoFactorModel != nil && !errors.Is(errGetTwoFactorModel, sql.ErrNoRows) {
+		return nil, errGetTwoFactorModel
+	}
+	if errGetTwoFactorModel != nil || !twoFactorModel.confirmed() {
+		supervisor, errGetSupervisor := getSupervisorByUser(context, transaction, user)
+		if errGetSupervisor != nil {
+			return nil, errGetSupervisor
+		}
+		if !supervisor.company.model.TwoFactorRequired {
+			return nil, nil
+		}
+
+		enrollment = true
+	}
+
+	challenge := new(TwoFactorChallenge)
+
+	var errInsertModel error
+	challenge.model, errInsertModel = insertTwoFactorChallengeModel(context, transaction, user.model.UUID, enrollment)
+	if errInsertModel != nil {
+		return nil, errInsertModel
+	}
+
+	challenge.valid = true
+
+	return challenge, nil
+}
+
+// twoFactorKey returns the application key that seals authenticator secrets at
+// rest, configured as 32 base64-encoded bytes.
+func twoFactorKey() ([]byte, error) {
+	key, errDecode := base64.StdEncoding.DecodeString(configuration.Application.GetString("twoFactorKey"))
+	if errDecode != nil {
+		return nil, errors.Join(ErrTwoFactorKeyInvalid, errDecode)
+	}
+	if len(key) == 0 {
+		return nil, ErrTwoFactorKeyInvalid
+	}
+
+	return key, nil
+}
+
+func (user *User) beginTwoFactorEnrollment(context context.Context, transaction *database.Transaction) (*TwoFactorEnrollment, error) {
+	existingModel, errGetExistingModel := getSupervisorTwoFactorModelBySupervisorUUID(context, transaction, user.model.UUID)
+	if errGetExistingModel == nil && existingModel.confirmed() {
+		return nil, ErrTwoFactorEnrolled
+	} else if errGetExistingModel != nil && !errors.Is(errGetExistingModel, sql.ErrNoRows) {
+		return nil, errGetExistingModel
+	}
+
+	secret, errNewSecret := totp.NewSecret()
+	if errNewSecret != nil {
+		return nil, errNewSecret
+	}
+
+	key, errGetKey := twoFactorKey()
+	if errGetKey != nil {
+		return nil, errGetKey
+	}
+
+	sealedSecret, errSeal := totp.Seal(key, secret, user.model.UUID.String())
+	if errSeal != nil {
+		return nil, errSeal
+	}
+
+	enrollment := new(TwoFactorEnrollment)
+	enrollment.secret = secret
+
+	var errUpsertModel error
+	enrollment.model, errUpsertModel = upsertSupervisorTwoFactorModel(context, transaction, user.model.UUID, sealedSecret)
+	if errUpsertModel != nil {
+		return nil, errUpsertModel
+	}
+
+	enrollment.uri = totp.ProvisioningURI(configuration.Application.GetString("twoFactorIssuer"), user.model.Identity, secret)
+	enrollment.valid = true
+
+	return enrollment, nil
+}
+
+func (user *User) confirmTwoFactorEnrollment(context context.Context, transaction *database.Transaction, code string) (*TwoFactorEnrollment, error) {
+	enrollment := new(TwoFactorEnrollment)
+
+	var errGetModel error
+	enrollment.model, errGetModel = getSupervisorTwoFactorModelBySupervisorUUID(context, transaction, user.model.UUID)
+	if errors.Is(errGetModel, sql.ErrNoRows) {
+		return nil, ErrTwoFactorNotEnrolled
+	} else if errGetModel != nil {
+		return nil, errGetModel
+	}
+	if enrollment.model.confirmed() {
+		return nil, ErrTwoFactorEnrolled
+	}
+
+	secret, errOpenSecret := enrollment.model.secret()
+	if errOpenSecret != nil {
+		return nil, errOpenSecret
+	}
+
+	step, errValidate := totp.Validate(secret, code, time.Now(), enrollment.model.lastStep())
+	if errValidate != nil {
+		return nil, errValidate
+	}
+	if step == 0 {
+		return nil, ErrTwoFactorCodeInvalid
+	}
+
+	errUpdateLastUsedStep := enrollment.model.updateLastUsedStep(context, transaction, step)
+	if errUpdateLastUsedStep != nil {
+		return nil, errUpdateLastUsedStep
+	}
+
+	errUpdateConfirmedOn := enrollment.model.updateConfirmedOn(context, transaction)
+	if errUpdateConfirmedOn != nil {
+		return nil, errUpdateConfirmedOn
+	}
+
+	errDeleteRecoveryCodes := deleteSupervisorRecoveryCodeModels(context, transaction, user.model.UUID)
+	if errDeleteRecoveryCodes != nil {
+		return nil, errDeleteRecoveryCodes
+	}
+
+	enrollment.recoveryCodes = make([]string, 0, twoFactorRecoveryCodeCount)
+	for len(enrollment.recoveryCodes) < twoFactorRecoveryCodeCount {
+		recoveryCode, errNewRecoveryCode := newRecoveryCode()
+		if errNewRecoveryCode != nil {
+			return nil, errNewRecoveryCode
+		}
+
+		errInsertRecoveryCode := insertSupervisorRecoveryCodeModel(context, transaction, user.model.UUID, hashRecoveryCode(recoveryCode))
+		if errInsertRecoveryCode != nil {
+			return nil, errInsertRecoveryCode
+		}
+
+		enrollment.recoveryCodes = append(enrollment.recoveryCodes, recoveryCode)
+	}
+
+	enrollment.valid = true
+
+	return enrollment, nil
+}
+
+func (user *User) verifySecondFactor(context context.Context, transaction *database.Transaction, code string) (bool, error) {
+	model, errGetModel := getSupervisorTwoFactorModelBySupervisorUUID(context, transaction, user.model.UUID)
+	if errors.Is(errGetModel, sql.ErrNoRows) {
+		return false, ErrTwoFactorNotEnrolled
+	} else if errGetModel != nil {
+		return false, errGetModel
+	}
+	if !model.confirmed() {
+		return false, ErrTwoFactorNotEnrolled
+	}
+
+	secret, errOpenSecret := model.secret()
+	if errOpenSecret != nil {
+		return false, errOpenSecret
+	}
+
+	step, errValidate := totp.Validate(secret, code, time.Now(), model.lastStep())
+	if errValidate != nil {
+		return false, errValidate
+	}
+	if step != 0 {
+		return false, model.updateLastUsedStep(context, transaction, step)
+	}
+
+	used, errUseRecoveryCode := updateSupervisorRecoveryCodeModelUsedOn(context, transaction, user.model.UUID, hashRecoveryCode(code))
+	if errUseRecoveryCode != nil {
+		return false, errUseRecoveryCode
+	}
+	if !used {
+		return false, ErrTwoFactorCodeInvalid
+	}
+
+	return true, nil
+}
+
+func (user *User) resetTwoFactor(context context.Context, transaction *database.Transaction) error {
+	model, errGetModel := getSupervisorTwoFactorModelBySupervisorUUID(context, transaction, user.model.UUID)
+	if errors.Is(errGetModel, sql.ErrNoRows) {
+		return ErrTwoFactorNotEnrolled
+	} else if errGetModel != nil {
+		return errGetModel
+	}
+
+	errDeleteChallenges := deleteTwoFactorChallengeModelsBySupervisorUUID(context, transaction, user.model.UUID)
+	if errDeleteChallenges != nil {
+		return errDeleteChallenges
+	}
+
+	errDeleteRecoveryCodes := deleteSupervisorRecoveryCodeModels(context, transaction, user.model.UUID)
+	if errDeleteRecoveryCodes != nil {
+		return errDeleteRecoveryCodes
+	}
+
+	return model.delete(context, transaction)
+}
+
+func BeginTwoFactorEnrollment(context context.Context, user *User) (*TwoFactorEnrollment, error) {
+	if !user.valid {
+		panic(ErrUserInvalid)
+	}
+	if !user.is("supervisor") {
+		return nil, ErrUserNotSupervisor
+	}
+
+	errPing := database.Ping(context)
+	if errPing != nil {
+		return nil, errPing
+	}
+
+	transaction, errBegin := database.Begin(context)
+	if errBegin != nil {
+		return nil, errBegin
+	}
+
+	enrollment, errBeginEnrollment := user.beginTwoFactorEnrollment(context, transaction)
+	if errBeginEnrollment != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errBeginEnrollment, errRollback)
+		}
+
+		return nil, errBeginEnrollment
+	}
+
+	errRecord := recordAudit(context, transaction, "Began two-factor enrollment.", user)
+	if errRecord != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errRecord, errRollback)
+		}
+
+		return nil, errRecord
+	}
+
+	errCommit := transaction.Commit()
+	if errCommit != nil {
+		return nil, errCommit
+	}
+
+	return enrollment, nil
+}
+
+func ConfirmTwoFactorEnrollment(context context.Context, user *User, code string) (*TwoFactorEnrollment, error) {
+	if !user.valid {
+		panic(ErrUserInvalid)
+	}
+	if !user.is("supervisor") {
+		return nil, ErrUserNotSupervisor
+	}
+
+	errPing := database.Ping(context)
+	if errPing != nil {
+		return nil, errPing
+	}
+
+	transaction, errBegin := database.Begin(context)
+	if errBegin != nil {
+		return nil, errBegin
+	}
+
+	enrollment, errConfirmEnrollment := user.confirmTwoFactorEnrollment(context, transaction, code)
+	if errConfirmEnrollment != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errConfirmEnrollment, errRollback)
+		}
+
+		return nil, errConfirmEnrollment
+	}
+
+	errRecord := recordAudit(context, transaction, "Enrolled in two-factor authentication.", user)
+	if errRecord != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errRecord, errRollback)
+		}
+
+		return nil, errRecord
+	}
+
+	errCommit := transaction.Commit()
+	if errCommit != nil {
+		return nil, errCommit
+	}
+
+	return enrollment, nil
+}
+
+func DisableTwoFactor(context context.Context, user *User, code string) error {
+	if !user.valid {
+		panic(ErrUserInvalid)
+	}
+	if !user.is("supervisor") {
+		return ErrUserNotSupervisor
+	}
+
+	errPing := database.Ping(context)
+	if errPing != nil {
+		return errPing
+	}
+
+	transaction, errBegin := database.Begin(context)
+	if errBegin != nil {
+		return errBegin
+	}
+
+	supervisor, errGetSupervisor := getSupervisorByUser(context, transaction, user)
+	if errGetSupervisor != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return errors.Join(errGetSupervisor, errRollback)
+		}
+
+		return errGetSupervisor
+	}
+	if supervisor.company.model.TwoFactorRequired {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return errors.Join(ErrTwoFactorRequired, errRollback)
+		}
+
+		return ErrTwoFactorRequired
+	}
+
+	_, errVerify := user.verifySecondFactor(context, transaction, code)
+	if errVerify != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return errors.Join(errVerify, errRollback)
+		}
+
+		return errVerify
+	}
+
+	errReset := user.resetTwoFactor(context, transaction)
+	if errReset != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return errors.Join(errReset, errRollback)
+		}
+
+		return errReset
+	}
+
+	errRecord := recordAudit(context, transaction, "Disabled two-factor authentication.", user)
+	if errRecord != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return errors.Join(errRecord, errRollback)
+		}
+
+		return errRecord
+	}
+
+	errCommit := transaction.Commit()
+	if errCommit != nil {
+		return errCommit
+	}
+
+	return nil
+}
+
+func BeginChallengedTwoFactorEnrollment(context context.Context, challengeToken uuid.UUID) (*TwoFactorEnrollment, error) {
+	errPing := database.Ping(context)
+	if errPing != nil {
+		return nil, errPing
+	}
+
+	transaction, errBegin := database.Begin(context)
+	if errBegin != nil {
+		return nil, errBegin
+	}
+
+	challenge, errGetChallenge := getTwoFactorChallengeByToken(context, transaction, challengeToken)
+	if errGetChallenge != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errGetChallenge, errRollback)
+		}
+
+		return nil, errGetChallenge
+	}
+	if challenge.model.expired() || !challenge.model.Enrollment {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(ErrTwoFactorChallengeInvalid, errRollback)
+		}
+
+		return nil, ErrTwoFactorChallengeInvalid
+	}
+
+	user, errGetUser := getUserByUUID(context, transaction, challenge.model.SupervisorUUID)
+	if errGetUser != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errGetUser, errRollback)
+		}
+
+		return nil, errGetUser
+	}
+
+	enrollment, errBeginEnrollment := user.beginTwoFactorEnrollment(context, transaction)
+	if errBeginEnrollment != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errBeginEnrollment, errRollback)
+		}
+
+		return nil, errBeginEnrollment
+	}
+
+	errRecord := recordAudit(context, transaction, "Began two-factor enrollment.", user)
+	if errRecord != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errRecord, errRollback)
+		}
+
+		return nil, errRecord
+	}
+
+	errCommit := transaction.Commit()
+	if errCommit != nil {
+		return nil, errCommit
+	}
+
+	return enrollment, nil
+}
+
+func VerifyTwoFactorChallenge(context context.Context, challengeToken uuid.UUID, code string, address string) (*User, *Session, *TwoFactorEnrollment, error) {
+	errPing := database.Ping(context)
+	if errPing != nil {
+		return nil, nil, nil, errPing
+	}
+
+	transaction, errBegin := database.Begin(context)
+	if errBegin != nil {
+		return nil, nil, nil, errBegin
+	}
+
+	challenge, errGetChallenge := getTwoFactorChallengeByToken(context, transaction, challengeToken)
+	if errGetChallenge != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, nil, nil, errors.Join(errGetChallenge, errRollback)
+		}
+
+		return nil, nil, nil, errGetChallenge
+	}
+	if challenge.model.expired() {
+		errEnd := challenge.end(context, transaction)
+		if errEnd != nil {
+			errRollback := transaction.Rollback()
+			if errRollback != nil {
+				return nil, nil, nil, errors.Join(errEnd, errRollback)
+			}
+
+			return nil, nil, nil, errEnd
+		}
+
+		errCommit := transaction.Commit()
+		if errCommit != nil {
+			return nil, nil, nil, errors.Join(ErrTwoFactorChallengeExpired, errCommit)
+		}
+
+		return nil, nil, nil, ErrTwoFactorChallengeExpired
+	}
+
+	user, errGetUser := getUserByUUID(context, transaction, challenge.model.SupervisorUUID)
+	if errGetUser != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, nil, nil, errors.Join(errGetUser, errRollback)
+		}
+
+		return nil, nil, nil, errGetUser
+	}
+
+	errLocked := user.checkLocked()
+	if errLocked == nil {
+		errLocked = checkLoginThrottle(context, transaction, user.model.Identity, address)
+	}
+	if errLocked != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, nil, nil, errors.Join(errLocked, errRollback)
+		}
+
+		return nil, nil, nil, errLocked
+	}
+
+	var enrollment *TwoFactorEnrollment
+	var message string
+	var errVerify error
+	if challenge.model.Enrollment {
+		enrollment, errVerify = user.confirmTwoFactorEnrollment(context, transaction, code)
+		message = "Enrolled in two-factor authentication and logged in."
+	} else {
+		var recovered bool
+		recovered, errVerify = user.verifySecondFactor(context, transaction, code)
+		if recovered {
+			message = "Logged in with two-factor recovery code."
+		} else {
+			message = "Logged in with two-factor code."
+		}
+	}
+	if errors.Is(errVerify, ErrTwoFactorCodeInvalid) {
+		errFail := challenge.fail(context, transaction)
+		if errFail != nil {
+			errRollback := transaction.Rollback()
+			if errRollback != nil {
+				return nil, nil, nil, errors.Join(errFail, errRollback)
+			}
+
+			return nil, nil, nil, errFail
+		}
+
+		errRecord := recordFailedLoginAttempt(context, transaction, user.model.Identity, address, fmt.Sprintf("Failed two-factor code from %s (attempt %d of %d).", address, challenge.model.Attempts, twoFactorChallengeMaximumAttempts), user)
+		if errRecord != nil {
+			errRollback := transaction.Rollback()
+			if errRollback != nil {
+				return nil, nil, nil, errors.Join(errRecord, errRollback)
+			}
+
+			return nil, nil, nil, errRecord
+		}
+
+		errCommit := transaction.Commit()
+		if errCommit != nil {
+			return nil, nil, nil, errors.Join(ErrTwoFactorCodeInvalid, errCommit)
+		}
+
+		return nil, nil, nil, ErrTwoFactorCodeInvalid
+	} else if errVerify != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, nil, nil, errors.Join(errVerify, errRollback)
+		}
+
+		return nil, nil, nil, errVerify
+	}
+
+	errEnd := challenge.end(context, transaction)
+	if errEnd != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, nil, nil, errors.Join(errEnd, errRollback)
+		}
+
+		return nil, nil, nil, errEnd
+	}
+
+	errDeleteFailedLogins := deleteFailedLoginModelsByIdentity(context, transaction, user.model.Identity)
+	if errDeleteFailedLogins != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, nil, nil, errors.Join(errDeleteFailedLogins, errRollback)
+		}
+
+		return nil, nil, nil, errDeleteFailedLogins
+	}
+
+	session, errStartSession := beginSession(context, transaction, user)
+	if errStartSession != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, nil, nil, errors.Join(errStartSession, errRollback)
+		}
+
+		return nil, nil, nil, errStartSession
+	}
+
+	errRecord := recordAudit(context, transaction, message, user)
+	if errRecord != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, nil, nil, errors.Join(errRecord, errRollback)
+		}
+
+		return nil, nil, nil, errRecord
+	}
+
+	errCommit := transaction.Commit()
+	if errCommit != nil {
+		return nil, nil, nil, errCommit
+	}
+
+	return user, session, enrollment, nil
+}
+
+func SetCompanyTwoFactorRequired(context context.Context, actor *User, companyUUID uuid.UUID, required bool) (*Company, error) {
+	if !actor.valid {
+		panic(ErrUserInvalid)
+	}
+	if !actor.is("administrator") {
+		return nil, ErrUserNotAdministrator
+	}
+
+	errPing := database.Ping(context)
+	if errPing != nil {
+		return nil, errPing
+	}
+
+	transaction, errBegin := database.Begin(context)
+	if errBegin != nil {
+		return nil, errBegin
+	}
+
+	company, errGetCompany := getCompanyByUUID(context, transaction, companyUUID)
+	if errGetCompany != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errGetCompany, errRollback)
+		}
+
+		return nil, errGetCompany
+	}
+
+	errUpdateModel := company.model.updateTwoFactorRequired(context, transaction, required)
+	if errUpdateModel != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errUpdateModel, errRollback)
+		}
+
+		return nil, errUpdateModel
+	}
+
+	message := fmt.Sprintf("Stopped requiring two-factor authentication for company %s.", company.model.UUID)
+	if required {
+		message = fmt.Sprintf("Required two-factor authentication for company %s.", company.model.UUID)
+	}
+
+	errRecord := recordAudit(context, transaction, message, actor)
+	if errRecord != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errRecord, errRollback)
+		}
+
+		return nil, errRecord
+	}
+
+	errCommit := transaction.Commit()
+	if errCommit != nil {
+		return nil, errCommit
+	}
+
+	return company, nil
+}
+
+func ResetSupervisorTwoFactor(context context.Context, actor *User, userUUID uuid.UUID) (*User, error) {
+	if !actor.valid {
+		panic(ErrUserInvalid)
+	}
+	if !actor.is("administrator") {
+		return nil, ErrUserNotAdministrator
+	}
+
+	errPing := database.Ping(context)
+	if errPing != nil {
+		return nil, errPing
+	}
+
+	transaction, errBegin := database.Begin(context)
+	if errBegin != nil {
+		return nil, errBegin
+	}
+
+	user, errGetUser := getUserByUUID(context, transaction, userUUID)
+	if errGetUser != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errGetUser, errRollback)
+		}
+
+		return nil, errGetUser
+	}
+	if !user.holds("supervisor") {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(ErrUserNotSupervisor, errRollback)
+		}
+
+		return nil, ErrUserNotSupervisor
+	}
+
+	errReset := user.resetTwoFactor(context, transaction)
+	if errReset != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errReset, errRollback)
+		}
+
+		return nil, errReset
+	}
+
+	errRecord := recordAudit(context, transaction, fmt.Sprintf("Reset two-factor authentication for supervisor %s.", user.model.UUID), actor)
+	if errRecord != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errRecord, errRollback)
+		}
+
+		return nil, errRecord
+	}
+
+	errCommit := transaction.Commit()
+	if errCommit != nil {
+		return nil, errCommit
+	}
+
+	return user, nil
+}
+
+func (challenge *TwoFactorChallenge) MarshalJSON() ([]byte, error) {
+	if !challenge.valid {
+		panic(ErrTwoFactorChallengeInvalid)
+	}
+
+	return json.Marshal(map[string]any{
+		"token":      challenge.model.Token,
+		"enrollment": challenge.model.Enrollment,
+		"expiresOn":  challenge.model.ExpiresOn,
+	})
+}
+
+func (enrollment *TwoFactorEnrollment) MarshalJSON() ([]byte, error) {
+	if !enrollment.valid {
+		panic(ErrTwoFactorEnrollmentInvalid)
+	}
+
+	enrollmentMap := map[string]any{
+		"createdOn": enrollment.model.CreatedOn,
+	}
+	if enrollment.model.ConfirmedOn.Valid {
+		enrollmentMap["confirmedOn"] = enrollment.model.ConfirmedOn.Time
+	} else {
+		enrollmentMap["secret"] = enrollment.secret
+		enrollmentMap["uri"] = enrollment.uri
+	}
+	if enrollment.recoveryCodes != nil {
+		enrollmentMap["recoveryCodes"] = enrollment.recoveryCodes
+	}
+
+	return json.Marshal(enrollmentMap)
+}
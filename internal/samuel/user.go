@@ -2,22 +2,39 @@ package samuel
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/sorucoder/samuel/internal/database"
-	"github.com/sorucoder/samuel/internal/ldap"
 	"golang.org/x/crypto/bcrypt"
 )
 
 type userModel struct {
-	UUID         uuid.UUID `db:"uuid"`
-	Identity     string    `db:"identity"`
-	PasswordHash []byte    `db:"password_hash"`
-	RoleID       string    `db:"role_id"`
-	CreatedOn    time.Time `db:"created_on"`
+	UUID               uuid.UUID    `db:"uuid"`
+	Identity           string       `db:"identity"`
+	PasswordHash       []byte       `db:"password_hash"`
+	RoleID             string       `db:"role_id"`
+	CreatedOn          time.Time    `db:"created_on"`
+	ActivatedOn        sql.NullTime `db:"activated_on"`
+	Provider           string       `db:"provider"`
+	DirectoryRemovedOn sql.NullTime `db:"directory_removed_on"`
+	LockedUntil        sql.NullTime `db:"locked_until"`
+}
+
+func insertUserModel(context context.Context, transaction *database.Transaction, userIdentity string, userProvider string, userPasswordHash []byte, userRoleID string) (*userModel, error) {
+	userUUID := uuid.New()
+
+	_, errInsert := transaction.Execute(context, "INSERT INTO `users` (`uuid`, `identity`, `provider`, `password_hash`, `role_id`) VALUE (?, ?, ?, ?, ?)", userUUID, userIdentity, userProvider, userPasswordHash, userRoleID)
+	if errInsert != nil {
+		return nil, errInsert
+	}
+
+	return getUserModelByUUID(context, transaction, userUUID)
 }
 
 func getUserModelByUUID(context context.Context, transaction *database.Transaction, userUUID uuid.UUID) (*userModel, error) {
@@ -42,8 +59,99 @@ func getUserModelByIdentity(context context.Context, transaction *database.Trans
 	return model, nil
 }
 
-func (model *userModel) is(roleID string) bool {
-	return model.RoleID == roleID
+func countUserModelsByIdentity(context context.Context, transaction *database.Transaction, userIdentity string) (int64, error) {
+	var count int64
+
+	errGet := transaction.Get(context, &count, "SELECT COUNT(*) FROM `users` WHERE `identity` = ?", userIdentity)
+	if errGet != nil {
+		return 0, errGet
+	}
+
+	return count, nil
+}
+
+type directoryUserModel struct {
+	userModel
+	DirectoryRoleID string `db:"directory_role_id"`
+}
+
+func selectDirectoryUserModels(context context.Context, transaction *database.Transaction) ([]*directoryUserModel, error) {
+	models := make([]*directoryUserModel, 0)
+
+	errSelect := transaction.Select(context, &models, "SELECT `users`.*, `user_roles`.`role_id` AS `directory_role_id` FROM `users` JOIN `user_roles` ON `user_roles`.`user_uuid` = `users`.`uuid` WHERE `user_roles`.`role_id` IN ('instructor', 'student') ORDER BY `users`.`identity`, `user_roles`.`role_id`")
+	if errSelect != nil {
+		return nil, errSelect
+	}
+
+	return models, nil
+}
+
+func (model *userModel) activated() bool {
+	return model.ActivatedOn.Valid
+}
+
+func (model *userModel) updateActivatedOn(context context.Context, transaction *database.Transaction) error {
+	_, errUpdate := transaction.Execute(context, "UPDATE `users` SET `activated_on` = NOW() WHERE `uuid` = ?", model.UUID)
+	if errUpdate != nil {
+		return errUpdate
+	}
+
+	errGetActivatedOn := transaction.Get(context, &model.ActivatedOn, "SELECT `activated_on` FROM `users` WHERE `uuid` = ?", model.UUID)
+	if errGetActivatedOn != nil {
+		return errGetActivatedOn
+	}
+
+	return nil
+}
+
+func (model *userModel) removedFromDirectory() bool {
+	return model.DirectoryRemovedOn.Valid
+}
+
+func (model *userModel) updateDirectoryRemovedOn(context context.Context, transaction *database.Transaction, removed bool) error {
+	if removed {
+		_, errUpdate := transaction.Execute(context, "UPDATE `users` SET `directory_removed_on` = NOW() WHERE `uuid` = ?", model.UUID)
+		if errUpdate != nil {
+			return errUpdate
+		}
+	} else {
+		_, errUpdate := transaction.Execute(context, "UPDATE `users` SET `directory_removed_on` = NULL WHERE `uuid` = ?", model.UUID)
+		if errUpdate != nil {
+			return errUpdate
+		}
+	}
+
+	errGetDirectoryRemovedOn := transaction.Get(context, &model.DirectoryRemovedOn, "SELECT `directory_removed_on` FROM `users` WHERE `uuid` = ?", model.UUID)
+	if errGetDirectoryRemovedOn != nil {
+		return errGetDirectoryRemovedOn
+	}
+
+	return nil
+}
+
+func (model *userModel) locked() bool {
+	return model.LockedUntil.Valid && model.LockedUntil.Time.After(time.Now())
+}
+
+func (model *userModel) updateLockedUntil(context context.Context, transaction *database.Transaction, lockout time.Duration) error {
+	if lockout > 0 {
+		_, errUpdate := transaction.Execute(context, "UPDATE `users` SET `locked_until` = DATE_ADD(NOW(), INTERVAL ? SECOND) WHERE `uuid` = ?", int64(lockout.Seconds()), model.UUID)
+		if errUpdate != nil {
+			return errUpdate
+		}
+	} else {
+		_, errUpdate := transaction.Execute(context, "UPDATE `users` SET `locked_until` = NULL WHERE `uuid` = ?", model.UUID)
+		if errUpdate != nil {
+			return errUpdate
+		}
+	}
+
+	errGetLockedUntil := transaction.Get(context, &model.LockedUntil, "SELECT `locked_until` FROM `users` WHERE `uuid` = ?", model.UUID)
+	if errGetLockedUntil != nil {
+		return errGetLockedUntil
+	}
+
+	return nil
 }
 
 func (model *userModel) updatePasswordHash(context context.Context, transaction *database.Transaction, newUserPasswordHash []byte) error {
@@ -58,18 +166,24 @@ func (model *userModel) updatePasswordHash(context context.Context, transaction
 }
 
 type User struct {
-	model *userModel
-	role  *Role
-	valid bool
+	model        *userModel
+	role         *Role
+	roles        []*Role
+	coordinators []*coordinatorModel
+	valid        bool
 }
 
 var (
-	ErrUserInvalid          error = errors.New("user invalid")
-	ErrUserUsesLDAP         error = errors.New("user uses ldap")
-	ErrUserNotAdministrator error = errors.New("user not administrator")
-	ErrUserNotInstructor    error = errors.New("user not instructor")
-	ErrUserNotSupervisor    error = errors.New("user not supervisor")
-	ErrUserNotStudent       error = errors.New("user not student")
+	ErrUserInvalid            error = errors.New("user invalid")
+	ErrUserUsesLDAP           error = errors.New("user uses ldap")
+	ErrUserExists             error = errors.New("user exists")
+	ErrUserNotActivated       error = errors.New("user not activated")
+	ErrUserProviderMismatch   error = errors.New("user provider mismatch")
+	ErrUserCredentialsInvalid error = errors.New("user credentials invalid")
+	ErrUserNotAdministrator   error = errors.New("user not administrator")
+	ErrUserNotInstructor      error = errors.New("user not instructor")
+	ErrUserNotSupervisor      error = errors.New("user not supervisor")
+	ErrUserNotStudent         error = errors.New("user not student")
 )
 
 func getUserByUUID(context context.Context, transaction *database.Transaction, userUUID uuid.UUID) (*User, error) {
@@ -81,10 +195,9 @@ func getUserByUUID(context context.Context, transaction *database.Transaction, u
 		return nil, errGetModel
 	}
 
-	var errGetRole error
-	user.role, errGetRole = getRoleByID(context, transaction, user.model.RoleID)
-	if errGetRole != nil {
-		return nil, errGetRole
+	errLoadRoles := user.loadRoles(context, transaction, user.model.RoleID)
+	if errLoadRoles != nil {
+		return nil, errLoadRoles
 	}
 
 	user.valid = true
@@ -101,10 +214,9 @@ func getUserByIdentity(context context.Context, transaction *database.Transactio
 		return nil, errGetModel
 	}
 
-	var errGetRole error
-	user.role, errGetRole = getRoleByID(context, transaction, user.model.RoleID)
-	if errGetRole != nil {
-		return nil, errGetRole
+	errLoadRoles := user.loadRoles(context, transaction, user.model.RoleID)
+	if errLoadRoles != nil {
+		return nil, errLoadRoles
 	}
 
 	user.valid = true
@@ -121,10 +233,17 @@ func getUserBySession(context context.Context, transaction *database.Transaction
 		return nil, errGetModel
 	}
 
-	var errGetRole error
-	user.role, errGetRole = getRoleByID(context, transaction, user.model.RoleID)
-	if errGetRole != nil {
-		return nil, errGetRole
+	errLoadRoles := user.loadRoles(context, transaction, session.model.ActiveRoleID.String)
+	if errLoadRoles != nil {
+		return nil, errLoadRoles
+	}
+
+	if user.is("instructor") {
+		var errSelectCoordinators error
+		user.coordinators, errSelectCoordinators = selectCoordinatorModelsByInstructorUUID(context, transaction, user.model.UUID)
+		if errSelectCoordinators != nil {
+			return nil, errSelectCoordinators
+		}
 	}
 
 	user.valid = true
@@ -141,10 +260,9 @@ func getUserByPasswordChange(context context.Context, transaction *database.Tran
 		return nil, errGetModel
 	}
 
-	var errGetRole error
-	user.role, errGetRole = getRoleByID(context, transaction, user.model.RoleID)
-	if errGetRole != nil {
-		return nil, errGetRole
+	errLoadRoles := user.loadRoles(context, transaction, user.model.RoleID)
+	if errLoadRoles != nil {
+		return nil, errLoadRoles
 	}
 
 	user.valid = true
@@ -152,7 +270,55 @@ func getUserByPasswordChange(context context.Context, transaction *database.Tran
 	return user, nil
 }
 
-func LoginUser(context context.Context, userIdentity string, userPassword string) (*User, *Session, error) {
+func GetAdministratorByIdentity(context context.Context, administratorIdentity string) (*User, error) {
+	errPing := database.Ping(context)
+	if errPing != nil {
+		return nil, errPing
+	}
+
+	transaction, errBegin := database.Begin(context)
+	if errBegin != nil {
+		return nil, errBegin
+	}
+
+	administrator, errGetAdministrator := getUserByIdentity(context, transaction, administratorIdentity)
+	if errGetAdministrator != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errGetAdministrator, errRollback)
+		}
+
+		return nil, errGetAdministrator
+	}
+
+	// Commands and scheduled jobs have no session to switch roles with, so act as administrator whenever the role is held.
+	errLoadRoles := administrator.loadRoles(context, transaction, "administrator")
+	if errLoadRoles != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errLoadRoles, errRollback)
+		}
+
+		return nil, errLoadRoles
+	}
+	if !administrator.is("administrator") {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(ErrUserNotAdministrator, errRollback)
+		}
+
+		return nil, ErrUserNotAdministrator
+	}
+
+	errCommit := transaction.Commit()
+	if errCommit != nil {
+		return nil, errCommit
+	}
+
+	return administrator, nil
+}
+
+func LoginUser(context context.Context, userIdentity string, userPassword string, address string) (*User, *Session, error) {
 	errPing := database.Ping(context)
 	if errPing != nil {
 		return nil, nil, errPing
@@ -163,8 +329,28 @@ func LoginUser(context context.Context, userIdentity string, userPassword string
 		return nil, nil, errBegin
 	}
 
+	errThrottle := checkLoginThrottle(context, transaction, userIdentity, address)
+	if errThrottle != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, nil, errors.Join(errThrottle, errRollback)
+		}
+
+		return nil, nil, errThrottle
+	}
+
 	user, errGetUser := getUserByIdentity(context, transaction, userIdentity)
-	if errGetUser != nil {
+	provisioned := false
+	if errors.Is(errGetUser, sql.ErrNoRows) {
+		user, errGetUser = provisionUserOnFirstLogin(context, transaction, userIdentity, userPassword)
+		if errors.Is(errGetUser, ErrUserNotProvisionable) {
+			errGetUser = errors.Join(sql.ErrNoRows, errGetUser)
+		}
+		provisioned = errGetUser == nil
+	}
+	if errors.Is(errGetUser, sql.ErrNoRows) || errors.Is(errGetUser, ErrUserCredentialsInvalid) {
+		return nil, nil, failLogin(context, transaction, userIdentity, address, nil, errGetUser)
+	} else if errGetUser != nil {
 		errRollback := transaction.Rollback()
 		if errRollback != nil {
 			return nil, nil, errors.Join(errGetUser, errRollback)
@@ -173,19 +359,77 @@ func LoginUser(context context.Context, userIdentity string, userPassword string
 		return nil, nil, errGetUser
 	}
 
-	switch user.model.RoleID {
-	case "administrator", "instructor", "student":
-		errAuthenticate := ldap.Authenticate(context, userIdentity, userPassword)
+	if !provisioned {
+		errAuthenticate := user.authenticate(context, userPassword)
 		if errAuthenticate != nil {
-			return nil, nil, errAuthenticate
+			return nil, nil, failLogin(context, transaction, userIdentity, address, user, errors.Join(ErrUserCredentialsInvalid, errAuthenticate))
 		}
-	case "supervisor":
-		errAuthenticate := bcrypt.CompareHashAndPassword(user.model.PasswordHash, []byte(userPassword))
-		if errAuthenticate != nil {
-			return nil, nil, errAuthenticate
+	}
+
+	// A locked account answers like a wrong password so that lockouts cannot be used to confirm a guess.
+	if user.model.locked() {
+		return nil, nil, failLogin(context, transaction, userIdentity, address, user, ErrUserCredentialsInvalid)
+	}
+
+	if !user.model.activated() {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, nil, errors.Join(ErrUserNotActivated, errRollback)
+		}
+
+		return nil, nil, ErrUserNotActivated
+	}
+
+	if user.model.Provider == "ldap" {
+		errSynchronizeRoles := user.synchronizeRolesWithDirectory(context, transaction)
+		if errSynchronizeRoles != nil {
+			errRollback := transaction.Rollback()
+			if errRollback != nil {
+				return nil, nil, errors.Join(errSynchronizeRoles, errRollback)
+			}
+
+			return nil, nil, errSynchronizeRoles
 		}
 	}
 
+	challenge, errChallenge := user.challengeSecondFactor(context, transaction)
+	if errChallenge != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, nil, errors.Join(errChallenge, errRollback)
+		}
+
+		return nil, nil, errChallenge
+	}
+	if challenge != nil {
+		errRecord := recordAudit(context, transaction, "Passed password check; awaiting two-factor code.", user)
+		if errRecord != nil {
+			errRollback := transaction.Rollback()
+			if errRollback != nil {
+				return nil, nil, errors.Join(errRecord, errRollback)
+			}
+
+			return nil, nil, errRecord
+		}
+
+		errCommit := transaction.Commit()
+		if errCommit != nil {
+			return nil, nil, errCommit
+		}
+
+		return nil, nil, &TwoFactorRequiredError{challenge: challenge}
+	}
+
+	errDeleteFailedLogins := deleteFailedLoginModelsByIdentity(context, transaction, userIdentity)
+	if errDeleteFailedLogins != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, nil, errors.Join(errDeleteFailedLogins, errRollback)
+		}
+
+		return nil, nil, errDeleteFailedLogins
+	}
+
 	session, errStartSession := beginSession(context, transaction, user)
 	if errStartSession != nil {
 		errRollback := transaction.Rollback()
@@ -214,6 +458,121 @@ func LoginUser(context context.Context, userIdentity string, userPassword string
 	return user, session, nil
 }
 
+func LoginExternalUser(context context.Context, provider string, userIdentity string, acceptedProviders []string) (*User, *Session, error) {
+	errPing := database.Ping(context)
+	if errPing != nil {
+		return nil, nil, errPing
+	}
+
+	transaction, errBegin := database.Begin(context)
+	if errBegin != nil {
+		return nil, nil, errBegin
+	}
+
+	user, errGetUser := getUserByIdentity(context, transaction, userIdentity)
+	if errGetUser != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, nil, errors.Join(errGetUser, errRollback)
+		}
+
+		return nil, nil, errGetUser
+	}
+	if !slices.Contains(acceptedProviders, user.model.Provider) {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, nil, errors.Join(ErrUserProviderMismatch, errRollback)
+		}
+
+		return nil, nil, ErrUserProviderMismatch
+	}
+
+	errLocked := user.checkLocked()
+	if errLocked != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, nil, errors.Join(errLocked, errRollback)
+		}
+
+		return nil, nil, errLocked
+	}
+	if !user.model.activated() {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, nil, errors.Join(ErrUserNotActivated, errRollback)
+		}
+
+		return nil, nil, ErrUserNotActivated
+	}
+
+	if user.model.Provider == "ldap" {
+		errSynchronizeRoles := user.synchronizeRolesWithDirectory(context, transaction)
+		if errSynchronizeRoles != nil {
+			errRollback := transaction.Rollback()
+			if errRollback != nil {
+				return nil, nil, errors.Join(errSynchronizeRoles, errRollback)
+			}
+
+			return nil, nil, errSynchronizeRoles
+		}
+	}
+
+	challenge, errChallenge := user.challengeSecondFactor(context, transaction)
+	if errChallenge != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, nil, errors.Join(errChallenge, errRollback)
+		}
+
+		return nil, nil, errChallenge
+	}
+	if challenge != nil {
+		errRecord := recordAudit(context, transaction, fmt.Sprintf("Passed %s check; awaiting two-factor code.", provider), user)
+		if errRecord != nil {
+			errRollback := transaction.Rollback()
+			if errRollback != nil {
+				return nil, nil, errors.Join(errRecord, errRollback)
+			}
+
+			return nil, nil, errRecord
+		}
+
+		errCommit := transaction.Commit()
+		if errCommit != nil {
+			return nil, nil, errCommit
+		}
+
+		return nil, nil, &TwoFactorRequiredError{challenge: challenge}
+	}
+
+	session, errStartSession := beginSession(context, transaction, user)
+	if errStartSession != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, nil, errors.Join(errStartSession, errRollback)
+		}
+
+		return nil, nil, errStartSession
+	}
+
+	errRecord := recordAudit(context, transaction, fmt.Sprintf("Logged in with %s.", provider), user)
+	if errRecord != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, nil, errors.Join(errRecord, errRollback)
+		}
+
+		return nil, nil, errRecord
+	}
+
+	errCommit := transaction.Commit()
+	if errCommit != nil {
+		return nil, nil, errCommit
+	}
+
+	return user, session, nil
+}
+
 func AuthenticateSession(context context.Context, sessionToken uuid.UUID) (*User, *Session, error) {
 	errPing := database.Ping(context)
 	if errPing != nil {
@@ -262,6 +621,54 @@ func AuthenticateSession(context context.Context, sessionToken uuid.UUID) (*User
 	return user, session, nil
 }
 
+func VerifySession(context context.Context, sessionToken uuid.UUID) (*User, *Session, error) {
+	errPing := database.Ping(context)
+	if errPing != nil {
+		return nil, nil, errPing
+	}
+
+	transaction, errBegin := database.Begin(context)
+	if errBegin != nil {
+		return nil, nil, errBegin
+	}
+
+	session, errGetSession := getSessionByToken(context, transaction, sessionToken)
+	if errGetSession != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, nil, errors.Join(errGetSession, errRollback)
+		}
+
+		return nil, nil, errGetSession
+	}
+
+	if session.expired() {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, nil, errors.Join(ErrSessionExpired, errRollback)
+		}
+
+		return nil, nil, ErrSessionExpired
+	}
+
+	user, errGetUser := getUserBySession(context, transaction, session)
+	if errGetUser != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, nil, errors.Join(errGetUser, errRollback)
+		}
+
+		return nil, nil, errGetUser
+	}
+
+	errCommit := transaction.Commit()
+	if errCommit != nil {
+		return nil, nil, errCommit
+	}
+
+	return user, session, nil
+}
+
 func LogoutUser(context context.Context, user *User, session *Session) error {
 	if !user.valid {
 		panic(ErrUserInvalid)
@@ -308,6 +715,51 @@ func LogoutUser(context context.Context, user *User, session *Session) error {
 	return nil
 }
 
+func (user *User) loadRoles(context context.Context, transaction *database.Transaction, activeRoleID string) error {
+	roleModels, errSelectRoleModels := selectRoleModelsByUserUUID(context, transaction, user.model.UUID)
+	if errSelectRoleModels != nil {
+		return errSelectRoleModels
+	}
+
+	user.roles = make([]*Role, 0, len(roleModels))
+	user.role = nil
+	for _, roleModel := range roleModels {
+		role := &Role{
+			model: roleModel,
+			valid: true,
+		}
+		if roleModel.ID == activeRoleID {
+			user.role = role
+		}
+
+		user.roles = append(user.roles, role)
+	}
+
+	if user.role == nil {
+		var errGetRole error
+		user.role, errGetRole = getRoleByID(context, transaction, user.model.RoleID)
+		if errGetRole != nil {
+			return errGetRole
+		}
+	}
+
+	return nil
+}
+
+func (user *User) is(roleID string) bool {
+	return user.role.model.ID == roleID
+}
+
+func (user *User) holds(roleID string) bool {
+	for _, role := range user.roles {
+		if role.model.ID == roleID {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (user *User) Role() *Role {
 	if !user.valid {
 		panic(ErrUserInvalid)
@@ -316,16 +768,46 @@ func (user *User) Role() *Role {
 	return user.role
 }
 
+func (user *User) Roles() []*Role {
+	if !user.valid {
+		panic(ErrUserInvalid)
+	}
+
+	return user.roles
+}
+
 func (user *User) Is(roleID string) bool {
 	if !user.valid {
 		panic(ErrUserInvalid)
 	}
 
-	return user.model.is(roleID)
+	return user.is(roleID)
+}
+
+func (user *User) authenticate(context context.Context, password string) error {
+	authenticator, errGetAuthenticator := getAuthenticator(user.model.Provider)
+	if errGetAuthenticator != nil {
+		return errGetAuthenticator
+	}
+
+	return authenticator.Authenticate(context, &Credentials{
+		Identity:     user.model.Identity,
+		Password:     password,
+		PasswordHash: user.model.PasswordHash,
+	})
+}
+
+func (user *User) usesPasswordHash() bool {
+	authenticator, errGetAuthenticator := getAuthenticator(user.model.Provider)
+	if errGetAuthenticator != nil {
+		return false
+	}
+
+	return authenticator.UsesPasswordHash()
 }
 
 func (user *User) changePassword(context context.Context, transaction *database.Transaction, newUserPassword string) error {
-	if !user.model.is("supervisor") {
+	if !user.usesPasswordHash() {
 		return ErrUserUsesLDAP
 	}
 
@@ -347,9 +829,22 @@ func (user *User) MarshalJSON() ([]byte, error) {
 		panic(ErrUserInvalid)
 	}
 
-	return json.Marshal(map[string]any{
+	userMap := map[string]any{
 		"uuid":      user.model.UUID,
 		"role":      user.role,
+		"roles":     user.roles,
+		"provider":  user.model.Provider,
 		"createdOn": user.model.CreatedOn,
-	})
+	}
+	if user.model.ActivatedOn.Valid {
+		userMap["activatedOn"] = user.model.ActivatedOn.Time
+	}
+	if user.model.DirectoryRemovedOn.Valid {
+		userMap["directoryRemovedOn"] = user.model.DirectoryRemovedOn.Time
+	}
+	if user.model.locked() {
+		userMap["lockedUntil"] = user.model.LockedUntil.Time
+	}
+
+	return json.Marshal(userMap)
 }
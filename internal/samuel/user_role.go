@@ -0,0 +1,397 @@
+package samuel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/sorucoder/samuel/internal/configuration"
+	"github.com/sorucoder/samuel/internal/database"
+	"github.com/sorucoder/samuel/internal/ldap"
+)
+
+var (
+	roleProfileTables map[string]string = map[string]string{
+		"administrator": "administrators",
+		"instructor":    "instructors",
+		"supervisor":    "supervisors",
+		"student":       "students",
+	}
+)
+
+func insertUserRoleModel(context context.Context, transaction *database.Transaction, userUUID uuid.UUID, roleID string) error {
+	_, errInsert := transaction.Execute(context, "INSERT INTO `user_roles` (`user_uuid`, `role_id`) VALUE (?, ?)", userUUID, roleID)
+	if errInsert != nil {
+		return errInsert
+	}
+
+	return nil
+}
+
+func deleteUserRoleModel(context context.Context, transaction *database.Transaction, userUUID uuid.UUID, roleID string) error {
+	_, errDelete := transaction.Execute(context, "DELETE FROM `user_roles` WHERE `user_uuid` = ? AND `role_id` = ?", userUUID, roleID)
+	if errDelete != nil {
+		return errDelete
+	}
+
+	return nil
+}
+
+func countRoleProfileModelsByUserUUID(context context.Context, transaction *database.Transaction, roleID string, userUUID uuid.UUID) (int64, error) {
+	var count int64
+
+	errGet := transaction.Get(context, &count, fmt.Sprintf("SELECT COUNT(*) FROM `%s` WHERE `user_uuid` = ?", roleProfileTables[roleID]), userUUID)
+	if errGet != nil {
+		return 0, errGet
+	}
+
+	return count, nil
+}
+
+func getRoleProfileContentByUserUUID(context context.Context, transaction *database.Transaction, roleID string, userUUID uuid.UUID) (*UserProfileContent, error) {
+	var model struct {
+		FirstName string `db:"first_name"`
+		LastName  string `db:"last_name"`
+		Email     string `db:"email"`
+		Phone     string `db:"phone"`
+	}
+
+	errGet := transaction.Get(context, &model, fmt.Sprintf("SELECT `first_name`, `last_name`, `email`, `phone` FROM `%s` WHERE `user_uuid` = ?", roleProfileTables[roleID]), userUUID)
+	if errGet != nil {
+		return nil, errGet
+	}
+
+	return &UserProfileContent{
+		FirstName: model.FirstName,
+		LastName:  model.LastName,
+		Email:     model.Email,
+		Phone:     model.Phone,
+	}, nil
+}
+
+var (
+	ErrUserRoleHeld         error = errors.New("user role held")
+	ErrUserRoleNotHeld      error = errors.New("user role not held")
+	ErrUserRolePrimary      error = errors.New("user role primary")
+	ErrUserRoleIncompatible error = errors.New("user role incompatible")
+	ErrUserProfileMissing   error = errors.New("user profile missing")
+)
+
+func (user *User) grantRole(context context.Context, transaction *database.Transaction, roleID string) error {
+	if _, exists := roleProfileTables[roleID]; !exists {
+		return ErrUserRoleIncompatible
+	}
+	if user.holds(roleID) {
+		return ErrUserRoleHeld
+	}
+	if roleID == "supervisor" || user.model.RoleID == "supervisor" {
+		return ErrUserRoleIncompatible
+	}
+
+	profileCount, errCountProfiles := countRoleProfileModelsByUserUUID(context, transaction, roleID, user.model.UUID)
+	if errCountProfiles != nil {
+		return errCountProfiles
+	}
+	if profileCount == 0 {
+		if roleID != "administrator" {
+			return ErrUserProfileMissing
+		}
+
+		content, errGetContent := getRoleProfileContentByUserUUID(context, transaction, user.model.RoleID, user.model.UUID)
+		if errGetContent != nil {
+			return errGetContent
+		}
+
+		_, errInsertProfile := insertAdministratorModel(context, transaction, user.model.UUID, content)
+		if errInsertProfile != nil {
+			return errInsertProfile
+		}
+	}
+
+	errInsertModel := insertUserRoleModel(context, transaction, user.model.UUID, roleID)
+	if errInsertModel != nil {
+		return errInsertModel
+	}
+
+	role, errGetRole := getRoleByID(context, transaction, roleID)
+	if errGetRole != nil {
+		return errGetRole
+	}
+
+	user.roles = append(user.roles, role)
+
+	return nil
+}
+
+func (user *User) revokeRole(context context.Context, transaction *database.Transaction, roleID string) error {
+	if !user.holds(roleID) {
+		return ErrUserRoleNotHeld
+	}
+	if roleID == user.model.RoleID {
+		return ErrUserRolePrimary
+	}
+
+	errDeleteModel := deleteUserRoleModel(context, transaction, user.model.UUID, roleID)
+	if errDeleteModel != nil {
+		return errDeleteModel
+	}
+
+	user.roles = slices.DeleteFunc(user.roles, func(role *Role) bool {
+		return role.model.ID == roleID
+	})
+	if user.role.model.ID == roleID {
+		for _, role := range user.roles {
+			if role.model.ID == user.model.RoleID {
+				user.role = role
+			}
+		}
+	}
+
+	return nil
+}
+
+type directoryRoleMapping struct {
+	roleID string
+	group  string
+}
+
+func directoryRoleMappings() []*directoryRoleMapping {
+	mappings := make([]*directoryRoleMapping, 0)
+	for _, rawMapping := range configuration.LDAP.GetStringSlice("roleGroups") {
+		roleID, group, valid := strings.Cut(rawMapping, ":")
+		if !valid || !slices.Contains(directoryRoles, strings.TrimSpace(roleID)) {
+			continue
+		}
+
+		mappings = append(mappings, &directoryRoleMapping{
+			roleID: strings.TrimSpace(roleID),
+			group:  strings.TrimSpace(group),
+		})
+	}
+
+	return mappings
+}
+
+func (user *User) synchronizeRolesWithDirectory(context context.Context, transaction *database.Transaction) error {
+	mappings := directoryRoleMappings()
+	if len(mappings) == 0 {
+		return nil
+	}
+
+	entry, errLookup := ldap.Lookup(context, user.model.Identity)
+	if errLookup != nil {
+		return errLookup
+	}
+
+	mappedRoleIDs := make(map[string]bool, len(mappings))
+	for _, mapping := range mappings {
+		for _, group := range entry.Groups {
+			if strings.EqualFold(mapping.group, group) {
+				mappedRoleIDs[mapping.roleID] = true
+			}
+		}
+	}
+
+	for _, roleID := range directoryRoles {
+		switch {
+		case mappedRoleIDs[roleID] && !user.holds(roleID):
+			errGrant := user.grantRole(context, transaction, roleID)
+			if errors.Is(errGrant, ErrUserProfileMissing) {
+				continue
+			} else if errGrant != nil {
+				return errGrant
+			}
+
+			errRecord := recordAudit(context, transaction, fmt.Sprintf("Granted %s role from directory.", roleID), user)
+			if errRecord != nil {
+				return errRecord
+			}
+		case !mappedRoleIDs[roleID] && user.holds(roleID) && roleID != user.model.RoleID:
+			errRevoke := user.revokeRole(context, transaction, roleID)
+			if errRevoke != nil {
+				return errRevoke
+			}
+
+			errRecord := recordAudit(context, transaction, fmt.Sprintf("Revoked %s role from directory.", roleID), user)
+			if errRecord != nil {
+				return errRecord
+			}
+		}
+	}
+
+	return nil
+}
+
+func SwitchActiveRole(context context.Context, user *User, session *Session, roleID string) (*User, error) {
+	if !user.valid {
+		panic(ErrUserInvalid)
+	}
+	if !session.valid {
+		panic(ErrSessionInvalid)
+	}
+	if !user.holds(roleID) {
+		return nil, ErrUserRoleNotHeld
+	}
+
+	errPing := database.Ping(context)
+	if errPing != nil {
+		return nil, errPing
+	}
+
+	transaction, errBegin := database.Begin(context)
+	if errBegin != nil {
+		return nil, errBegin
+	}
+
+	errUpdateSession := session.model.updateActiveRoleID(context, transaction, roleID)
+	if errUpdateSession != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errUpdateSession, errRollback)
+		}
+
+		return nil, errUpdateSession
+	}
+
+	switchedUser, errGetUser := getUserBySession(context, transaction, session)
+	if errGetUser != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errGetUser, errRollback)
+		}
+
+		return nil, errGetUser
+	}
+
+	errRecord := recordAudit(context, transaction, fmt.Sprintf("Switched active role to %s.", roleID), switchedUser)
+	if errRecord != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errRecord, errRollback)
+		}
+
+		return nil, errRecord
+	}
+
+	errCommit := transaction.Commit()
+	if errCommit != nil {
+		return nil, errCommit
+	}
+
+	return switchedUser, nil
+}
+
+func GrantUserRole(context context.Context, actor *User, userUUID uuid.UUID, roleID string) (*User, error) {
+	if !actor.valid {
+		panic(ErrUserInvalid)
+	}
+	if !actor.is("administrator") {
+		return nil, ErrUserNotAdministrator
+	}
+
+	errPing := database.Ping(context)
+	if errPing != nil {
+		return nil, errPing
+	}
+
+	transaction, errBegin := database.Begin(context)
+	if errBegin != nil {
+		return nil, errBegin
+	}
+
+	user, errGetUser := getUserByUUID(context, transaction, userUUID)
+	if errGetUser != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errGetUser, errRollback)
+		}
+
+		return nil, errGetUser
+	}
+
+	errGrant := user.grantRole(context, transaction, roleID)
+	if errGrant != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errGrant, errRollback)
+		}
+
+		return nil, errGrant
+	}
+
+	errRecord := recordAudit(context, transaction, fmt.Sprintf("Granted %s role to user %s.", roleID, user.model.UUID), actor)
+	if errRecord != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errRecord, errRollback)
+		}
+
+		return nil, errRecord
+	}
+
+	errCommit := transaction.Commit()
+	if errCommit != nil {
+		return nil, errCommit
+	}
+
+	return user, nil
+}
+
+func RevokeUserRole(context context.Context, actor *User, userUUID uuid.UUID, roleID string) (*User, error) {
+	if !actor.valid {
+		panic(ErrUserInvalid)
+	}
+	if !actor.is("administrator") {
+		return nil, ErrUserNotAdministrator
+	}
+
+	errPing := database.Ping(context)
+	if errPing != nil {
+		return nil, errPing
+	}
+
+	transaction, errBegin := database.Begin(context)
+	if errBegin != nil {
+		return nil, errBegin
+	}
+
+	user, errGetUser := getUserByUUID(context, transaction, userUUID)
+	if errGetUser != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errGetUser, errRollback)
+		}
+
+		return nil, errGetUser
+	}
+
+	errRevoke := user.revokeRole(context, transaction, roleID)
+	if errRevoke != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errRevoke, errRollback)
+		}
+
+		return nil, errRevoke
+	}
+
+	errRecord := recordAudit(context, transaction, fmt.Sprintf("Revoked %s role from user %s.", roleID, user.model.UUID), actor)
+	if errRecord != nil {
+		errRollback := transaction.Rollback()
+		if errRollback != nil {
+			return nil, errors.Join(errRecord, errRollback)
+		}
+
+		return nil, errRecord
+	}
+
+	errCommit := transaction.Commit()
+	if errCommit != nil {
+		return nil, errCommit
+	}
+
+	return user, nil
+}
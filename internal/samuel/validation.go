@@ -0,0 +1,63 @@
+package samuel
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+type ValidationError struct {
+	fields map[string]string
+}
+
+func newValidationError() *ValidationError {
+	return &ValidationError{
+		fields: make(map[string]string),
+	}
+}
+
+func (validationError *ValidationError) add(field string, message string) {
+	if _, exists := validationError.fields[field]; exists {
+		return
+	}
+
+	validationError.fields[field] = message
+}
+
+func (validationError *ValidationError) empty() bool {
+	return len(validationError.fields) == 0
+}
+
+func (validationError *ValidationError) Fields() map[string]string {
+	return validationError.fields
+}
+
+func (validationError *ValidationError) Error() string {
+	fields := make([]string, 0, len(validationError.fields))
+	for field := range validationError.fields {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	var messageBuilder strings.Builder
+	messageBuilder.WriteString("validation failed")
+	for index, field := range fields {
+		if index == 0 {
+			messageBuilder.WriteString(": ")
+		} else {
+			messageBuilder.WriteString("; ")
+		}
+		messageBuilder.WriteString(fmt.Sprintf("%s %s", field, validationError.fields[field]))
+	}
+
+	return messageBuilder.String()
+}
+
+func validateRequiredText(validation *ValidationError, field string, value string, maximumLength int) {
+	if value == "" {
+		validation.add(field, "must not be empty")
+	} else if utf8.RuneCountInString(value) > maximumLength {
+		validation.add(field, fmt.Sprintf("must be at most %d characters", maximumLength))
+	}
+}
@@ -0,0 +1,134 @@
+package server
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sorucoder/samuel/internal/payloads"
+	"github.com/sorucoder/samuel/internal/samuel"
+)
+
+func handleListCampuses(context *gin.Context) {
+	user := context.MustGet("user").(*samuel.User)
+	session := context.MustGet("session").(*samuel.Session)
+
+	var payload payloads.ListCampuses
+	errBindPayload := context.ShouldBind(&payload)
+	if errBindPayload != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed campus list data", errBindPayload)
+		return
+	}
+
+	campuses, errGetCampuses := samuel.GetCampuses(context, payload.Retired)
+	if errGetCampuses != nil {
+		respondAPIError(context, http.StatusInternalServerError, "cannot get campuses", errGetCampuses)
+		return
+	}
+
+	respondAPISuccess(context, http.StatusOK, map[string]any{
+		"user":     user,
+		"session":  session,
+		"campuses": campuses,
+	})
+}
+
+func handleCreateCampus(context *gin.Context) {
+	user := context.MustGet("user").(*samuel.User)
+	session := context.MustGet("session").(*samuel.Session)
+	administrator := context.MustGet("administrator").(*samuel.Administrator)
+
+	var payload payloads.CreateCampus
+	errBindPayload := context.ShouldBind(&payload)
+	if errBindPayload != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed campus create data", errBindPayload)
+		return
+	}
+
+	campus, errCreateCampus := samuel.CreateCampus(context, user, payload.ID, payload.Content())
+	if errCreateCampus != nil {
+		var validationError *samuel.ValidationError
+		switch {
+		case errors.As(errCreateCampus, &validationError):
+			respondAPIValidationError(context, "invalid campus", validationError)
+		case errors.Is(errCreateCampus, samuel.ErrCampusExists):
+			respondAPIError(context, http.StatusConflict, "campus already exists", errCreateCampus)
+		default:
+			respondAPIError(context, http.StatusInternalServerError, "cannot create campus", errCreateCampus)
+		}
+		return
+	}
+
+	respondAPISuccess(context, http.StatusCreated, map[string]any{
+		"user":          user,
+		"session":       session,
+		"administrator": administrator,
+		"campus":        campus,
+	})
+}
+
+func handleUpdateCampus(context *gin.Context) {
+	user := context.MustGet("user").(*samuel.User)
+	session := context.MustGet("session").(*samuel.Session)
+	administrator := context.MustGet("administrator").(*samuel.Administrator)
+
+	var payload payloads.SaveCampus
+	errBindPayload := context.ShouldBind(&payload)
+	if errBindPayload != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed campus update data", errBindPayload)
+		return
+	}
+
+	campus, errUpdateCampus := samuel.UpdateCampus(context, user, context.Param("id"), payload.Content())
+	if errUpdateCampus != nil {
+		var validationError *samuel.ValidationError
+		switch {
+		case errors.As(errUpdateCampus, &validationError):
+			respondAPIValidationError(context, "invalid campus", validationError)
+		case errors.Is(errUpdateCampus, sql.ErrNoRows):
+			respondAPIError(context, http.StatusNotFound, "campus not found", errUpdateCampus)
+		case errors.Is(errUpdateCampus, samuel.ErrCampusRetired):
+			respondAPIError(context, http.StatusConflict, "campus retired", errUpdateCampus)
+		default:
+			respondAPIError(context, http.StatusInternalServerError, "cannot update campus", errUpdateCampus)
+		}
+		return
+	}
+
+	respondAPISuccess(context, http.StatusOK, map[string]any{
+		"user":          user,
+		"session":       session,
+		"administrator": administrator,
+		"campus":        campus,
+	})
+}
+
+func handleRetireCampus(context *gin.Context) {
+	user := context.MustGet("user").(*samuel.User)
+	session := context.MustGet("session").(*samuel.Session)
+	administrator := context.MustGet("administrator").(*samuel.Administrator)
+
+	campus, errRetireCampus := samuel.RetireCampus(context, user, context.Param("id"))
+	if errRetireCampus != nil {
+		var referenceError *samuel.ReferenceError
+		switch {
+		case errors.As(errRetireCampus, &referenceError):
+			respondAPIReferenceError(context, "campus still in use", referenceError)
+		case errors.Is(errRetireCampus, sql.ErrNoRows):
+			respondAPIError(context, http.StatusNotFound, "campus not found", errRetireCampus)
+		case errors.Is(errRetireCampus, samuel.ErrCampusRetired):
+			respondAPIError(context, http.StatusConflict, "campus already retired", errRetireCampus)
+		default:
+			respondAPIError(context, http.StatusInternalServerError, "cannot retire campus", errRetireCampus)
+		}
+		return
+	}
+
+	respondAPISuccess(context, http.StatusOK, map[string]any{
+		"user":          user,
+		"session":       session,
+		"administrator": administrator,
+		"campus":        campus,
+	})
+}
@@ -0,0 +1,177 @@
+package server
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sorucoder/samuel/internal/payloads"
+	"github.com/sorucoder/samuel/internal/samuel"
+)
+
+func handleListCompanies(context *gin.Context) {
+	user := context.MustGet("user").(*samuel.User)
+	session := context.MustGet("session").(*samuel.Session)
+
+	var payload payloads.ListCompanies
+	errBindPayload := context.ShouldBind(&payload)
+	if errBindPayload != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed company list data", errBindPayload)
+		return
+	}
+
+	companyBatch, errGetCompanyBatch := samuel.GetCompanyBatch(context, user, payload.Search, payload.Archived, payload.Page, payload.Count)
+	if errGetCompanyBatch != nil {
+		switch {
+		case errors.Is(errGetCompanyBatch, samuel.ErrCompanyForbidden):
+			respondAPIError(context, http.StatusForbidden, "cannot list companies", errGetCompanyBatch)
+		default:
+			respondAPIError(context, http.StatusInternalServerError, "cannot get companies", errGetCompanyBatch)
+		}
+		return
+	}
+
+	respondAPISuccess(context, http.StatusOK, map[string]any{
+		"user":    user,
+		"session": session,
+		"batch":   companyBatch,
+	})
+}
+
+func handleViewCompany(context *gin.Context) {
+	user := context.MustGet("user").(*samuel.User)
+	session := context.MustGet("session").(*samuel.Session)
+
+	companyUUID, errParseCompanyUUID := uuid.Parse(context.Param("uuid"))
+	if errParseCompanyUUID != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed company uuid", errParseCompanyUUID)
+		return
+	}
+
+	company, errGetCompany := samuel.GetCompanyByUUID(context, user, companyUUID)
+	if errGetCompany != nil {
+		switch {
+		case errors.Is(errGetCompany, sql.ErrNoRows):
+			respondAPIError(context, http.StatusNotFound, "company not found", errGetCompany)
+		case errors.Is(errGetCompany, samuel.ErrCompanyForbidden):
+			respondAPIError(context, http.StatusForbidden, "cannot view company", errGetCompany)
+		default:
+			respondAPIError(context, http.StatusInternalServerError, "cannot get company", errGetCompany)
+		}
+		return
+	}
+
+	respondAPISuccess(context, http.StatusOK, map[string]any{
+		"user":    user,
+		"session": session,
+		"company": company,
+	})
+}
+
+func handleCreateCompany(context *gin.Context) {
+	user := context.MustGet("user").(*samuel.User)
+	session := context.MustGet("session").(*samuel.Session)
+
+	var payload payloads.SaveCompany
+	errBindPayload := context.ShouldBind(&payload)
+	if errBindPayload != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed company create data", errBindPayload)
+		return
+	}
+
+	company, errCreateCompany := samuel.CreateCompany(context, user, payload.Content())
+	if errCreateCompany != nil {
+		var validationError *samuel.ValidationError
+		switch {
+		case errors.As(errCreateCompany, &validationError):
+			respondAPIValidationError(context, "invalid company", validationError)
+		case errors.Is(errCreateCompany, samuel.ErrCompanyForbidden):
+			respondAPIError(context, http.StatusForbidden, "cannot create company", errCreateCompany)
+		default:
+			respondAPIError(context, http.StatusInternalServerError, "cannot create company", errCreateCompany)
+		}
+		return
+	}
+
+	respondAPISuccess(context, http.StatusCreated, map[string]any{
+		"user":    user,
+		"session": session,
+		"company": company,
+	})
+}
+
+func handleUpdateCompany(context *gin.Context) {
+	user := context.MustGet("user").(*samuel.User)
+	session := context.MustGet("session").(*samuel.Session)
+
+	companyUUID, errParseCompanyUUID := uuid.Parse(context.Param("uuid"))
+	if errParseCompanyUUID != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed company uuid", errParseCompanyUUID)
+		return
+	}
+
+	var payload payloads.SaveCompany
+	errBindPayload := context.ShouldBind(&payload)
+	if errBindPayload != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed company update data", errBindPayload)
+		return
+	}
+
+	company, errUpdateCompany := samuel.UpdateCompany(context, user, companyUUID, payload.Content())
+	if errUpdateCompany != nil {
+		var validationError *samuel.ValidationError
+		switch {
+		case errors.As(errUpdateCompany, &validationError):
+			respondAPIValidationError(context, "invalid company", validationError)
+		case errors.Is(errUpdateCompany, sql.ErrNoRows):
+			respondAPIError(context, http.StatusNotFound, "company not found", errUpdateCompany)
+		case errors.Is(errUpdateCompany, samuel.ErrCompanyForbidden):
+			respondAPIError(context, http.StatusForbidden, "cannot update company", errUpdateCompany)
+		case errors.Is(errUpdateCompany, samuel.ErrCompanyArchived):
+			respondAPIError(context, http.StatusConflict, "company archived", errUpdateCompany)
+		default:
+			respondAPIError(context, http.StatusInternalServerError, "cannot update company", errUpdateCompany)
+		}
+		return
+	}
+
+	respondAPISuccess(context, http.StatusOK, map[string]any{
+		"user":    user,
+		"session": session,
+		"company": company,
+	})
+}
+
+func handleArchiveCompany(context *gin.Context) {
+	user := context.MustGet("user").(*samuel.User)
+	session := context.MustGet("session").(*samuel.Session)
+
+	companyUUID, errParseCompanyUUID := uuid.Parse(context.Param("uuid"))
+	if errParseCompanyUUID != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed company uuid", errParseCompanyUUID)
+		return
+	}
+
+	company, errArchiveCompany := samuel.ArchiveCompany(context, user, companyUUID)
+	if errArchiveCompany != nil {
+		switch {
+		case errors.Is(errArchiveCompany, sql.ErrNoRows):
+			respondAPIError(context, http.StatusNotFound, "company not found", errArchiveCompany)
+		case errors.Is(errArchiveCompany, samuel.ErrCompanyForbidden):
+			respondAPIError(context, http.StatusForbidden, "cannot archive company", errArchiveCompany)
+		case errors.Is(errArchiveCompany, samuel.ErrCompanyArchived):
+			respondAPIError(context, http.StatusConflict, "company already archived", errArchiveCompany)
+		default:
+			respondAPIError(context, http.StatusInternalServerError, "cannot archive company", errArchiveCompany)
+		}
+		return
+	}
+
+	respondAPISuccess(context, http.StatusOK, map[string]any{
+		"user":    user,
+		"session": session,
+		"company": company,
+	})
+}
@@ -0,0 +1,87 @@
+package server
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sorucoder/samuel/internal/payloads"
+	"github.com/sorucoder/samuel/internal/samuel"
+)
+
+func handleListCoordinators(context *gin.Context) {
+	user := context.MustGet("user").(*samuel.User)
+	session := context.MustGet("session").(*samuel.Session)
+	administrator := context.MustGet("administrator").(*samuel.Administrator)
+
+	coordinators, errGetCoordinators := samuel.GetCoordinators(context)
+	if errGetCoordinators != nil {
+		respondAPIError(context, http.StatusInternalServerError, "cannot get coordinators", errGetCoordinators)
+		return
+	}
+
+	respondAPISuccess(context, http.StatusOK, map[string]any{
+		"user":          user,
+		"session":       session,
+		"administrator": administrator,
+		"coordinators":  coordinators,
+	})
+}
+
+func handleAssignCoordinator(context *gin.Context) {
+	user := context.MustGet("user").(*samuel.User)
+	session := context.MustGet("session").(*samuel.Session)
+	administrator := context.MustGet("administrator").(*samuel.Administrator)
+
+	var payload payloads.AssignCoordinator
+	errBindPayload := context.ShouldBind(&payload)
+	if errBindPayload != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed coordinator assign data", errBindPayload)
+		return
+	}
+
+	coordinator, errAssignCoordinator := samuel.AssignCoordinator(context, user, context.Param("campus"), context.Param("program"), payload.InstructorUUID)
+	if errAssignCoordinator != nil {
+		switch {
+		case errors.Is(errAssignCoordinator, sql.ErrNoRows):
+			respondAPIError(context, http.StatusNotFound, "campus, program or instructor not found", errAssignCoordinator)
+		case errors.Is(errAssignCoordinator, samuel.ErrUserNotAdministrator):
+			respondAPIError(context, http.StatusForbidden, "cannot assign coordinator", errAssignCoordinator)
+		case errors.Is(errAssignCoordinator, samuel.ErrUserNotInstructor):
+			respondAPIError(context, http.StatusBadRequest, "invalid coordinator assign data", errAssignCoordinator)
+		case errors.Is(errAssignCoordinator, samuel.ErrCampusRetired),
+			errors.Is(errAssignCoordinator, samuel.ErrProgramRetired):
+			respondAPIError(context, http.StatusConflict, "campus or program retired", errAssignCoordinator)
+		default:
+			respondAPIError(context, http.StatusInternalServerError, "cannot assign coordinator", errAssignCoordinator)
+		}
+		return
+	}
+
+	respondAPISuccess(context, http.StatusOK, map[string]any{
+		"user":          user,
+		"session":       session,
+		"administrator": administrator,
+		"coordinator":   coordinator,
+	})
+}
+
+func handleUnassignCoordinator(context *gin.Context) {
+	user := context.MustGet("user").(*samuel.User)
+
+	errUnassignCoordinator := samuel.UnassignCoordinator(context, user, context.Param("campus"), context.Param("program"))
+	if errUnassignCoordinator != nil {
+		switch {
+		case errors.Is(errUnassignCoordinator, sql.ErrNoRows):
+			respondAPIError(context, http.StatusNotFound, "coordinator not found", errUnassignCoordinator)
+		case errors.Is(errUnassignCoordinator, samuel.ErrUserNotAdministrator):
+			respondAPIError(context, http.StatusForbidden, "cannot unassign coordinator", errUnassignCoordinator)
+		default:
+			respondAPIError(context, http.StatusInternalServerError, "cannot unassign coordinator", errUnassignCoordinator)
+		}
+		return
+	}
+
+	respondAPISuccess(context, http.StatusOK, nil)
+}
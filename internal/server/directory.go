@@ -0,0 +1,42 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/sorucoder/samuel/internal/configuration"
+	"github.com/sorucoder/samuel/internal/samuel"
+)
+
+func synchronizeDirectoryPeriodically(interval time.Duration, administratorIdentity string) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		context, cancel := context.WithTimeout(context.Background(), interval)
+
+		actor, errGetActor := samuel.GetAdministratorByIdentity(context, administratorIdentity)
+		if errGetActor != nil {
+			samuel.RecordDirectorySynchronizationFailure(nil, errGetActor)
+			cancel()
+			continue
+		}
+
+		_, errSynchronize := samuel.SynchronizeDirectory(context, actor, true)
+		if errSynchronize != nil {
+			samuel.RecordDirectorySynchronizationFailure(actor, errSynchronize)
+		}
+
+		cancel()
+	}
+}
+
+func scheduleDirectorySynchronization() {
+	interval := configuration.LDAP.GetDuration("syncInterval")
+	administratorIdentity := configuration.LDAP.GetString("syncAdministrator")
+	if interval <= 0 || administratorIdentity == "" {
+		return
+	}
+
+	go synchronizeDirectoryPeriodically(interval, administratorIdentity)
+}
@@ -0,0 +1,212 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sorucoder/samuel/internal/samuel"
+)
+
+const (
+	eventStreamHeartbeat      time.Duration = 15 * time.Second
+	eventStreamTicketLifetime time.Duration = 30 * time.Second
+)
+
+type eventStreamTicket struct {
+	sessionToken uuid.UUID
+	expiresOn    time.Time
+}
+
+var (
+	eventStreamTickets      map[uuid.UUID]*eventStreamTicket = make(map[uuid.UUID]*eventStreamTicket)
+	eventStreamTicketsMutex sync.Mutex
+)
+
+var (
+	errEventStreamTicketInvalid error = errors.New("event stream ticket invalid")
+)
+
+func issueEventStreamTicket(sessionToken uuid.UUID) (uuid.UUID, time.Time) {
+	eventStreamTicketsMutex.Lock()
+	defer eventStreamTicketsMutex.Unlock()
+
+	now := time.Now()
+	for storedTicket, storedEventStreamTicket := range eventStreamTickets {
+		if now.After(storedEventStreamTicket.expiresOn) {
+			delete(eventStreamTickets, storedTicket)
+		}
+	}
+
+	ticket := uuid.New()
+	expiresOn := now.Add(eventStreamTicketLifetime)
+	eventStreamTickets[ticket] = &eventStreamTicket{
+		sessionToken: sessionToken,
+		expiresOn:    expiresOn,
+	}
+
+	return ticket, expiresOn
+}
+
+func redeemEventStreamTicket(ticket uuid.UUID) (uuid.UUID, error) {
+	eventStreamTicketsMutex.Lock()
+	defer eventStreamTicketsMutex.Unlock()
+
+	storedEventStreamTicket, exists := eventStreamTickets[ticket]
+	if !exists {
+		return uuid.Nil, errEventStreamTicketInvalid
+	}
+	delete(eventStreamTickets, ticket)
+
+	if time.Now().After(storedEventStreamTicket.expiresOn) {
+		return uuid.Nil, errEventStreamTicketInvalid
+	}
+
+	return storedEventStreamTicket.sessionToken, nil
+}
+
+// Middleware
+func handleEventStreamGroup(context *gin.Context) {
+	var sessionToken uuid.UUID
+	if authorization := context.GetHeader("Authorization"); authorization != "" {
+		authorizationScheme, authorizationPayload, authorizationValid := strings.Cut(authorization, " ")
+		if !authorizationValid {
+			respondAPIError(context, http.StatusBadRequest, "missing authorization", nil)
+			return
+		} else if authorizationScheme != "Bearer" {
+			respondAPIError(context, http.StatusBadRequest, "invalid authentication scheme", nil)
+			return
+		}
+
+		var errParseSessionToken error
+		sessionToken, errParseSessionToken = uuid.Parse(authorizationPayload)
+		if errParseSessionToken != nil {
+			respondAPIError(context, http.StatusBadRequest, "invalid session token", errParseSessionToken)
+			return
+		}
+	} else {
+		// Browsers cannot set headers on an EventSource, so they redeem a single-use ticket instead of exposing the session token in the URL.
+		ticket, errParseTicket := uuid.Parse(context.Query("ticket"))
+		if errParseTicket != nil {
+			respondAPIError(context, http.StatusBadRequest, "invalid event stream ticket", errParseTicket)
+			return
+		}
+
+		var errRedeemTicket error
+		sessionToken, errRedeemTicket = redeemEventStreamTicket(ticket)
+		if errRedeemTicket != nil {
+			respondAPIError(context, http.StatusUnauthorized, "invalid event stream ticket", errRedeemTicket)
+			return
+		}
+	}
+
+	// Unlike the authorized API group, the stream only verifies the session so that it never extends it.
+	user, session, errVerifySession := samuel.VerifySession(context, sessionToken)
+	if errVerifySession != nil {
+		respondAPIError(context, http.StatusUnauthorized, "session expired", errVerifySession)
+		return
+	}
+
+	context.Set("sessionToken", sessionToken)
+	context.Set("user", user)
+	context.Set("session", session)
+}
+
+// Routes
+func handleEventStream(context *gin.Context) {
+	user := context.MustGet("user").(*samuel.User)
+	session := context.MustGet("session").(*samuel.Session)
+	sessionToken := context.MustGet("sessionToken").(uuid.UUID)
+
+	lastEventIDParameter := context.GetHeader("Last-Event-ID")
+	if lastEventIDParameter == "" {
+		lastEventIDParameter = context.Query("lastEventID")
+	}
+	var lastEventID uint64
+	if lastEventIDParameter != "" {
+		var errParseLastEventID error
+		lastEventID, errParseLastEventID = strconv.ParseUint(lastEventIDParameter, 10, 64)
+		if errParseLastEventID != nil {
+			respondAPIError(context, http.StatusBadRequest, "malformed last event id", errParseLastEventID)
+			return
+		}
+	}
+
+	subscription, missedEvents, truncated := samuel.SubscribeEvents(user, lastEventID)
+	defer subscription.Close()
+
+	context.Header("Content-Type", "text/event-stream")
+	context.Header("Cache-Control", "no-cache")
+	context.Header("Connection", "keep-alive")
+	context.Header("X-Accel-Buffering", "no")
+	context.Status(http.StatusOK)
+
+	if truncated {
+		fmt.Fprint(context.Writer, "event: truncated\ndata: {}\n\n")
+	}
+	for _, missedEvent := range missedEvents {
+		writeEvent(context, missedEvent)
+	}
+	context.Writer.Flush()
+
+	heartbeat := time.NewTicker(eventStreamHeartbeat)
+	defer heartbeat.Stop()
+
+	expiry := time.NewTimer(time.Until(session.ExpiresOn()))
+	defer expiry.Stop()
+
+	for {
+		select {
+		case <-context.Request.Context().Done():
+			return
+		case event, open := <-subscription.Events():
+			if !open {
+				return
+			}
+			writeEvent(context, event)
+			context.Writer.Flush()
+		case <-heartbeat.C:
+			// Logging out ends the session without touching open streams, so each heartbeat checks it again.
+			_, currentSession, errVerifySession := samuel.VerifySession(context, sessionToken)
+			if errVerifySession != nil {
+				fmt.Fprint(context.Writer, "event: expired\ndata: {}\n\n")
+				context.Writer.Flush()
+				return
+			}
+			expiry.Reset(time.Until(currentSession.ExpiresOn()))
+
+			fmt.Fprint(context.Writer, ": heartbeat\n\n")
+			context.Writer.Flush()
+		case <-expiry.C:
+			// The session may have been extended by other requests since the stream opened.
+			_, currentSession, errVerifySession := samuel.VerifySession(context, sessionToken)
+			if errVerifySession != nil {
+				fmt.Fprint(context.Writer, "event: expired\ndata: {}\n\n")
+				context.Writer.Flush()
+				return
+			}
+			expiry.Reset(time.Until(currentSession.ExpiresOn()))
+		}
+	}
+}
+
+func handleIssueEventStreamTicket(context *gin.Context) {
+	session := context.MustGet("session").(*samuel.Session)
+
+	ticket, expiresOn := issueEventStreamTicket(session.Token())
+
+	respondAPISuccess(context, http.StatusCreated, map[string]any{
+		"ticket":    ticket,
+		"expiresOn": expiresOn,
+	})
+}
+
+func writeEvent(context *gin.Context, event *samuel.Event) {
+	fmt.Fprintf(context.Writer, "id: %s\nevent: %s\ndata: %s\n\n", event.ID(), event.Name(), event.Data())
+}
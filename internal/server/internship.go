@@ -0,0 +1,177 @@
+package server
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sorucoder/samuel/internal/payloads"
+	"github.com/sorucoder/samuel/internal/samuel"
+)
+
+func handleCreateInternship(context *gin.Context) {
+	user := context.MustGet("user").(*samuel.User)
+	session := context.MustGet("session").(*samuel.Session)
+
+	var payload payloads.CreateInternship
+	errBindPayload := context.ShouldBind(&payload)
+	if errBindPayload != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed internship create data", errBindPayload)
+		return
+	}
+
+	internship, errCreateInternship := samuel.CreateInternship(context, user, payload.StudentUUID, payload.InstructorUUID, payload.SupervisorUUID, payload.StartOn.Time, payload.EndOn.Time)
+	if errCreateInternship != nil {
+		switch {
+		case errors.Is(errCreateInternship, samuel.ErrInternshipCreateForbidden):
+			respondAPIError(context, http.StatusForbidden, "cannot create internship", errCreateInternship)
+		case errors.Is(errCreateInternship, sql.ErrNoRows):
+			respondAPIError(context, http.StatusNotFound, "internship participant not found", errCreateInternship)
+		case errors.Is(errCreateInternship, samuel.ErrUserNotStudent),
+			errors.Is(errCreateInternship, samuel.ErrUserNotInstructor),
+			errors.Is(errCreateInternship, samuel.ErrUserNotSupervisor),
+			errors.Is(errCreateInternship, samuel.ErrInternshipStartNotSunday),
+			errors.Is(errCreateInternship, samuel.ErrInternshipEndNotSaturday),
+			errors.Is(errCreateInternship, samuel.ErrInternshipEndBeforeStart):
+			respondAPIError(context, http.StatusBadRequest, "invalid internship create data", errCreateInternship)
+		default:
+			respondAPIError(context, http.StatusInternalServerError, "cannot create internship", errCreateInternship)
+		}
+		return
+	}
+
+	respondAPISuccess(context, http.StatusCreated, map[string]any{
+		"user":       user,
+		"session":    session,
+		"internship": internship,
+	})
+}
+
+func handleListInternships(context *gin.Context) {
+	user := context.MustGet("user").(*samuel.User)
+	session := context.MustGet("session").(*samuel.Session)
+
+	var payload payloads.ListInternships
+	errBindPayload := context.ShouldBind(&payload)
+	if errBindPayload != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed internship list data", errBindPayload)
+		return
+	}
+
+	internshipBatch, errGetInternshipBatch := samuel.GetInternshipBatchByUser(context, user, payload.Page, payload.Count)
+	if errGetInternshipBatch != nil {
+		respondAPIError(context, http.StatusInternalServerError, "cannot get internships", errGetInternshipBatch)
+		return
+	}
+
+	respondAPISuccess(context, http.StatusOK, map[string]any{
+		"user":    user,
+		"session": session,
+		"batch":   internshipBatch,
+	})
+}
+
+func handleViewInternship(context *gin.Context) {
+	user := context.MustGet("user").(*samuel.User)
+	session := context.MustGet("session").(*samuel.Session)
+
+	internshipUUID, errParseInternshipUUID := uuid.Parse(context.Param("uuid"))
+	if errParseInternshipUUID != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed internship uuid", errParseInternshipUUID)
+		return
+	}
+
+	internship, errGetInternship := samuel.GetInternshipByUUID(context, user, internshipUUID)
+	if errGetInternship != nil {
+		switch {
+		case errors.Is(errGetInternship, sql.ErrNoRows):
+			respondAPIError(context, http.StatusNotFound, "internship not found", errGetInternship)
+		case errors.Is(errGetInternship, samuel.ErrInternshipNotInvolved):
+			respondAPIError(context, http.StatusForbidden, "cannot view internship", errGetInternship)
+		default:
+			respondAPIError(context, http.StatusInternalServerError, "cannot get internship", errGetInternship)
+		}
+		return
+	}
+
+	respondAPISuccess(context, http.StatusOK, map[string]any{
+		"user":       user,
+		"session":    session,
+		"internship": internship,
+	})
+}
+
+func handleCloseInternship(context *gin.Context) {
+	user := context.MustGet("user").(*samuel.User)
+	session := context.MustGet("session").(*samuel.Session)
+
+	internshipUUID, errParseInternshipUUID := uuid.Parse(context.Param("uuid"))
+	if errParseInternshipUUID != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed internship uuid", errParseInternshipUUID)
+		return
+	}
+
+	internship, errCloseInternship := samuel.CloseInternship(context, user, internshipUUID)
+	if errCloseInternship != nil {
+		switch {
+		case errors.Is(errCloseInternship, sql.ErrNoRows):
+			respondAPIError(context, http.StatusNotFound, "internship not found", errCloseInternship)
+		case errors.Is(errCloseInternship, samuel.ErrInternshipCloseForbidden):
+			respondAPIError(context, http.StatusForbidden, "cannot close internship", errCloseInternship)
+		case errors.Is(errCloseInternship, samuel.ErrInternshipClosed):
+			respondAPIError(context, http.StatusConflict, "internship already closed", errCloseInternship)
+		default:
+			respondAPIError(context, http.StatusInternalServerError, "cannot close internship", errCloseInternship)
+		}
+		return
+	}
+
+	respondAPISuccess(context, http.StatusOK, map[string]any{
+		"user":       user,
+		"session":    session,
+		"internship": internship,
+	})
+}
+
+func handleReassignInternship(context *gin.Context) {
+	user := context.MustGet("user").(*samuel.User)
+	session := context.MustGet("session").(*samuel.Session)
+
+	internshipUUID, errParseInternshipUUID := uuid.Parse(context.Param("uuid"))
+	if errParseInternshipUUID != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed internship uuid", errParseInternshipUUID)
+		return
+	}
+
+	var payload payloads.ReassignInternship
+	errBindPayload := context.ShouldBind(&payload)
+	if errBindPayload != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed internship reassign data", errBindPayload)
+		return
+	}
+
+	internship, errReassignInternship := samuel.ReassignInternshipInstructor(context, user, internshipUUID, payload.InstructorUUID)
+	if errReassignInternship != nil {
+		switch {
+		case errors.Is(errReassignInternship, sql.ErrNoRows):
+			respondAPIError(context, http.StatusNotFound, "internship or instructor not found", errReassignInternship)
+		case errors.Is(errReassignInternship, samuel.ErrInternshipReassignForbidden):
+			respondAPIError(context, http.StatusForbidden, "cannot reassign internship", errReassignInternship)
+		case errors.Is(errReassignInternship, samuel.ErrUserNotInstructor):
+			respondAPIError(context, http.StatusBadRequest, "invalid internship reassign data", errReassignInternship)
+		case errors.Is(errReassignInternship, samuel.ErrInternshipClosed):
+			respondAPIError(context, http.StatusConflict, "internship already closed", errReassignInternship)
+		default:
+			respondAPIError(context, http.StatusInternalServerError, "cannot reassign internship", errReassignInternship)
+		}
+		return
+	}
+
+	respondAPISuccess(context, http.StatusOK, map[string]any{
+		"user":       user,
+		"session":    session,
+		"internship": internship,
+	})
+}
@@ -0,0 +1,164 @@
+package server
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sorucoder/samuel/internal/payloads"
+	"github.com/sorucoder/samuel/internal/samuel"
+)
+
+func handleSendMessage(context *gin.Context) {
+	user := context.MustGet("user").(*samuel.User)
+	session := context.MustGet("session").(*samuel.Session)
+
+	recipientUUID, errParseRecipientUUID := uuid.Parse(context.Param("user"))
+	if errParseRecipientUUID != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed user uuid", errParseRecipientUUID)
+		return
+	}
+
+	var payload payloads.SendMessage
+	errBindPayload := context.ShouldBind(&payload)
+	if errBindPayload != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed message send data", errBindPayload)
+		return
+	}
+
+	message, errSendMessage := samuel.SendMessage(context, user, recipientUUID, payload.Message)
+	if errSendMessage != nil {
+		var validationError *samuel.ValidationError
+		switch {
+		case errors.As(errSendMessage, &validationError):
+			respondAPIValidationError(context, "invalid message", validationError)
+		case errors.Is(errSendMessage, sql.ErrNoRows):
+			respondAPIError(context, http.StatusNotFound, "user not found", errSendMessage)
+		case errors.Is(errSendMessage, samuel.ErrMessageNotRelated):
+			respondAPIError(context, http.StatusForbidden, "cannot message user", errSendMessage)
+		default:
+			respondAPIError(context, http.StatusInternalServerError, "cannot send message", errSendMessage)
+		}
+		return
+	}
+
+	respondAPISuccess(context, http.StatusCreated, map[string]any{
+		"user":    user,
+		"session": session,
+		"message": message,
+	})
+}
+
+func handleReplyToMessage(context *gin.Context) {
+	user := context.MustGet("user").(*samuel.User)
+	session := context.MustGet("session").(*samuel.Session)
+
+	replyToUUID, errParseReplyToUUID := uuid.Parse(context.Param("uuid"))
+	if errParseReplyToUUID != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed message uuid", errParseReplyToUUID)
+		return
+	}
+
+	var payload payloads.SendMessage
+	errBindPayload := context.ShouldBind(&payload)
+	if errBindPayload != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed message reply data", errBindPayload)
+		return
+	}
+
+	message, errReplyToMessage := samuel.ReplyToMessage(context, user, replyToUUID, payload.Message)
+	if errReplyToMessage != nil {
+		var validationError *samuel.ValidationError
+		switch {
+		case errors.As(errReplyToMessage, &validationError):
+			respondAPIValidationError(context, "invalid message", validationError)
+		case errors.Is(errReplyToMessage, sql.ErrNoRows),
+			errors.Is(errReplyToMessage, samuel.ErrMessageNotPersonal):
+			respondAPIError(context, http.StatusNotFound, "message not found", errReplyToMessage)
+		case errors.Is(errReplyToMessage, samuel.ErrMessageNotParticipant),
+			errors.Is(errReplyToMessage, samuel.ErrMessageNotRelated):
+			respondAPIError(context, http.StatusForbidden, "cannot reply to message", errReplyToMessage)
+		default:
+			respondAPIError(context, http.StatusInternalServerError, "cannot reply to message", errReplyToMessage)
+		}
+		return
+	}
+
+	respondAPISuccess(context, http.StatusCreated, map[string]any{
+		"user":    user,
+		"session": session,
+		"message": message,
+	})
+}
+
+func handleViewMessageThread(context *gin.Context) {
+	user := context.MustGet("user").(*samuel.User)
+	session := context.MustGet("session").(*samuel.Session)
+
+	messageUUID, errParseMessageUUID := uuid.Parse(context.Param("uuid"))
+	if errParseMessageUUID != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed message uuid", errParseMessageUUID)
+		return
+	}
+
+	messages, errGetMessageThread := samuel.GetMessageThread(context, user, messageUUID)
+	if errGetMessageThread != nil {
+		switch {
+		case errors.Is(errGetMessageThread, sql.ErrNoRows),
+			errors.Is(errGetMessageThread, samuel.ErrMessageNotPersonal):
+			respondAPIError(context, http.StatusNotFound, "message not found", errGetMessageThread)
+		case errors.Is(errGetMessageThread, samuel.ErrMessageNotParticipant):
+			respondAPIError(context, http.StatusForbidden, "cannot view message thread", errGetMessageThread)
+		default:
+			respondAPIError(context, http.StatusInternalServerError, "cannot get message thread", errGetMessageThread)
+		}
+		return
+	}
+
+	respondAPISuccess(context, http.StatusOK, map[string]any{
+		"user":     user,
+		"session":  session,
+		"messages": messages,
+	})
+}
+
+func handleViewMessageLog(context *gin.Context) {
+	user := context.MustGet("user").(*samuel.User)
+	session := context.MustGet("session").(*samuel.Session)
+	administrator := context.MustGet("administrator").(*samuel.Administrator)
+
+	logUserUUID, errParseLogUserUUID := uuid.Parse(context.Param("user"))
+	if errParseLogUserUUID != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed user uuid", errParseLogUserUUID)
+		return
+	}
+
+	var payload payloads.ViewMessageLog
+	errBindPayload := context.ShouldBind(&payload)
+	if errBindPayload != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed message log view data", errBindPayload)
+		return
+	}
+
+	messageBatch, errGetMessageBatch := samuel.GetMessageLogBatchByUser(context, user, logUserUUID, payload.Page, payload.Count)
+	if errGetMessageBatch != nil {
+		switch {
+		case errors.Is(errGetMessageBatch, sql.ErrNoRows):
+			respondAPIError(context, http.StatusNotFound, "user not found", errGetMessageBatch)
+		case errors.Is(errGetMessageBatch, samuel.ErrMessageLogForbidden):
+			respondAPIError(context, http.StatusForbidden, "cannot view message log", errGetMessageBatch)
+		default:
+			respondAPIError(context, http.StatusInternalServerError, "cannot get message log", errGetMessageBatch)
+		}
+		return
+	}
+
+	respondAPISuccess(context, http.StatusOK, map[string]any{
+		"user":          user,
+		"session":       session,
+		"administrator": administrator,
+		"batch":         messageBatch,
+	})
+}
@@ -0,0 +1,135 @@
+package server
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sorucoder/samuel/internal/payloads"
+	"github.com/sorucoder/samuel/internal/samuel"
+)
+
+func handleListNotifications(context *gin.Context) {
+	user := context.MustGet("user").(*samuel.User)
+	session := context.MustGet("session").(*samuel.Session)
+
+	var payload payloads.ListNotifications
+	errBindPayload := context.ShouldBind(&payload)
+	if errBindPayload != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed notification list data", errBindPayload)
+		return
+	}
+
+	notificationBatch, errGetNotificationBatch := samuel.GetNotificationBatchByUser(context, user, payload.Unseen, payload.Page, payload.Count)
+	if errGetNotificationBatch != nil {
+		respondAPIError(context, http.StatusInternalServerError, "cannot get notifications", errGetNotificationBatch)
+		return
+	}
+
+	respondAPISuccess(context, http.StatusOK, map[string]any{
+		"user":    user,
+		"session": session,
+		"batch":   notificationBatch,
+	})
+}
+
+func handleCountUnseenNotifications(context *gin.Context) {
+	user := context.MustGet("user").(*samuel.User)
+	session := context.MustGet("session").(*samuel.Session)
+
+	unseenNotifications, errCountUnseenNotifications := samuel.CountUnseenNotifications(context, user)
+	if errCountUnseenNotifications != nil {
+		respondAPIError(context, http.StatusInternalServerError, "cannot count unseen notifications", errCountUnseenNotifications)
+		return
+	}
+
+	respondAPISuccess(context, http.StatusOK, map[string]any{
+		"user":                user,
+		"session":             session,
+		"unseenNotifications": unseenNotifications,
+	})
+}
+
+func handleMarkNotificationSeen(context *gin.Context) {
+	user := context.MustGet("user").(*samuel.User)
+	session := context.MustGet("session").(*samuel.Session)
+
+	notificationUUID, errParseNotificationUUID := uuid.Parse(context.Param("uuid"))
+	if errParseNotificationUUID != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed notification uuid", errParseNotificationUUID)
+		return
+	}
+
+	notification, errMarkNotificationSeen := samuel.MarkNotificationSeen(context, user, notificationUUID)
+	if errMarkNotificationSeen != nil {
+		switch {
+		case errors.Is(errMarkNotificationSeen, sql.ErrNoRows),
+			errors.Is(errMarkNotificationSeen, samuel.ErrNotificationNotRecipient):
+			respondAPIError(context, http.StatusNotFound, "notification not found", errMarkNotificationSeen)
+		default:
+			respondAPIError(context, http.StatusInternalServerError, "cannot mark notification seen", errMarkNotificationSeen)
+		}
+		return
+	}
+
+	respondAPISuccess(context, http.StatusOK, map[string]any{
+		"user":         user,
+		"session":      session,
+		"notification": notification,
+	})
+}
+
+func handleMarkNotificationsSeen(context *gin.Context) {
+	user := context.MustGet("user").(*samuel.User)
+	session := context.MustGet("session").(*samuel.Session)
+
+	var payload payloads.MarkNotificationsSeen
+	errBindPayload := context.ShouldBind(&payload)
+	if errBindPayload != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed notification seen data", errBindPayload)
+		return
+	}
+
+	updated, errMarkNotificationsSeen := samuel.MarkNotificationsSeen(context, user, payload.UUIDs, payload.All)
+	if errMarkNotificationsSeen != nil {
+		switch {
+		case errors.Is(errMarkNotificationsSeen, samuel.ErrNotificationsUnspecified):
+			respondAPIError(context, http.StatusBadRequest, "no notifications specified", errMarkNotificationsSeen)
+		default:
+			respondAPIError(context, http.StatusInternalServerError, "cannot mark notifications seen", errMarkNotificationsSeen)
+		}
+		return
+	}
+
+	respondAPISuccess(context, http.StatusOK, map[string]any{
+		"user":    user,
+		"session": session,
+		"updated": updated,
+	})
+}
+
+func handleDeleteNotification(context *gin.Context) {
+	user := context.MustGet("user").(*samuel.User)
+
+	notificationUUID, errParseNotificationUUID := uuid.Parse(context.Param("uuid"))
+	if errParseNotificationUUID != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed notification uuid", errParseNotificationUUID)
+		return
+	}
+
+	errDeleteNotification := samuel.DeleteNotification(context, user, notificationUUID)
+	if errDeleteNotification != nil {
+		switch {
+		case errors.Is(errDeleteNotification, sql.ErrNoRows),
+			errors.Is(errDeleteNotification, samuel.ErrNotificationNotRecipient):
+			respondAPIError(context, http.StatusNotFound, "notification not found", errDeleteNotification)
+		default:
+			respondAPIError(context, http.StatusInternalServerError, "cannot delete notification", errDeleteNotification)
+		}
+		return
+	}
+
+	respondAPISuccess(context, http.StatusOK, nil)
+}
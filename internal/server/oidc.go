@@ -0,0 +1,144 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sorucoder/samuel/internal/configuration"
+	"github.com/sorucoder/samuel/internal/oidc"
+	"github.com/sorucoder/samuel/internal/payloads"
+	"github.com/sorucoder/samuel/internal/samuel"
+)
+
+type oidcLoginState struct {
+	verifier  string
+	nonce     string
+	expiresOn time.Time
+}
+
+var (
+	oidcLoginStates      map[string]*oidcLoginState = make(map[string]*oidcLoginState)
+	oidcLoginStatesMutex sync.Mutex
+)
+
+var (
+	errOIDCLoginStateInvalid error = errors.New("oidc login state invalid")
+)
+
+func storeOIDCLoginState(state string, loginState *oidcLoginState) {
+	oidcLoginStatesMutex.Lock()
+	defer oidcLoginStatesMutex.Unlock()
+
+	now := time.Now()
+	for storedState, storedLoginState := range oidcLoginStates {
+		if now.After(storedLoginState.expiresOn) {
+			delete(oidcLoginStates, storedState)
+		}
+	}
+
+	oidcLoginStates[state] = loginState
+}
+
+func takeOIDCLoginState(state string) (*oidcLoginState, error) {
+	oidcLoginStatesMutex.Lock()
+	defer oidcLoginStatesMutex.Unlock()
+
+	loginState, exists := oidcLoginStates[state]
+	if !exists {
+		return nil, errOIDCLoginStateInvalid
+	}
+	delete(oidcLoginStates, state)
+
+	if time.Now().After(loginState.expiresOn) {
+		return nil, errOIDCLoginStateInvalid
+	}
+
+	return loginState, nil
+}
+
+func handleBeginOIDCLogin(context *gin.Context) {
+	if !oidc.Enabled() {
+		respondAPIError(context, http.StatusNotFound, "single sign-on not configured", oidc.ErrNotConfigured)
+		return
+	}
+
+	state, errNewState := oidc.NewToken()
+	if errNewState != nil {
+		respondAPIError(context, http.StatusInternalServerError, "cannot begin single sign-on", errNewState)
+		return
+	}
+
+	nonce, errNewNonce := oidc.NewToken()
+	if errNewNonce != nil {
+		respondAPIError(context, http.StatusInternalServerError, "cannot begin single sign-on", errNewNonce)
+		return
+	}
+
+	verifier, errNewVerifier := oidc.NewToken()
+	if errNewVerifier != nil {
+		respondAPIError(context, http.StatusInternalServerError, "cannot begin single sign-on", errNewVerifier)
+		return
+	}
+
+	authorizationURL, errAuthorizationURL := oidc.AuthorizationURL(context, state, nonce, verifier)
+	if errAuthorizationURL != nil {
+		respondAPIError(context, http.StatusBadGateway, "cannot reach identity provider", errAuthorizationURL)
+		return
+	}
+
+	storeOIDCLoginState(state, &oidcLoginState{
+		verifier:  verifier,
+		nonce:     nonce,
+		expiresOn: time.Now().Add(configuration.OIDC.GetDuration("stateLifetime")),
+	})
+
+	respondAPISuccess(context, http.StatusOK, map[string]any{
+		"url": authorizationURL,
+	})
+}
+
+func handleCompleteOIDCLogin(context *gin.Context) {
+	var payload payloads.CompleteOIDCLogin
+	errBindPayload := context.ShouldBind(&payload)
+	if errBindPayload != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed single sign-on data", errBindPayload)
+		return
+	}
+
+	loginState, errTakeLoginState := takeOIDCLoginState(payload.State)
+	if errTakeLoginState != nil {
+		respondAPIError(context, http.StatusBadRequest, "invalid single sign-on state", errTakeLoginState)
+		return
+	}
+
+	identity, errExchange := oidc.Exchange(context, payload.Code, loginState.verifier, loginState.nonce)
+	if errExchange != nil {
+		respondAPIError(context, http.StatusUnauthorized, "invalid single sign-on credentials", errExchange)
+		return
+	}
+
+	user, session, errLogin := samuel.LoginExternalUser(context, "oidc", identity, configuration.OIDC.GetStringSlice("providers"))
+	if errLogin != nil {
+		var twoFactorRequiredError *samuel.TwoFactorRequiredError
+		var loginThrottledError *samuel.LoginThrottledError
+		switch {
+		case errors.As(errLogin, &twoFactorRequiredError):
+			respondAPISuccess(context, http.StatusAccepted, map[string]any{
+				"challenge": twoFactorRequiredError.Challenge(),
+			})
+		case errors.As(errLogin, &loginThrottledError):
+			respondAPIThrottledError(context, loginThrottledError)
+		default:
+			respondAPIError(context, http.StatusUnauthorized, "invalid single sign-on credentials", errLogin)
+		}
+		return
+	}
+
+	respondAPISuccess(context, http.StatusOK, map[string]any{
+		"user":    user,
+		"session": session,
+	})
+}
@@ -0,0 +1,134 @@
+package server
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sorucoder/samuel/internal/payloads"
+	"github.com/sorucoder/samuel/internal/samuel"
+)
+
+func handleListPrograms(context *gin.Context) {
+	user := context.MustGet("user").(*samuel.User)
+	session := context.MustGet("session").(*samuel.Session)
+
+	var payload payloads.ListPrograms
+	errBindPayload := context.ShouldBind(&payload)
+	if errBindPayload != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed program list data", errBindPayload)
+		return
+	}
+
+	programs, errGetPrograms := samuel.GetPrograms(context, payload.Retired)
+	if errGetPrograms != nil {
+		respondAPIError(context, http.StatusInternalServerError, "cannot get programs", errGetPrograms)
+		return
+	}
+
+	respondAPISuccess(context, http.StatusOK, map[string]any{
+		"user":     user,
+		"session":  session,
+		"programs": programs,
+	})
+}
+
+func handleCreateProgram(context *gin.Context) {
+	user := context.MustGet("user").(*samuel.User)
+	session := context.MustGet("session").(*samuel.Session)
+	administrator := context.MustGet("administrator").(*samuel.Administrator)
+
+	var payload payloads.CreateProgram
+	errBindPayload := context.ShouldBind(&payload)
+	if errBindPayload != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed program create data", errBindPayload)
+		return
+	}
+
+	program, errCreateProgram := samuel.CreateProgram(context, user, payload.ID, payload.Name)
+	if errCreateProgram != nil {
+		var validationError *samuel.ValidationError
+		switch {
+		case errors.As(errCreateProgram, &validationError):
+			respondAPIValidationError(context, "invalid program", validationError)
+		case errors.Is(errCreateProgram, samuel.ErrProgramExists):
+			respondAPIError(context, http.StatusConflict, "program already exists", errCreateProgram)
+		default:
+			respondAPIError(context, http.StatusInternalServerError, "cannot create program", errCreateProgram)
+		}
+		return
+	}
+
+	respondAPISuccess(context, http.StatusCreated, map[string]any{
+		"user":          user,
+		"session":       session,
+		"administrator": administrator,
+		"program":       program,
+	})
+}
+
+func handleUpdateProgram(context *gin.Context) {
+	user := context.MustGet("user").(*samuel.User)
+	session := context.MustGet("session").(*samuel.Session)
+	administrator := context.MustGet("administrator").(*samuel.Administrator)
+
+	var payload payloads.UpdateProgram
+	errBindPayload := context.ShouldBind(&payload)
+	if errBindPayload != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed program update data", errBindPayload)
+		return
+	}
+
+	program, errUpdateProgram := samuel.UpdateProgram(context, user, context.Param("id"), payload.Name)
+	if errUpdateProgram != nil {
+		var validationError *samuel.ValidationError
+		switch {
+		case errors.As(errUpdateProgram, &validationError):
+			respondAPIValidationError(context, "invalid program", validationError)
+		case errors.Is(errUpdateProgram, sql.ErrNoRows):
+			respondAPIError(context, http.StatusNotFound, "program not found", errUpdateProgram)
+		case errors.Is(errUpdateProgram, samuel.ErrProgramRetired):
+			respondAPIError(context, http.StatusConflict, "program retired", errUpdateProgram)
+		default:
+			respondAPIError(context, http.StatusInternalServerError, "cannot update program", errUpdateProgram)
+		}
+		return
+	}
+
+	respondAPISuccess(context, http.StatusOK, map[string]any{
+		"user":          user,
+		"session":       session,
+		"administrator": administrator,
+		"program":       program,
+	})
+}
+
+func handleRetireProgram(context *gin.Context) {
+	user := context.MustGet("user").(*samuel.User)
+	session := context.MustGet("session").(*samuel.Session)
+	administrator := context.MustGet("administrator").(*samuel.Administrator)
+
+	program, errRetireProgram := samuel.RetireProgram(context, user, context.Param("id"))
+	if errRetireProgram != nil {
+		var referenceError *samuel.ReferenceError
+		switch {
+		case errors.As(errRetireProgram, &referenceError):
+			respondAPIReferenceError(context, "program still in use", referenceError)
+		case errors.Is(errRetireProgram, sql.ErrNoRows):
+			respondAPIError(context, http.StatusNotFound, "program not found", errRetireProgram)
+		case errors.Is(errRetireProgram, samuel.ErrProgramRetired):
+			respondAPIError(context, http.StatusConflict, "program already retired", errRetireProgram)
+		default:
+			respondAPIError(context, http.StatusInternalServerError, "cannot retire program", errRetireProgram)
+		}
+		return
+	}
+
+	respondAPISuccess(context, http.StatusOK, map[string]any{
+		"user":          user,
+		"session":       session,
+		"administrator": administrator,
+		"program":       program,
+	})
+}
@@ -0,0 +1,187 @@
+package server
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sorucoder/samuel/internal/payloads"
+	"github.com/sorucoder/samuel/internal/samuel"
+)
+
+func handleListProgramEvaluationQuestions(context *gin.Context) {
+	user := context.MustGet("user").(*samuel.User)
+	session := context.MustGet("session").(*samuel.Session)
+
+	var payload payloads.ListProgramEvaluationQuestions
+	errBindPayload := context.ShouldBind(&payload)
+	if errBindPayload != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed program evaluation question list data", errBindPayload)
+		return
+	}
+
+	programEvaluationQuestions, errGetProgramEvaluationQuestions := samuel.GetProgramEvaluationQuestions(context, user, context.Param("program"), payload.Retired)
+	if errGetProgramEvaluationQuestions != nil {
+		switch {
+		case errors.Is(errGetProgramEvaluationQuestions, sql.ErrNoRows):
+			respondAPIError(context, http.StatusNotFound, "program not found", errGetProgramEvaluationQuestions)
+		case errors.Is(errGetProgramEvaluationQuestions, samuel.ErrProgramEvaluationQuestionForbidden):
+			respondAPIError(context, http.StatusForbidden, "cannot view program evaluation questions", errGetProgramEvaluationQuestions)
+		default:
+			respondAPIError(context, http.StatusInternalServerError, "cannot get program evaluation questions", errGetProgramEvaluationQuestions)
+		}
+		return
+	}
+
+	respondAPISuccess(context, http.StatusOK, map[string]any{
+		"user":      user,
+		"session":   session,
+		"questions": programEvaluationQuestions,
+	})
+}
+
+func handleCreateProgramEvaluationQuestion(context *gin.Context) {
+	user := context.MustGet("user").(*samuel.User)
+	session := context.MustGet("session").(*samuel.Session)
+
+	var payload payloads.CreateProgramEvaluationQuestion
+	errBindPayload := context.ShouldBind(&payload)
+	if errBindPayload != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed program evaluation question create data", errBindPayload)
+		return
+	}
+
+	programEvaluationQuestion, errCreateProgramEvaluationQuestion := samuel.CreateProgramEvaluationQuestion(context, user, context.Param("program"), payload.Question)
+	if errCreateProgramEvaluationQuestion != nil {
+		var validationError *samuel.ValidationError
+		switch {
+		case errors.As(errCreateProgramEvaluationQuestion, &validationError):
+			respondAPIValidationError(context, "invalid program evaluation question", validationError)
+		case errors.Is(errCreateProgramEvaluationQuestion, sql.ErrNoRows):
+			respondAPIError(context, http.StatusNotFound, "program not found", errCreateProgramEvaluationQuestion)
+		case errors.Is(errCreateProgramEvaluationQuestion, samuel.ErrProgramEvaluationQuestionForbidden):
+			respondAPIError(context, http.StatusForbidden, "cannot create program evaluation question", errCreateProgramEvaluationQuestion)
+		default:
+			respondAPIError(context, http.StatusInternalServerError, "cannot create program evaluation question", errCreateProgramEvaluationQuestion)
+		}
+		return
+	}
+
+	respondAPISuccess(context, http.StatusCreated, map[string]any{
+		"user":     user,
+		"session":  session,
+		"question": programEvaluationQuestion,
+	})
+}
+
+func handleUpdateProgramEvaluationQuestion(context *gin.Context) {
+	user := context.MustGet("user").(*samuel.User)
+	session := context.MustGet("session").(*samuel.Session)
+
+	number, errParseNumber := strconv.ParseUint(context.Param("number"), 10, 8)
+	if errParseNumber != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed question number", errParseNumber)
+		return
+	}
+
+	var payload payloads.UpdateProgramEvaluationQuestion
+	errBindPayload := context.ShouldBind(&payload)
+	if errBindPayload != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed program evaluation question update data", errBindPayload)
+		return
+	}
+
+	programEvaluationQuestion, errUpdateProgramEvaluationQuestion := samuel.UpdateProgramEvaluationQuestion(context, user, context.Param("program"), uint8(number), payload.Question)
+	if errUpdateProgramEvaluationQuestion != nil {
+		var validationError *samuel.ValidationError
+		switch {
+		case errors.As(errUpdateProgramEvaluationQuestion, &validationError):
+			respondAPIValidationError(context, "invalid program evaluation question", validationError)
+		case errors.Is(errUpdateProgramEvaluationQuestion, sql.ErrNoRows):
+			respondAPIError(context, http.StatusNotFound, "program evaluation question not found", errUpdateProgramEvaluationQuestion)
+		case errors.Is(errUpdateProgramEvaluationQuestion, samuel.ErrProgramEvaluationQuestionForbidden):
+			respondAPIError(context, http.StatusForbidden, "cannot update program evaluation question", errUpdateProgramEvaluationQuestion)
+		case errors.Is(errUpdateProgramEvaluationQuestion, samuel.ErrProgramEvaluationQuestionRetired):
+			respondAPIError(context, http.StatusConflict, "program evaluation question retired", errUpdateProgramEvaluationQuestion)
+		case errors.Is(errUpdateProgramEvaluationQuestion, samuel.ErrProgramEvaluationQuestionAnswered):
+			respondAPIError(context, http.StatusConflict, "program evaluation question already answered", errUpdateProgramEvaluationQuestion)
+		default:
+			respondAPIError(context, http.StatusInternalServerError, "cannot update program evaluation question", errUpdateProgramEvaluationQuestion)
+		}
+		return
+	}
+
+	respondAPISuccess(context, http.StatusOK, map[string]any{
+		"user":     user,
+		"session":  session,
+		"question": programEvaluationQuestion,
+	})
+}
+
+func handleReorderProgramEvaluationQuestions(context *gin.Context) {
+	user := context.MustGet("user").(*samuel.User)
+	session := context.MustGet("session").(*samuel.Session)
+
+	var payload payloads.ReorderProgramEvaluationQuestions
+	errBindPayload := context.ShouldBind(&payload)
+	if errBindPayload != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed program evaluation question reorder data", errBindPayload)
+		return
+	}
+
+	programEvaluationQuestions, errReorderProgramEvaluationQuestions := samuel.ReorderProgramEvaluationQuestions(context, user, context.Param("program"), payload.Numbers)
+	if errReorderProgramEvaluationQuestions != nil {
+		var validationError *samuel.ValidationError
+		switch {
+		case errors.As(errReorderProgramEvaluationQuestions, &validationError):
+			respondAPIValidationError(context, "invalid program evaluation question order", validationError)
+		case errors.Is(errReorderProgramEvaluationQuestions, sql.ErrNoRows):
+			respondAPIError(context, http.StatusNotFound, "program not found", errReorderProgramEvaluationQuestions)
+		case errors.Is(errReorderProgramEvaluationQuestions, samuel.ErrProgramEvaluationQuestionForbidden):
+			respondAPIError(context, http.StatusForbidden, "cannot reorder program evaluation questions", errReorderProgramEvaluationQuestions)
+		default:
+			respondAPIError(context, http.StatusInternalServerError, "cannot reorder program evaluation questions", errReorderProgramEvaluationQuestions)
+		}
+		return
+	}
+
+	respondAPISuccess(context, http.StatusOK, map[string]any{
+		"user":      user,
+		"session":   session,
+		"questions": programEvaluationQuestions,
+	})
+}
+
+func handleRetireProgramEvaluationQuestion(context *gin.Context) {
+	user := context.MustGet("user").(*samuel.User)
+	session := context.MustGet("session").(*samuel.Session)
+
+	number, errParseNumber := strconv.ParseUint(context.Param("number"), 10, 8)
+	if errParseNumber != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed question number", errParseNumber)
+		return
+	}
+
+	programEvaluationQuestion, errRetireProgramEvaluationQuestion := samuel.RetireProgramEvaluationQuestion(context, user, context.Param("program"), uint8(number))
+	if errRetireProgramEvaluationQuestion != nil {
+		switch {
+		case errors.Is(errRetireProgramEvaluationQuestion, sql.ErrNoRows):
+			respondAPIError(context, http.StatusNotFound, "program evaluation question not found", errRetireProgramEvaluationQuestion)
+		case errors.Is(errRetireProgramEvaluationQuestion, samuel.ErrProgramEvaluationQuestionForbidden):
+			respondAPIError(context, http.StatusForbidden, "cannot retire program evaluation question", errRetireProgramEvaluationQuestion)
+		case errors.Is(errRetireProgramEvaluationQuestion, samuel.ErrProgramEvaluationQuestionRetired):
+			respondAPIError(context, http.StatusConflict, "program evaluation question already retired", errRetireProgramEvaluationQuestion)
+		default:
+			respondAPIError(context, http.StatusInternalServerError, "cannot retire program evaluation question", errRetireProgramEvaluationQuestion)
+		}
+		return
+	}
+
+	respondAPISuccess(context, http.StatusOK, map[string]any{
+		"user":     user,
+		"session":  session,
+		"question": programEvaluationQuestion,
+	})
+}
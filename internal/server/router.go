@@ -3,7 +3,9 @@ package server
 import (
 	"encoding/base64"
 	"errors"
+	"math"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/gin-gonic/gin"
@@ -34,6 +36,30 @@ func respondAPIError(context *gin.Context, code int, message string, err error)
 	context.AbortWithStatusJSON(code, response)
 }
 
+func respondAPIValidationError(context *gin.Context, message string, err *samuel.ValidationError) {
+	context.AbortWithStatusJSON(http.StatusUnprocessableEntity, map[string]any{
+		"error":  message,
+		"fields": err.Fields(),
+	})
+}
+
+func respondAPIReferenceError(context *gin.Context, message string, err *samuel.ReferenceError) {
+	context.AbortWithStatusJSON(http.StatusConflict, map[string]any{
+		"error":       message,
+		"explanation": err.Error(),
+		"references":  err.References(),
+	})
+}
+
+func respondAPIThrottledError(context *gin.Context, err *samuel.LoginThrottledError) {
+	context.Header("Retry-After", strconv.FormatInt(int64(math.Ceil(err.RetryAfter().Seconds())), 10))
+	if err.Locked() {
+		respondAPIError(context, http.StatusLocked, "user locked", err)
+	} else {
+		respondAPIError(context, http.StatusTooManyRequests, "too many failed logins", err)
+	}
+}
+
 // Middleware
 func handleAuthorizedAPIGroup(context *gin.Context) {
 	authorization := context.GetHeader("Authorization")
@@ -102,9 +128,20 @@ func handleLogin(context *gin.Context) {
 		return
 	}
 
-	user, session, errAuthenticate := samuel.LoginUser(context, identity, password)
+	user, session, errAuthenticate := samuel.LoginUser(context, identity, password, context.ClientIP())
 	if errAuthenticate != nil {
-		respondAPIError(context, http.StatusUnauthorized, "invalid credentials", errAuthenticate)
+		var twoFactorRequiredError *samuel.TwoFactorRequiredError
+		var loginThrottledError *samuel.LoginThrottledError
+		switch {
+		case errors.As(errAuthenticate, &twoFactorRequiredError):
+			respondAPISuccess(context, http.StatusAccepted, map[string]any{
+				"challenge": twoFactorRequiredError.Challenge(),
+			})
+		case errors.As(errAuthenticate, &loginThrottledError):
+			respondAPIThrottledError(context, loginThrottledError)
+		default:
+			respondAPIError(context, http.StatusUnauthorized, "invalid credentials", errAuthenticate)
+		}
 		return
 	}
 
@@ -214,6 +251,13 @@ func handleDashboard(context *gin.Context) {
 		response["student"] = student
 	}
 
+	unseenNotifications, errCountUnseenNotifications := samuel.CountUnseenNotifications(context, user)
+	if errCountUnseenNotifications != nil {
+		respondAPIError(context, http.StatusInternalServerError, "cannot count unseen notifications", errCountUnseenNotifications)
+		return
+	}
+	response["unseenNotifications"] = unseenNotifications
+
 	respondAPISuccess(context, http.StatusOK, response)
 }
 
@@ -245,6 +289,10 @@ func handleViewAudit(context *gin.Context) {
 
 func newRouter() *gin.Engine {
 	router := gin.New()
+	errSetTrustedProxies := router.SetTrustedProxies(configuration.Application.GetStringSlice("trustedProxies"))
+	if errSetTrustedProxies != nil {
+		panic(errSetTrustedProxies)
+	}
 	router.Use(gin.Logger())
 	router.Use(gin.Recovery())
 
@@ -252,21 +300,153 @@ func newRouter() *gin.Engine {
 	{
 		API.GET("/login", handleLogin)
 
+		oidcAPI := API.Group("/oidc")
+		{
+			oidcAPI.GET("/login", handleBeginOIDCLogin)
+			oidcAPI.GET("/callback", handleCompleteOIDCLogin)
+		}
+
 		passwordChangeAPI := API.Group("/password_change")
 		{
 			passwordChangeAPI.POST("/create", handleCreatePasswordChange)
 			passwordChangeAPI.PUT("/fulfill/:token", handleFulfillPasswordChange)
 		}
 
+		twoFactorChallengeAPI := API.Group("/two_factor/challenge")
+		{
+			twoFactorChallengeAPI.POST("/enroll/:token", handleBeginChallengedTwoFactorEnrollment)
+			twoFactorChallengeAPI.PUT("/verify/:token", handleVerifyTwoFactorChallenge)
+		}
+
+		API.PUT("/invitation/accept/:token", handleAcceptSupervisorInvitation)
+
+		API.GET("/events/stream", handleEventStreamGroup, handleEventStream)
+
 		authorizedAPI := API.Group("/", handleAuthorizedAPIGroup)
 		{
 			authorizedAPI.GET("/ping", handlePing)
 			authorizedAPI.GET("/dashboard", handleDashboard)
 			authorizedAPI.GET("/logout", handleLogout)
+			authorizedAPI.PUT("/roles/switch/:role", handleSwitchRole)
+			authorizedAPI.POST("/events/ticket", handleIssueEventStreamTicket)
+
+			twoFactorAPI := authorizedAPI.Group("/two_factor")
+			{
+				twoFactorAPI.POST("/enroll", handleBeginTwoFactorEnrollment)
+				twoFactorAPI.PUT("/confirm", handleConfirmTwoFactorEnrollment)
+				twoFactorAPI.PUT("/disable", handleDisableTwoFactor)
+			}
+
+			notificationAPI := authorizedAPI.Group("/notifications")
+			{
+				notificationAPI.GET("/list", handleListNotifications)
+				notificationAPI.GET("/unseen_count", handleCountUnseenNotifications)
+				notificationAPI.PUT("/seen", handleMarkNotificationsSeen)
+				notificationAPI.PUT("/seen/:uuid", handleMarkNotificationSeen)
+				notificationAPI.DELETE("/delete/:uuid", handleDeleteNotification)
+			}
+
+			messageAPI := authorizedAPI.Group("/messages")
+			{
+				messageAPI.POST("/send/:user", handleSendMessage)
+				messageAPI.POST("/reply/:uuid", handleReplyToMessage)
+				messageAPI.GET("/thread/:uuid", handleViewMessageThread)
+			}
+
+			internshipAPI := authorizedAPI.Group("/internships")
+			{
+				internshipAPI.GET("/list", handleListInternships)
+				internshipAPI.GET("/view/:uuid", handleViewInternship)
+				internshipAPI.PUT("/close/:uuid", handleCloseInternship)
+				internshipAPI.PUT("/reassign/:uuid", handleReassignInternship)
+			}
+
+			authorizedAPI.GET("/campuses/list", handleListCampuses)
+			authorizedAPI.GET("/programs/list", handleListPrograms)
+
+			companyAPI := authorizedAPI.Group("/companies")
+			{
+				companyAPI.GET("/list", handleListCompanies)
+				companyAPI.GET("/view/:uuid", handleViewCompany)
+				companyAPI.POST("/create", handleCreateCompany)
+				companyAPI.PUT("/update/:uuid", handleUpdateCompany)
+				companyAPI.PUT("/archive/:uuid", handleArchiveCompany)
+			}
+
+			timecardAPI := authorizedAPI.Group("/timecards")
+			{
+				timecardAPI.GET("/list/:internship", handleListTimecards)
+				timecardAPI.GET("/view/:internship/:week", handleViewTimecard)
+				timecardAPI.PUT("/draft/:internship/:week", handleDraftTimecard)
+				timecardAPI.PUT("/submit/:internship/:week", handleSubmitTimecard)
+				timecardAPI.PUT("/approve/:internship/:week", handleApproveTimecard)
+				timecardAPI.PUT("/deny/:internship/:week", handleDenyTimecard)
+			}
+
+			supervisorReportAPI := authorizedAPI.Group("/supervisor_reports")
+			{
+				supervisorReportAPI.GET("/list/:internship", handleListSupervisorReports)
+				supervisorReportAPI.GET("/view/:internship/:week", handleViewSupervisorReport)
+				supervisorReportAPI.POST("/submit/:internship/:week", handleSubmitSupervisorReport)
+				supervisorReportAPI.GET("/call_requests", handleListPhoneCallRequests)
+				supervisorReportAPI.PUT("/resolve_call/:internship/:week", handleResolvePhoneCallRequest)
+			}
+
+			studentReportAPI := authorizedAPI.Group("/student_reports")
+			{
+				studentReportAPI.GET("/list/:internship", handleListStudentReports)
+				studentReportAPI.GET("/view/:internship/:week", handleViewStudentReport)
+				studentReportAPI.POST("/submit/:internship/:week", handleSubmitStudentReport)
+				studentReportAPI.PUT("/comment/:internship/:week", handleCommentStudentReport)
+			}
+
+			studentEvaluationAPI := authorizedAPI.Group("/student_evaluations")
+			{
+				studentEvaluationAPI.GET("/view/:internship", handleViewStudentEvaluation)
+				studentEvaluationAPI.POST("/submit/:internship", handleSubmitStudentEvaluation)
+				studentEvaluationAPI.GET("/company/:company", handleListCompanyStudentEvaluations)
+			}
+
+			supervisorGeneralEvaluationAPI := authorizedAPI.Group("/supervisor_evaluations")
+			{
+				supervisorGeneralEvaluationAPI.GET("/questions/:internship", handleListSupervisorGeneralEvaluationQuestions)
+				supervisorGeneralEvaluationAPI.GET("/view/:internship", handleViewSupervisorGeneralEvaluation)
+				supervisorGeneralEvaluationAPI.POST("/submit/:internship", handleSubmitSupervisorGeneralEvaluation)
+				supervisorGeneralEvaluationAPI.PUT("/release/:internship", handleReleaseSupervisorGeneralEvaluation)
+			}
+
+			programEvaluationQuestionAPI := authorizedAPI.Group("/program_questions")
+			{
+				programEvaluationQuestionAPI.GET("/list/:program", handleListProgramEvaluationQuestions)
+				programEvaluationQuestionAPI.POST("/create/:program", handleCreateProgramEvaluationQuestion)
+				programEvaluationQuestionAPI.PUT("/update/:program/:number", handleUpdateProgramEvaluationQuestion)
+				programEvaluationQuestionAPI.PUT("/reorder/:program", handleReorderProgramEvaluationQuestions)
+				programEvaluationQuestionAPI.PUT("/retire/:program/:number", handleRetireProgramEvaluationQuestion)
+			}
 
 			administratorAPI := authorizedAPI.Group("/", handleAdministratorAPIGroup)
 			{
 				administratorAPI.GET("/audit/view", handleViewAudit)
+				administratorAPI.POST("/users/provision", handleProvisionUser)
+				administratorAPI.POST("/users/import_students", handleImportStudents)
+				administratorAPI.PUT("/users/roles/grant/:uuid/:role", handleGrantUserRole)
+				administratorAPI.DELETE("/users/roles/revoke/:uuid/:role", handleRevokeUserRole)
+				administratorAPI.DELETE("/users/two_factor/reset/:uuid", handleResetSupervisorTwoFactor)
+				administratorAPI.PUT("/users/unlock/:uuid", handleUnlockUser)
+				administratorAPI.PUT("/companies/two_factor/:uuid", handleRequireCompanyTwoFactor)
+				administratorAPI.GET("/invitations/list", handleListSupervisorInvitations)
+				administratorAPI.PUT("/invitations/resend/:supervisor", handleResendSupervisorInvitation)
+				administratorAPI.POST("/internships/create", handleCreateInternship)
+				administratorAPI.GET("/messages/log/:user", handleViewMessageLog)
+				administratorAPI.POST("/campuses/create", handleCreateCampus)
+				administratorAPI.PUT("/campuses/update/:id", handleUpdateCampus)
+				administratorAPI.PUT("/campuses/retire/:id", handleRetireCampus)
+				administratorAPI.POST("/programs/create", handleCreateProgram)
+				administratorAPI.PUT("/programs/update/:id", handleUpdateProgram)
+				administratorAPI.PUT("/programs/retire/:id", handleRetireProgram)
+				administratorAPI.GET("/coordinators/list", handleListCoordinators)
+				administratorAPI.PUT("/coordinators/assign/:campus/:program", handleAssignCoordinator)
+				administratorAPI.DELETE("/coordinators/unassign/:campus/:program", handleUnassignCoordinator)
 			}
 		}
 	}
@@ -9,6 +9,8 @@ import (
 func Run() {
 	router := newRouter()
 
+	scheduleDirectorySynchronization()
+
 	address := fmt.Sprintf(`%s:%d`, configuration.Application.GetString("ip"), configuration.Application.GetInt("port"))
 	fmt.Println(address)
 
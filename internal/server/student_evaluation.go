@@ -0,0 +1,121 @@
+package server
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sorucoder/samuel/internal/payloads"
+	"github.com/sorucoder/samuel/internal/samuel"
+)
+
+func handleSubmitStudentEvaluation(context *gin.Context) {
+	user := context.MustGet("user").(*samuel.User)
+	session := context.MustGet("session").(*samuel.Session)
+
+	internshipUUID, errParseInternshipUUID := uuid.Parse(context.Param("internship"))
+	if errParseInternshipUUID != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed internship uuid", errParseInternshipUUID)
+		return
+	}
+
+	var payload payloads.SubmitStudentEvaluation
+	errBindPayload := context.ShouldBind(&payload)
+	if errBindPayload != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed student evaluation submit data", errBindPayload)
+		return
+	}
+
+	studentEvaluation, errSubmitStudentEvaluation := samuel.SubmitStudentEvaluation(context, user, internshipUUID, payload.Content())
+	if errSubmitStudentEvaluation != nil {
+		switch {
+		case errors.Is(errSubmitStudentEvaluation, sql.ErrNoRows):
+			respondAPIError(context, http.StatusNotFound, "internship not found", errSubmitStudentEvaluation)
+		case errors.Is(errSubmitStudentEvaluation, samuel.ErrUserNotStudent),
+			errors.Is(errSubmitStudentEvaluation, samuel.ErrStudentEvaluationNotStudent):
+			respondAPIError(context, http.StatusForbidden, "cannot submit student evaluation", errSubmitStudentEvaluation)
+		case errors.Is(errSubmitStudentEvaluation, samuel.ErrStudentEvaluationNotOpen),
+			errors.Is(errSubmitStudentEvaluation, samuel.ErrStudentEvaluationExists):
+			respondAPIError(context, http.StatusConflict, "student evaluation not submittable", errSubmitStudentEvaluation)
+		default:
+			respondAPIError(context, http.StatusInternalServerError, "cannot submit student evaluation", errSubmitStudentEvaluation)
+		}
+		return
+	}
+
+	respondAPISuccess(context, http.StatusCreated, map[string]any{
+		"user":              user,
+		"session":           session,
+		"studentEvaluation": studentEvaluation,
+	})
+}
+
+func handleViewStudentEvaluation(context *gin.Context) {
+	user := context.MustGet("user").(*samuel.User)
+	session := context.MustGet("session").(*samuel.Session)
+
+	internshipUUID, errParseInternshipUUID := uuid.Parse(context.Param("internship"))
+	if errParseInternshipUUID != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed internship uuid", errParseInternshipUUID)
+		return
+	}
+
+	studentEvaluation, errGetStudentEvaluation := samuel.GetStudentEvaluation(context, user, internshipUUID)
+	if errGetStudentEvaluation != nil {
+		switch {
+		case errors.Is(errGetStudentEvaluation, sql.ErrNoRows):
+			respondAPIError(context, http.StatusNotFound, "student evaluation not found", errGetStudentEvaluation)
+		case errors.Is(errGetStudentEvaluation, samuel.ErrStudentEvaluationNotVisible):
+			respondAPIError(context, http.StatusForbidden, "cannot view student evaluation", errGetStudentEvaluation)
+		default:
+			respondAPIError(context, http.StatusInternalServerError, "cannot get student evaluation", errGetStudentEvaluation)
+		}
+		return
+	}
+
+	respondAPISuccess(context, http.StatusOK, map[string]any{
+		"user":              user,
+		"session":           session,
+		"studentEvaluation": studentEvaluation,
+	})
+}
+
+func handleListCompanyStudentEvaluations(context *gin.Context) {
+	user := context.MustGet("user").(*samuel.User)
+	session := context.MustGet("session").(*samuel.Session)
+
+	companyUUID, errParseCompanyUUID := uuid.Parse(context.Param("company"))
+	if errParseCompanyUUID != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed company uuid", errParseCompanyUUID)
+		return
+	}
+
+	var payload payloads.ListStudentEvaluations
+	errBindPayload := context.ShouldBind(&payload)
+	if errBindPayload != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed student evaluation list data", errBindPayload)
+		return
+	}
+
+	studentEvaluationBatch, studentEvaluationAggregate, errGetStudentEvaluationBatch := samuel.GetStudentEvaluationBatchByCompany(context, user, companyUUID, payload.Page, payload.Count)
+	if errGetStudentEvaluationBatch != nil {
+		switch {
+		case errors.Is(errGetStudentEvaluationBatch, sql.ErrNoRows):
+			respondAPIError(context, http.StatusNotFound, "company not found", errGetStudentEvaluationBatch)
+		case errors.Is(errGetStudentEvaluationBatch, samuel.ErrStudentEvaluationNotVisible):
+			respondAPIError(context, http.StatusForbidden, "cannot view student evaluations", errGetStudentEvaluationBatch)
+		default:
+			respondAPIError(context, http.StatusInternalServerError, "cannot get student evaluations", errGetStudentEvaluationBatch)
+		}
+		return
+	}
+
+	respondAPISuccess(context, http.StatusOK, map[string]any{
+		"user":      user,
+		"session":   session,
+		"batch":     studentEvaluationBatch,
+		"aggregate": studentEvaluationAggregate,
+	})
+}
@@ -0,0 +1,185 @@
+package server
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sorucoder/samuel/internal/payloads"
+	"github.com/sorucoder/samuel/internal/samuel"
+)
+
+func handleSubmitStudentReport(context *gin.Context) {
+	user := context.MustGet("user").(*samuel.User)
+	session := context.MustGet("session").(*samuel.Session)
+
+	internshipUUID, errParseInternshipUUID := uuid.Parse(context.Param("internship"))
+	if errParseInternshipUUID != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed internship uuid", errParseInternshipUUID)
+		return
+	}
+
+	var weekOf payloads.Date
+	errParseWeekOf := weekOf.UnmarshalParam(context.Param("week"))
+	if errParseWeekOf != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed week", errParseWeekOf)
+		return
+	}
+
+	var payload payloads.SubmitStudentReport
+	errBindPayload := context.ShouldBind(&payload)
+	if errBindPayload != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed student report submit data", errBindPayload)
+		return
+	}
+
+	studentReport, errSubmitStudentReport := samuel.SubmitStudentReport(context, user, internshipUUID, weekOf.Time, payload.Content())
+	if errSubmitStudentReport != nil {
+		var validationError *samuel.ValidationError
+		switch {
+		case errors.As(errSubmitStudentReport, &validationError):
+			respondAPIValidationError(context, "invalid student report", validationError)
+		case errors.Is(errSubmitStudentReport, sql.ErrNoRows):
+			respondAPIError(context, http.StatusNotFound, "internship not found", errSubmitStudentReport)
+		case errors.Is(errSubmitStudentReport, samuel.ErrUserNotStudent),
+			errors.Is(errSubmitStudentReport, samuel.ErrStudentReportNotStudent):
+			respondAPIError(context, http.StatusForbidden, "cannot submit student report", errSubmitStudentReport)
+		case errors.Is(errSubmitStudentReport, samuel.ErrInternshipClosed),
+			errors.Is(errSubmitStudentReport, samuel.ErrStudentReportExists):
+			respondAPIError(context, http.StatusConflict, "student report not submittable", errSubmitStudentReport)
+		default:
+			respondAPIError(context, http.StatusInternalServerError, "cannot submit student report", errSubmitStudentReport)
+		}
+		return
+	}
+
+	respondAPISuccess(context, http.StatusCreated, map[string]any{
+		"user":          user,
+		"session":       session,
+		"studentReport": studentReport,
+	})
+}
+
+func handleListStudentReports(context *gin.Context) {
+	user := context.MustGet("user").(*samuel.User)
+	session := context.MustGet("session").(*samuel.Session)
+
+	internshipUUID, errParseInternshipUUID := uuid.Parse(context.Param("internship"))
+	if errParseInternshipUUID != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed internship uuid", errParseInternshipUUID)
+		return
+	}
+
+	var payload payloads.ListStudentReports
+	errBindPayload := context.ShouldBind(&payload)
+	if errBindPayload != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed student report list data", errBindPayload)
+		return
+	}
+
+	studentReportBatch, errGetStudentReportBatch := samuel.GetStudentReportBatchByInternship(context, user, internshipUUID, payload.Page, payload.Count)
+	if errGetStudentReportBatch != nil {
+		switch {
+		case errors.Is(errGetStudentReportBatch, sql.ErrNoRows):
+			respondAPIError(context, http.StatusNotFound, "internship not found", errGetStudentReportBatch)
+		case errors.Is(errGetStudentReportBatch, samuel.ErrStudentReportNotVisible):
+			respondAPIError(context, http.StatusForbidden, "cannot view student reports", errGetStudentReportBatch)
+		default:
+			respondAPIError(context, http.StatusInternalServerError, "cannot get student reports", errGetStudentReportBatch)
+		}
+		return
+	}
+
+	respondAPISuccess(context, http.StatusOK, map[string]any{
+		"user":    user,
+		"session": session,
+		"batch":   studentReportBatch,
+	})
+}
+
+func handleViewStudentReport(context *gin.Context) {
+	user := context.MustGet("user").(*samuel.User)
+	session := context.MustGet("session").(*samuel.Session)
+
+	internshipUUID, errParseInternshipUUID := uuid.Parse(context.Param("internship"))
+	if errParseInternshipUUID != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed internship uuid", errParseInternshipUUID)
+		return
+	}
+
+	var weekOf payloads.Date
+	errParseWeekOf := weekOf.UnmarshalParam(context.Param("week"))
+	if errParseWeekOf != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed week", errParseWeekOf)
+		return
+	}
+
+	studentReport, errGetStudentReport := samuel.GetStudentReport(context, user, internshipUUID, weekOf.Time)
+	if errGetStudentReport != nil {
+		switch {
+		case errors.Is(errGetStudentReport, sql.ErrNoRows):
+			respondAPIError(context, http.StatusNotFound, "student report not found", errGetStudentReport)
+		case errors.Is(errGetStudentReport, samuel.ErrStudentReportNotVisible):
+			respondAPIError(context, http.StatusForbidden, "cannot view student report", errGetStudentReport)
+		default:
+			respondAPIError(context, http.StatusInternalServerError, "cannot get student report", errGetStudentReport)
+		}
+		return
+	}
+
+	respondAPISuccess(context, http.StatusOK, map[string]any{
+		"user":          user,
+		"session":       session,
+		"studentReport": studentReport,
+	})
+}
+
+func handleCommentStudentReport(context *gin.Context) {
+	user := context.MustGet("user").(*samuel.User)
+	session := context.MustGet("session").(*samuel.Session)
+
+	internshipUUID, errParseInternshipUUID := uuid.Parse(context.Param("internship"))
+	if errParseInternshipUUID != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed internship uuid", errParseInternshipUUID)
+		return
+	}
+
+	var weekOf payloads.Date
+	errParseWeekOf := weekOf.UnmarshalParam(context.Param("week"))
+	if errParseWeekOf != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed week", errParseWeekOf)
+		return
+	}
+
+	var payload payloads.CommentStudentReport
+	errBindPayload := context.ShouldBind(&payload)
+	if errBindPayload != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed student report comment data", errBindPayload)
+		return
+	}
+
+	studentReport, errCommentStudentReport := samuel.CommentStudentReport(context, user, internshipUUID, weekOf.Time, payload.Comment)
+	if errCommentStudentReport != nil {
+		var validationError *samuel.ValidationError
+		switch {
+		case errors.As(errCommentStudentReport, &validationError):
+			respondAPIValidationError(context, "invalid student report comment", validationError)
+		case errors.Is(errCommentStudentReport, sql.ErrNoRows):
+			respondAPIError(context, http.StatusNotFound, "student report not found", errCommentStudentReport)
+		case errors.Is(errCommentStudentReport, samuel.ErrUserNotInstructor),
+			errors.Is(errCommentStudentReport, samuel.ErrStudentReportNotInstructor):
+			respondAPIError(context, http.StatusForbidden, "cannot comment on student report", errCommentStudentReport)
+		default:
+			respondAPIError(context, http.StatusInternalServerError, "cannot comment on student report", errCommentStudentReport)
+		}
+		return
+	}
+
+	respondAPISuccess(context, http.StatusOK, map[string]any{
+		"user":          user,
+		"session":       session,
+		"studentReport": studentReport,
+	})
+}
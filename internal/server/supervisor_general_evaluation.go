@@ -0,0 +1,150 @@
+package server
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sorucoder/samuel/internal/payloads"
+	"github.com/sorucoder/samuel/internal/samuel"
+)
+
+func handleSubmitSupervisorGeneralEvaluation(context *gin.Context) {
+	user := context.MustGet("user").(*samuel.User)
+	session := context.MustGet("session").(*samuel.Session)
+
+	internshipUUID, errParseInternshipUUID := uuid.Parse(context.Param("internship"))
+	if errParseInternshipUUID != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed internship uuid", errParseInternshipUUID)
+		return
+	}
+
+	var payload payloads.SubmitSupervisorGeneralEvaluation
+	errBindPayload := context.ShouldBind(&payload)
+	if errBindPayload != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed supervisor general evaluation submit data", errBindPayload)
+		return
+	}
+
+	supervisorGeneralEvaluation, errSubmit := samuel.SubmitSupervisorGeneralEvaluation(context, user, internshipUUID, payload.Content())
+	if errSubmit != nil {
+		var validationError *samuel.ValidationError
+		switch {
+		case errors.As(errSubmit, &validationError):
+			respondAPIValidationError(context, "invalid supervisor general evaluation", validationError)
+		case errors.Is(errSubmit, sql.ErrNoRows):
+			respondAPIError(context, http.StatusNotFound, "internship not found", errSubmit)
+		case errors.Is(errSubmit, samuel.ErrUserNotSupervisor),
+			errors.Is(errSubmit, samuel.ErrSupervisorGeneralEvaluationNotSupervisor):
+			respondAPIError(context, http.StatusForbidden, "cannot submit supervisor general evaluation", errSubmit)
+		case errors.Is(errSubmit, samuel.ErrSupervisorGeneralEvaluationTooEarly),
+			errors.Is(errSubmit, samuel.ErrSupervisorGeneralEvaluationExists):
+			respondAPIError(context, http.StatusConflict, "supervisor general evaluation not submittable", errSubmit)
+		default:
+			respondAPIError(context, http.StatusInternalServerError, "cannot submit supervisor general evaluation", errSubmit)
+		}
+		return
+	}
+
+	respondAPISuccess(context, http.StatusCreated, map[string]any{
+		"user":                        user,
+		"session":                     session,
+		"supervisorGeneralEvaluation": supervisorGeneralEvaluation,
+	})
+}
+
+func handleViewSupervisorGeneralEvaluation(context *gin.Context) {
+	user := context.MustGet("user").(*samuel.User)
+	session := context.MustGet("session").(*samuel.Session)
+
+	internshipUUID, errParseInternshipUUID := uuid.Parse(context.Param("internship"))
+	if errParseInternshipUUID != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed internship uuid", errParseInternshipUUID)
+		return
+	}
+
+	supervisorGeneralEvaluation, errGet := samuel.GetSupervisorGeneralEvaluation(context, user, internshipUUID)
+	if errGet != nil {
+		switch {
+		case errors.Is(errGet, sql.ErrNoRows):
+			respondAPIError(context, http.StatusNotFound, "supervisor general evaluation not found", errGet)
+		case errors.Is(errGet, samuel.ErrInternshipNotInvolved),
+			errors.Is(errGet, samuel.ErrSupervisorGeneralEvaluationNotVisible):
+			respondAPIError(context, http.StatusForbidden, "cannot view supervisor general evaluation", errGet)
+		default:
+			respondAPIError(context, http.StatusInternalServerError, "cannot get supervisor general evaluation", errGet)
+		}
+		return
+	}
+
+	respondAPISuccess(context, http.StatusOK, map[string]any{
+		"user":                        user,
+		"session":                     session,
+		"supervisorGeneralEvaluation": supervisorGeneralEvaluation,
+	})
+}
+
+func handleReleaseSupervisorGeneralEvaluation(context *gin.Context) {
+	user := context.MustGet("user").(*samuel.User)
+	session := context.MustGet("session").(*samuel.Session)
+
+	internshipUUID, errParseInternshipUUID := uuid.Parse(context.Param("internship"))
+	if errParseInternshipUUID != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed internship uuid", errParseInternshipUUID)
+		return
+	}
+
+	supervisorGeneralEvaluation, errRelease := samuel.ReleaseSupervisorGeneralEvaluation(context, user, internshipUUID)
+	if errRelease != nil {
+		switch {
+		case errors.Is(errRelease, sql.ErrNoRows):
+			respondAPIError(context, http.StatusNotFound, "supervisor general evaluation not found", errRelease)
+		case errors.Is(errRelease, samuel.ErrUserNotInstructor),
+			errors.Is(errRelease, samuel.ErrSupervisorGeneralEvaluationNotInstructor):
+			respondAPIError(context, http.StatusForbidden, "cannot release supervisor general evaluation", errRelease)
+		case errors.Is(errRelease, samuel.ErrSupervisorGeneralEvaluationReleased):
+			respondAPIError(context, http.StatusConflict, "supervisor general evaluation already released", errRelease)
+		default:
+			respondAPIError(context, http.StatusInternalServerError, "cannot release supervisor general evaluation", errRelease)
+		}
+		return
+	}
+
+	respondAPISuccess(context, http.StatusOK, map[string]any{
+		"user":                        user,
+		"session":                     session,
+		"supervisorGeneralEvaluation": supervisorGeneralEvaluation,
+	})
+}
+
+func handleListSupervisorGeneralEvaluationQuestions(context *gin.Context) {
+	user := context.MustGet("user").(*samuel.User)
+	session := context.MustGet("session").(*samuel.Session)
+
+	internshipUUID, errParseInternshipUUID := uuid.Parse(context.Param("internship"))
+	if errParseInternshipUUID != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed internship uuid", errParseInternshipUUID)
+		return
+	}
+
+	programEvaluationQuestions, errGetProgramEvaluationQuestions := samuel.GetSupervisorGeneralEvaluationQuestions(context, user, internshipUUID)
+	if errGetProgramEvaluationQuestions != nil {
+		switch {
+		case errors.Is(errGetProgramEvaluationQuestions, sql.ErrNoRows):
+			respondAPIError(context, http.StatusNotFound, "internship not found", errGetProgramEvaluationQuestions)
+		case errors.Is(errGetProgramEvaluationQuestions, samuel.ErrInternshipNotInvolved):
+			respondAPIError(context, http.StatusForbidden, "cannot view supervisor general evaluation questions", errGetProgramEvaluationQuestions)
+		default:
+			respondAPIError(context, http.StatusInternalServerError, "cannot get supervisor general evaluation questions", errGetProgramEvaluationQuestions)
+		}
+		return
+	}
+
+	respondAPISuccess(context, http.StatusOK, map[string]any{
+		"user":      user,
+		"session":   session,
+		"questions": programEvaluationQuestions,
+	})
+}
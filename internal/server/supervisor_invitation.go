@@ -0,0 +1,113 @@
+package server
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sorucoder/samuel/internal/payloads"
+	"github.com/sorucoder/samuel/internal/samuel"
+)
+
+func handleListSupervisorInvitations(context *gin.Context) {
+	user := context.MustGet("user").(*samuel.User)
+	session := context.MustGet("session").(*samuel.Session)
+	administrator := context.MustGet("administrator").(*samuel.Administrator)
+
+	var payload payloads.ListSupervisorInvitations
+	errBindPayload := context.ShouldBind(&payload)
+	if errBindPayload != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed invitation list data", errBindPayload)
+		return
+	}
+
+	supervisorInvitationBatch, errGetSupervisorInvitationBatch := samuel.GetSupervisorInvitationBatch(context, user, payload.Status, payload.Page, payload.Count)
+	if errGetSupervisorInvitationBatch != nil {
+		switch {
+		case errors.Is(errGetSupervisorInvitationBatch, samuel.ErrUserNotAdministrator):
+			respondAPIError(context, http.StatusForbidden, "cannot list invitations", errGetSupervisorInvitationBatch)
+		case errors.Is(errGetSupervisorInvitationBatch, samuel.ErrSupervisorInvitationStatusUnknown):
+			respondAPIError(context, http.StatusBadRequest, "malformed invitation list data", errGetSupervisorInvitationBatch)
+		default:
+			respondAPIError(context, http.StatusInternalServerError, "cannot list invitations", errGetSupervisorInvitationBatch)
+		}
+		return
+	}
+
+	respondAPISuccess(context, http.StatusOK, map[string]any{
+		"user":          user,
+		"session":       session,
+		"administrator": administrator,
+		"batch":         supervisorInvitationBatch,
+	})
+}
+
+func handleResendSupervisorInvitation(context *gin.Context) {
+	user := context.MustGet("user").(*samuel.User)
+	session := context.MustGet("session").(*samuel.Session)
+	administrator := context.MustGet("administrator").(*samuel.Administrator)
+
+	supervisorUUID, errParseSupervisorUUID := uuid.Parse(context.Param("supervisor"))
+	if errParseSupervisorUUID != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed supervisor uuid", errParseSupervisorUUID)
+		return
+	}
+
+	supervisorInvitation, errResendSupervisorInvitation := samuel.ResendSupervisorInvitation(context, user, supervisorUUID)
+	if errResendSupervisorInvitation != nil {
+		switch {
+		case errors.Is(errResendSupervisorInvitation, sql.ErrNoRows):
+			respondAPIError(context, http.StatusNotFound, "supervisor not found", errResendSupervisorInvitation)
+		case errors.Is(errResendSupervisorInvitation, samuel.ErrUserNotAdministrator):
+			respondAPIError(context, http.StatusForbidden, "cannot resend invitation", errResendSupervisorInvitation)
+		case errors.Is(errResendSupervisorInvitation, samuel.ErrUserNotSupervisor):
+			respondAPIError(context, http.StatusBadRequest, "user is not a supervisor", errResendSupervisorInvitation)
+		case errors.Is(errResendSupervisorInvitation, samuel.ErrSupervisorInvitationAccepted):
+			respondAPIError(context, http.StatusConflict, "invitation already accepted", errResendSupervisorInvitation)
+		default:
+			respondAPIError(context, http.StatusInternalServerError, "cannot resend invitation", errResendSupervisorInvitation)
+		}
+		return
+	}
+
+	respondAPISuccess(context, http.StatusOK, map[string]any{
+		"user":          user,
+		"session":       session,
+		"administrator": administrator,
+		"invitation":    supervisorInvitation,
+	})
+}
+
+func handleAcceptSupervisorInvitation(context *gin.Context) {
+	supervisorInvitationToken, errParseSupervisorInvitationToken := uuid.Parse(context.Param("token"))
+	if errParseSupervisorInvitationToken != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed invitation token", errParseSupervisorInvitationToken)
+		return
+	}
+
+	var payload payloads.AcceptSupervisorInvitation
+	errBindPayload := context.ShouldBind(&payload)
+	if errBindPayload != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed accept invitation data", errBindPayload)
+		return
+	}
+
+	errAcceptSupervisorInvitation := samuel.AcceptSupervisorInvitation(context, supervisorInvitationToken, payload.NewPassword)
+	if errAcceptSupervisorInvitation != nil {
+		switch {
+		case errors.Is(errAcceptSupervisorInvitation, sql.ErrNoRows):
+			respondAPIError(context, http.StatusNotFound, "invitation not found", errAcceptSupervisorInvitation)
+		case errors.Is(errAcceptSupervisorInvitation, samuel.ErrSupervisorInvitationExpired):
+			respondAPIError(context, http.StatusGone, "invitation expired", errAcceptSupervisorInvitation)
+		case errors.Is(errAcceptSupervisorInvitation, samuel.ErrSupervisorInvitationAccepted):
+			respondAPIError(context, http.StatusConflict, "invitation already accepted", errAcceptSupervisorInvitation)
+		default:
+			respondAPIError(context, http.StatusInternalServerError, "cannot accept invitation", errAcceptSupervisorInvitation)
+		}
+		return
+	}
+
+	respondAPISuccess(context, http.StatusOK, nil)
+}
@@ -0,0 +1,207 @@
+package server
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sorucoder/samuel/internal/payloads"
+	"github.com/sorucoder/samuel/internal/samuel"
+)
+
+func handleSubmitSupervisorReport(context *gin.Context) {
+	user := context.MustGet("user").(*samuel.User)
+	session := context.MustGet("session").(*samuel.Session)
+
+	internshipUUID, errParseInternshipUUID := uuid.Parse(context.Param("internship"))
+	if errParseInternshipUUID != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed internship uuid", errParseInternshipUUID)
+		return
+	}
+
+	var weekOf payloads.Date
+	errParseWeekOf := weekOf.UnmarshalParam(context.Param("week"))
+	if errParseWeekOf != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed week", errParseWeekOf)
+		return
+	}
+
+	var payload payloads.SubmitSupervisorReport
+	errBindPayload := context.ShouldBind(&payload)
+	if errBindPayload != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed supervisor report submit data", errBindPayload)
+		return
+	}
+
+	supervisorReport, errSubmitSupervisorReport := samuel.SubmitSupervisorReport(context, user, internshipUUID, weekOf.Time, payload.Content())
+	if errSubmitSupervisorReport != nil {
+		var validationError *samuel.ValidationError
+		switch {
+		case errors.As(errSubmitSupervisorReport, &validationError):
+			respondAPIValidationError(context, "invalid supervisor report", validationError)
+		case errors.Is(errSubmitSupervisorReport, sql.ErrNoRows):
+			respondAPIError(context, http.StatusNotFound, "internship not found", errSubmitSupervisorReport)
+		case errors.Is(errSubmitSupervisorReport, samuel.ErrUserNotSupervisor),
+			errors.Is(errSubmitSupervisorReport, samuel.ErrSupervisorReportNotSupervisor):
+			respondAPIError(context, http.StatusForbidden, "cannot submit supervisor report", errSubmitSupervisorReport)
+		case errors.Is(errSubmitSupervisorReport, samuel.ErrInternshipClosed),
+			errors.Is(errSubmitSupervisorReport, samuel.ErrSupervisorReportExists):
+			respondAPIError(context, http.StatusConflict, "supervisor report not submittable", errSubmitSupervisorReport)
+		default:
+			respondAPIError(context, http.StatusInternalServerError, "cannot submit supervisor report", errSubmitSupervisorReport)
+		}
+		return
+	}
+
+	respondAPISuccess(context, http.StatusCreated, map[string]any{
+		"user":             user,
+		"session":          session,
+		"supervisorReport": supervisorReport,
+	})
+}
+
+func handleListSupervisorReports(context *gin.Context) {
+	user := context.MustGet("user").(*samuel.User)
+	session := context.MustGet("session").(*samuel.Session)
+
+	internshipUUID, errParseInternshipUUID := uuid.Parse(context.Param("internship"))
+	if errParseInternshipUUID != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed internship uuid", errParseInternshipUUID)
+		return
+	}
+
+	var payload payloads.ListSupervisorReports
+	errBindPayload := context.ShouldBind(&payload)
+	if errBindPayload != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed supervisor report list data", errBindPayload)
+		return
+	}
+
+	supervisorReportBatch, errGetSupervisorReportBatch := samuel.GetSupervisorReportBatchByInternship(context, user, internshipUUID, payload.Page, payload.Count)
+	if errGetSupervisorReportBatch != nil {
+		switch {
+		case errors.Is(errGetSupervisorReportBatch, sql.ErrNoRows):
+			respondAPIError(context, http.StatusNotFound, "internship not found", errGetSupervisorReportBatch)
+		case errors.Is(errGetSupervisorReportBatch, samuel.ErrInternshipNotInvolved):
+			respondAPIError(context, http.StatusForbidden, "cannot view supervisor reports", errGetSupervisorReportBatch)
+		default:
+			respondAPIError(context, http.StatusInternalServerError, "cannot get supervisor reports", errGetSupervisorReportBatch)
+		}
+		return
+	}
+
+	respondAPISuccess(context, http.StatusOK, map[string]any{
+		"user":    user,
+		"session": session,
+		"batch":   supervisorReportBatch,
+	})
+}
+
+func handleViewSupervisorReport(context *gin.Context) {
+	user := context.MustGet("user").(*samuel.User)
+	session := context.MustGet("session").(*samuel.Session)
+
+	internshipUUID, errParseInternshipUUID := uuid.Parse(context.Param("internship"))
+	if errParseInternshipUUID != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed internship uuid", errParseInternshipUUID)
+		return
+	}
+
+	var weekOf payloads.Date
+	errParseWeekOf := weekOf.UnmarshalParam(context.Param("week"))
+	if errParseWeekOf != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed week", errParseWeekOf)
+		return
+	}
+
+	supervisorReport, errGetSupervisorReport := samuel.GetSupervisorReport(context, user, internshipUUID, weekOf.Time)
+	if errGetSupervisorReport != nil {
+		switch {
+		case errors.Is(errGetSupervisorReport, sql.ErrNoRows):
+			respondAPIError(context, http.StatusNotFound, "supervisor report not found", errGetSupervisorReport)
+		case errors.Is(errGetSupervisorReport, samuel.ErrInternshipNotInvolved),
+			errors.Is(errGetSupervisorReport, samuel.ErrSupervisorReportNotVisible):
+			respondAPIError(context, http.StatusForbidden, "cannot view supervisor report", errGetSupervisorReport)
+		default:
+			respondAPIError(context, http.StatusInternalServerError, "cannot get supervisor report", errGetSupervisorReport)
+		}
+		return
+	}
+
+	respondAPISuccess(context, http.StatusOK, map[string]any{
+		"user":             user,
+		"session":          session,
+		"supervisorReport": supervisorReport,
+	})
+}
+
+func handleListPhoneCallRequests(context *gin.Context) {
+	user := context.MustGet("user").(*samuel.User)
+	session := context.MustGet("session").(*samuel.Session)
+
+	var payload payloads.ListSupervisorReports
+	errBindPayload := context.ShouldBind(&payload)
+	if errBindPayload != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed phone call request list data", errBindPayload)
+		return
+	}
+
+	supervisorReportBatch, errGetSupervisorReportBatch := samuel.GetOpenPhoneCallSupervisorReportBatch(context, user, payload.Page, payload.Count)
+	if errGetSupervisorReportBatch != nil {
+		if errors.Is(errGetSupervisorReportBatch, samuel.ErrUserNotInstructor) {
+			respondAPIError(context, http.StatusForbidden, "cannot view phone call requests", errGetSupervisorReportBatch)
+		} else {
+			respondAPIError(context, http.StatusInternalServerError, "cannot get phone call requests", errGetSupervisorReportBatch)
+		}
+		return
+	}
+
+	respondAPISuccess(context, http.StatusOK, map[string]any{
+		"user":    user,
+		"session": session,
+		"batch":   supervisorReportBatch,
+	})
+}
+
+func handleResolvePhoneCallRequest(context *gin.Context) {
+	user := context.MustGet("user").(*samuel.User)
+	session := context.MustGet("session").(*samuel.Session)
+
+	internshipUUID, errParseInternshipUUID := uuid.Parse(context.Param("internship"))
+	if errParseInternshipUUID != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed internship uuid", errParseInternshipUUID)
+		return
+	}
+
+	var weekOf payloads.Date
+	errParseWeekOf := weekOf.UnmarshalParam(context.Param("week"))
+	if errParseWeekOf != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed week", errParseWeekOf)
+		return
+	}
+
+	supervisorReport, errResolve := samuel.ResolveSupervisorReportPhoneCall(context, user, internshipUUID, weekOf.Time)
+	if errResolve != nil {
+		switch {
+		case errors.Is(errResolve, sql.ErrNoRows):
+			respondAPIError(context, http.StatusNotFound, "supervisor report not found", errResolve)
+		case errors.Is(errResolve, samuel.ErrUserNotInstructor),
+			errors.Is(errResolve, samuel.ErrSupervisorReportNotInstructor):
+			respondAPIError(context, http.StatusForbidden, "cannot resolve phone call request", errResolve)
+		case errors.Is(errResolve, samuel.ErrSupervisorReportNoPhoneCall),
+			errors.Is(errResolve, samuel.ErrSupervisorReportCallResolved):
+			respondAPIError(context, http.StatusConflict, "phone call request not open", errResolve)
+		default:
+			respondAPIError(context, http.StatusInternalServerError, "cannot resolve phone call request", errResolve)
+		}
+		return
+	}
+
+	respondAPISuccess(context, http.StatusOK, map[string]any{
+		"user":             user,
+		"session":          session,
+		"supervisorReport": supervisorReport,
+	})
+}
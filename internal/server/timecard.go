@@ -0,0 +1,270 @@
+package server
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sorucoder/samuel/internal/payloads"
+	"github.com/sorucoder/samuel/internal/samuel"
+)
+
+func handleListTimecards(context *gin.Context) {
+	user := context.MustGet("user").(*samuel.User)
+	session := context.MustGet("session").(*samuel.Session)
+
+	internshipUUID, errParseInternshipUUID := uuid.Parse(context.Param("internship"))
+	if errParseInternshipUUID != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed internship uuid", errParseInternshipUUID)
+		return
+	}
+
+	var payload payloads.ListTimecards
+	errBindPayload := context.ShouldBind(&payload)
+	if errBindPayload != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed timecard list data", errBindPayload)
+		return
+	}
+
+	timecardBatch, errGetTimecardBatch := samuel.GetTimecardBatchByInternship(context, user, internshipUUID, payload.Page, payload.Count)
+	if errGetTimecardBatch != nil {
+		switch {
+		case errors.Is(errGetTimecardBatch, sql.ErrNoRows):
+			respondAPIError(context, http.StatusNotFound, "internship not found", errGetTimecardBatch)
+		case errors.Is(errGetTimecardBatch, samuel.ErrInternshipNotInvolved):
+			respondAPIError(context, http.StatusForbidden, "cannot view timecards", errGetTimecardBatch)
+		default:
+			respondAPIError(context, http.StatusInternalServerError, "cannot get timecards", errGetTimecardBatch)
+		}
+		return
+	}
+
+	respondAPISuccess(context, http.StatusOK, map[string]any{
+		"user":    user,
+		"session": session,
+		"batch":   timecardBatch,
+	})
+}
+
+func handleViewTimecard(context *gin.Context) {
+	user := context.MustGet("user").(*samuel.User)
+	session := context.MustGet("session").(*samuel.Session)
+
+	internshipUUID, errParseInternshipUUID := uuid.Parse(context.Param("internship"))
+	if errParseInternshipUUID != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed internship uuid", errParseInternshipUUID)
+		return
+	}
+
+	var weekOf payloads.Date
+	errParseWeekOf := weekOf.UnmarshalParam(context.Param("week"))
+	if errParseWeekOf != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed week", errParseWeekOf)
+		return
+	}
+
+	timecard, errGetTimecard := samuel.GetTimecard(context, user, internshipUUID, weekOf.Time)
+	if errGetTimecard != nil {
+		switch {
+		case errors.Is(errGetTimecard, sql.ErrNoRows):
+			respondAPIError(context, http.StatusNotFound, "timecard not found", errGetTimecard)
+		case errors.Is(errGetTimecard, samuel.ErrInternshipNotInvolved):
+			respondAPIError(context, http.StatusForbidden, "cannot view timecard", errGetTimecard)
+		default:
+			respondAPIError(context, http.StatusInternalServerError, "cannot get timecard", errGetTimecard)
+		}
+		return
+	}
+
+	respondAPISuccess(context, http.StatusOK, map[string]any{
+		"user":     user,
+		"session":  session,
+		"timecard": timecard,
+	})
+}
+
+func handleDraftTimecard(context *gin.Context) {
+	user := context.MustGet("user").(*samuel.User)
+	session := context.MustGet("session").(*samuel.Session)
+
+	internshipUUID, errParseInternshipUUID := uuid.Parse(context.Param("internship"))
+	if errParseInternshipUUID != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed internship uuid", errParseInternshipUUID)
+		return
+	}
+
+	var weekOf payloads.Date
+	errParseWeekOf := weekOf.UnmarshalParam(context.Param("week"))
+	if errParseWeekOf != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed week", errParseWeekOf)
+		return
+	}
+
+	var payload payloads.DraftTimecard
+	errBindPayload := context.ShouldBind(&payload)
+	if errBindPayload != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed timecard draft data", errBindPayload)
+		return
+	}
+
+	timecard, errDraftTimecard := samuel.DraftTimecard(context, user, internshipUUID, weekOf.Time, payload.Hours())
+	if errDraftTimecard != nil {
+		var validationError *samuel.ValidationError
+		switch {
+		case errors.As(errDraftTimecard, &validationError):
+			respondAPIValidationError(context, "invalid timecard", validationError)
+		case errors.Is(errDraftTimecard, sql.ErrNoRows):
+			respondAPIError(context, http.StatusNotFound, "internship not found", errDraftTimecard)
+		case errors.Is(errDraftTimecard, samuel.ErrUserNotStudent),
+			errors.Is(errDraftTimecard, samuel.ErrTimecardNotStudent):
+			respondAPIError(context, http.StatusForbidden, "cannot draft timecard", errDraftTimecard)
+		case errors.Is(errDraftTimecard, samuel.ErrInternshipClosed),
+			errors.Is(errDraftTimecard, samuel.ErrTimecardNotEditable):
+			respondAPIError(context, http.StatusConflict, "timecard not editable", errDraftTimecard)
+		default:
+			respondAPIError(context, http.StatusInternalServerError, "cannot draft timecard", errDraftTimecard)
+		}
+		return
+	}
+
+	respondAPISuccess(context, http.StatusOK, map[string]any{
+		"user":     user,
+		"session":  session,
+		"timecard": timecard,
+	})
+}
+
+func handleSubmitTimecard(context *gin.Context) {
+	user := context.MustGet("user").(*samuel.User)
+	session := context.MustGet("session").(*samuel.Session)
+
+	internshipUUID, errParseInternshipUUID := uuid.Parse(context.Param("internship"))
+	if errParseInternshipUUID != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed internship uuid", errParseInternshipUUID)
+		return
+	}
+
+	var weekOf payloads.Date
+	errParseWeekOf := weekOf.UnmarshalParam(context.Param("week"))
+	if errParseWeekOf != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed week", errParseWeekOf)
+		return
+	}
+
+	timecard, errSubmitTimecard := samuel.SubmitTimecard(context, user, internshipUUID, weekOf.Time)
+	if errSubmitTimecard != nil {
+		var validationError *samuel.ValidationError
+		switch {
+		case errors.As(errSubmitTimecard, &validationError):
+			respondAPIValidationError(context, "invalid timecard", validationError)
+		case errors.Is(errSubmitTimecard, sql.ErrNoRows):
+			respondAPIError(context, http.StatusNotFound, "timecard not found", errSubmitTimecard)
+		case errors.Is(errSubmitTimecard, samuel.ErrUserNotStudent),
+			errors.Is(errSubmitTimecard, samuel.ErrTimecardNotStudent):
+			respondAPIError(context, http.StatusForbidden, "cannot submit timecard", errSubmitTimecard)
+		case errors.Is(errSubmitTimecard, samuel.ErrInternshipClosed),
+			errors.Is(errSubmitTimecard, samuel.ErrTimecardNotDraft):
+			respondAPIError(context, http.StatusConflict, "timecard not submittable", errSubmitTimecard)
+		default:
+			respondAPIError(context, http.StatusInternalServerError, "cannot submit timecard", errSubmitTimecard)
+		}
+		return
+	}
+
+	respondAPISuccess(context, http.StatusOK, map[string]any{
+		"user":     user,
+		"session":  session,
+		"timecard": timecard,
+	})
+}
+
+func handleApproveTimecard(context *gin.Context) {
+	user := context.MustGet("user").(*samuel.User)
+	session := context.MustGet("session").(*samuel.Session)
+
+	internshipUUID, errParseInternshipUUID := uuid.Parse(context.Param("internship"))
+	if errParseInternshipUUID != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed internship uuid", errParseInternshipUUID)
+		return
+	}
+
+	var weekOf payloads.Date
+	errParseWeekOf := weekOf.UnmarshalParam(context.Param("week"))
+	if errParseWeekOf != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed week", errParseWeekOf)
+		return
+	}
+
+	timecard, errApproveTimecard := samuel.ApproveTimecard(context, user, internshipUUID, weekOf.Time)
+	if errApproveTimecard != nil {
+		switch {
+		case errors.Is(errApproveTimecard, sql.ErrNoRows):
+			respondAPIError(context, http.StatusNotFound, "timecard not found", errApproveTimecard)
+		case errors.Is(errApproveTimecard, samuel.ErrUserNotSupervisor),
+			errors.Is(errApproveTimecard, samuel.ErrTimecardNotSupervisor):
+			respondAPIError(context, http.StatusForbidden, "cannot approve timecard", errApproveTimecard)
+		case errors.Is(errApproveTimecard, samuel.ErrTimecardNotSubmitted):
+			respondAPIError(context, http.StatusConflict, "timecard not submitted", errApproveTimecard)
+		default:
+			respondAPIError(context, http.StatusInternalServerError, "cannot approve timecard", errApproveTimecard)
+		}
+		return
+	}
+
+	respondAPISuccess(context, http.StatusOK, map[string]any{
+		"user":     user,
+		"session":  session,
+		"timecard": timecard,
+	})
+}
+
+func handleDenyTimecard(context *gin.Context) {
+	user := context.MustGet("user").(*samuel.User)
+	session := context.MustGet("session").(*samuel.Session)
+
+	internshipUUID, errParseInternshipUUID := uuid.Parse(context.Param("internship"))
+	if errParseInternshipUUID != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed internship uuid", errParseInternshipUUID)
+		return
+	}
+
+	var weekOf payloads.Date
+	errParseWeekOf := weekOf.UnmarshalParam(context.Param("week"))
+	if errParseWeekOf != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed week", errParseWeekOf)
+		return
+	}
+
+	var payload payloads.DenyTimecard
+	errBindPayload := context.ShouldBind(&payload)
+	if errBindPayload != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed timecard deny data", errBindPayload)
+		return
+	}
+
+	timecard, errDenyTimecard := samuel.DenyTimecard(context, user, internshipUUID, weekOf.Time, payload.Reason)
+	if errDenyTimecard != nil {
+		switch {
+		case errors.Is(errDenyTimecard, sql.ErrNoRows):
+			respondAPIError(context, http.StatusNotFound, "timecard not found", errDenyTimecard)
+		case errors.Is(errDenyTimecard, samuel.ErrUserNotSupervisor),
+			errors.Is(errDenyTimecard, samuel.ErrTimecardNotSupervisor):
+			respondAPIError(context, http.StatusForbidden, "cannot deny timecard", errDenyTimecard)
+		case errors.Is(errDenyTimecard, samuel.ErrTimecardReasonMissing):
+			respondAPIError(context, http.StatusBadRequest, "missing denial reason", errDenyTimecard)
+		case errors.Is(errDenyTimecard, samuel.ErrTimecardNotSubmitted):
+			respondAPIError(context, http.StatusConflict, "timecard not submitted", errDenyTimecard)
+		default:
+			respondAPIError(context, http.StatusInternalServerError, "cannot deny timecard", errDenyTimecard)
+		}
+		return
+	}
+
+	respondAPISuccess(context, http.StatusOK, map[string]any{
+		"user":     user,
+		"session":  session,
+		"timecard": timecard,
+	})
+}
@@ -0,0 +1,242 @@
+package server
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sorucoder/samuel/internal/payloads"
+	"github.com/sorucoder/samuel/internal/samuel"
+)
+
+func handleBeginTwoFactorEnrollment(context *gin.Context) {
+	user := context.MustGet("user").(*samuel.User)
+	session := context.MustGet("session").(*samuel.Session)
+
+	enrollment, errBeginEnrollment := samuel.BeginTwoFactorEnrollment(context, user)
+	if errBeginEnrollment != nil {
+		switch {
+		case errors.Is(errBeginEnrollment, samuel.ErrUserNotSupervisor):
+			respondAPIError(context, http.StatusForbidden, "cannot enroll in two-factor authentication", errBeginEnrollment)
+		case errors.Is(errBeginEnrollment, samuel.ErrTwoFactorEnrolled):
+			respondAPIError(context, http.StatusConflict, "two-factor authentication already enrolled", errBeginEnrollment)
+		default:
+			respondAPIError(context, http.StatusInternalServerError, "cannot begin two-factor enrollment", errBeginEnrollment)
+		}
+		return
+	}
+
+	respondAPISuccess(context, http.StatusCreated, map[string]any{
+		"user":       user,
+		"session":    session,
+		"enrollment": enrollment,
+	})
+}
+
+func handleConfirmTwoFactorEnrollment(context *gin.Context) {
+	user := context.MustGet("user").(*samuel.User)
+	session := context.MustGet("session").(*samuel.Session)
+
+	var payload payloads.VerifyTwoFactorCode
+	errBindPayload := context.ShouldBind(&payload)
+	if errBindPayload != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed two-factor confirmation data", errBindPayload)
+		return
+	}
+
+	enrollment, errConfirmEnrollment := samuel.ConfirmTwoFactorEnrollment(context, user, payload.Code)
+	if errConfirmEnrollment != nil {
+		switch {
+		case errors.Is(errConfirmEnrollment, samuel.ErrUserNotSupervisor):
+			respondAPIError(context, http.StatusForbidden, "cannot enroll in two-factor authentication", errConfirmEnrollment)
+		case errors.Is(errConfirmEnrollment, samuel.ErrTwoFactorNotEnrolled):
+			respondAPIError(context, http.StatusConflict, "two-factor enrollment not started", errConfirmEnrollment)
+		case errors.Is(errConfirmEnrollment, samuel.ErrTwoFactorEnrolled):
+			respondAPIError(context, http.StatusConflict, "two-factor authentication already enrolled", errConfirmEnrollment)
+		case errors.Is(errConfirmEnrollment, samuel.ErrTwoFactorCodeInvalid):
+			respondAPIError(context, http.StatusUnprocessableEntity, "invalid two-factor code", errConfirmEnrollment)
+		default:
+			respondAPIError(context, http.StatusInternalServerError, "cannot confirm two-factor enrollment", errConfirmEnrollment)
+		}
+		return
+	}
+
+	respondAPISuccess(context, http.StatusOK, map[string]any{
+		"user":       user,
+		"session":    session,
+		"enrollment": enrollment,
+	})
+}
+
+func handleDisableTwoFactor(context *gin.Context) {
+	user := context.MustGet("user").(*samuel.User)
+
+	var payload payloads.VerifyTwoFactorCode
+	errBindPayload := context.ShouldBind(&payload)
+	if errBindPayload != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed two-factor disable data", errBindPayload)
+		return
+	}
+
+	errDisable := samuel.DisableTwoFactor(context, user, payload.Code)
+	if errDisable != nil {
+		switch {
+		case errors.Is(errDisable, samuel.ErrUserNotSupervisor):
+			respondAPIError(context, http.StatusForbidden, "cannot disable two-factor authentication", errDisable)
+		case errors.Is(errDisable, samuel.ErrTwoFactorRequired):
+			respondAPIError(context, http.StatusForbidden, "two-factor authentication required by company", errDisable)
+		case errors.Is(errDisable, samuel.ErrTwoFactorNotEnrolled):
+			respondAPIError(context, http.StatusConflict, "two-factor authentication not enrolled", errDisable)
+		case errors.Is(errDisable, samuel.ErrTwoFactorCodeInvalid):
+			respondAPIError(context, http.StatusUnprocessableEntity, "invalid two-factor code", errDisable)
+		default:
+			respondAPIError(context, http.StatusInternalServerError, "cannot disable two-factor authentication", errDisable)
+		}
+		return
+	}
+
+	respondAPISuccess(context, http.StatusOK, nil)
+}
+
+func handleBeginChallengedTwoFactorEnrollment(context *gin.Context) {
+	challengeToken, errParseChallengeToken := uuid.Parse(context.Param("token"))
+	if errParseChallengeToken != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed two-factor challenge token", errParseChallengeToken)
+		return
+	}
+
+	enrollment, errBeginEnrollment := samuel.BeginChallengedTwoFactorEnrollment(context, challengeToken)
+	if errBeginEnrollment != nil {
+		switch {
+		case errors.Is(errBeginEnrollment, sql.ErrNoRows), errors.Is(errBeginEnrollment, samuel.ErrTwoFactorChallengeInvalid):
+			respondAPIError(context, http.StatusUnauthorized, "invalid two-factor challenge", errBeginEnrollment)
+		default:
+			respondAPIError(context, http.StatusInternalServerError, "cannot begin two-factor enrollment", errBeginEnrollment)
+		}
+		return
+	}
+
+	respondAPISuccess(context, http.StatusCreated, map[string]any{
+		"enrollment": enrollment,
+	})
+}
+
+func handleVerifyTwoFactorChallenge(context *gin.Context) {
+	challengeToken, errParseChallengeToken := uuid.Parse(context.Param("token"))
+	if errParseChallengeToken != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed two-factor challenge token", errParseChallengeToken)
+		return
+	}
+
+	var payload payloads.VerifyTwoFactorCode
+	errBindPayload := context.ShouldBind(&payload)
+	if errBindPayload != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed two-factor verification data", errBindPayload)
+		return
+	}
+
+	user, session, enrollment, errVerify := samuel.VerifyTwoFactorChallenge(context, challengeToken, payload.Code, context.ClientIP())
+	if errVerify != nil {
+		var loginThrottledError *samuel.LoginThrottledError
+		switch {
+		case errors.As(errVerify, &loginThrottledError):
+			respondAPIThrottledError(context, loginThrottledError)
+		case errors.Is(errVerify, sql.ErrNoRows), errors.Is(errVerify, samuel.ErrTwoFactorChallengeExpired):
+			respondAPIError(context, http.StatusUnauthorized, "invalid two-factor challenge", errVerify)
+		case errors.Is(errVerify, samuel.ErrTwoFactorNotEnrolled):
+			respondAPIError(context, http.StatusConflict, "two-factor enrollment not started", errVerify)
+		case errors.Is(errVerify, samuel.ErrTwoFactorCodeInvalid):
+			respondAPIError(context, http.StatusUnauthorized, "invalid two-factor code", errVerify)
+		default:
+			respondAPIError(context, http.StatusInternalServerError, "cannot verify two-factor code", errVerify)
+		}
+		return
+	}
+
+	response := map[string]any{
+		"user":    user,
+		"session": session,
+	}
+	if enrollment != nil {
+		response["enrollment"] = enrollment
+	}
+
+	respondAPISuccess(context, http.StatusOK, response)
+}
+
+func handleRequireCompanyTwoFactor(context *gin.Context) {
+	user := context.MustGet("user").(*samuel.User)
+	session := context.MustGet("session").(*samuel.Session)
+	administrator := context.MustGet("administrator").(*samuel.Administrator)
+
+	companyUUID, errParseCompanyUUID := uuid.Parse(context.Param("uuid"))
+	if errParseCompanyUUID != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed company uuid", errParseCompanyUUID)
+		return
+	}
+
+	var payload payloads.RequireCompanyTwoFactor
+	errBindPayload := context.ShouldBind(&payload)
+	if errBindPayload != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed company two-factor data", errBindPayload)
+		return
+	}
+
+	company, errSetRequired := samuel.SetCompanyTwoFactorRequired(context, user, companyUUID, payload.Required)
+	if errSetRequired != nil {
+		switch {
+		case errors.Is(errSetRequired, sql.ErrNoRows):
+			respondAPIError(context, http.StatusNotFound, "company not found", errSetRequired)
+		case errors.Is(errSetRequired, samuel.ErrUserNotAdministrator):
+			respondAPIError(context, http.StatusForbidden, "cannot update company", errSetRequired)
+		default:
+			respondAPIError(context, http.StatusInternalServerError, "cannot update company", errSetRequired)
+		}
+		return
+	}
+
+	respondAPISuccess(context, http.StatusOK, map[string]any{
+		"user":          user,
+		"session":       session,
+		"administrator": administrator,
+		"company":       company,
+	})
+}
+
+func handleResetSupervisorTwoFactor(context *gin.Context) {
+	user := context.MustGet("user").(*samuel.User)
+	session := context.MustGet("session").(*samuel.Session)
+	administrator := context.MustGet("administrator").(*samuel.Administrator)
+
+	userUUID, errParseUserUUID := uuid.Parse(context.Param("uuid"))
+	if errParseUserUUID != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed user uuid", errParseUserUUID)
+		return
+	}
+
+	resetUser, errReset := samuel.ResetSupervisorTwoFactor(context, user, userUUID)
+	if errReset != nil {
+		switch {
+		case errors.Is(errReset, sql.ErrNoRows):
+			respondAPIError(context, http.StatusNotFound, "user not found", errReset)
+		case errors.Is(errReset, samuel.ErrUserNotAdministrator):
+			respondAPIError(context, http.StatusForbidden, "cannot reset two-factor authentication", errReset)
+		case errors.Is(errReset, samuel.ErrUserNotSupervisor):
+			respondAPIError(context, http.StatusBadRequest, "user is not a supervisor", errReset)
+		case errors.Is(errReset, samuel.ErrTwoFactorNotEnrolled):
+			respondAPIError(context, http.StatusConflict, "two-factor authentication not enrolled", errReset)
+		default:
+			respondAPIError(context, http.StatusInternalServerError, "cannot reset two-factor authentication", errReset)
+		}
+		return
+	}
+
+	respondAPISuccess(context, http.StatusOK, map[string]any{
+		"user":          user,
+		"session":       session,
+		"administrator": administrator,
+		"resetUser":     resetUser,
+	})
+}
@@ -0,0 +1,225 @@
+package server
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sorucoder/samuel/internal/payloads"
+	"github.com/sorucoder/samuel/internal/samuel"
+)
+
+func handleProvisionUser(context *gin.Context) {
+	user := context.MustGet("user").(*samuel.User)
+	session := context.MustGet("session").(*samuel.Session)
+	administrator := context.MustGet("administrator").(*samuel.Administrator)
+
+	var payload payloads.ProvisionUser
+	errBindPayload := context.ShouldBind(&payload)
+	if errBindPayload != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed user provision data", errBindPayload)
+		return
+	}
+
+	provisionedUser, errProvisionUser := samuel.ProvisionUser(context, user, payload.Identity, payload.Provider, payload.RoleID, payload.Content())
+	if errProvisionUser != nil {
+		var validationError *samuel.ValidationError
+		switch {
+		case errors.As(errProvisionUser, &validationError):
+			respondAPIValidationError(context, "invalid user", validationError)
+		case errors.Is(errProvisionUser, samuel.ErrUserExists):
+			respondAPIError(context, http.StatusConflict, "user already exists", errProvisionUser)
+		default:
+			respondAPIError(context, http.StatusInternalServerError, "cannot provision user", errProvisionUser)
+		}
+		return
+	}
+
+	respondAPISuccess(context, http.StatusCreated, map[string]any{
+		"user":            user,
+		"session":         session,
+		"administrator":   administrator,
+		"provisionedUser": provisionedUser,
+	})
+}
+
+func handleImportStudents(context *gin.Context) {
+	user := context.MustGet("user").(*samuel.User)
+	session := context.MustGet("session").(*samuel.Session)
+	administrator := context.MustGet("administrator").(*samuel.Administrator)
+
+	var payload payloads.ImportStudents
+	errBindPayload := context.ShouldBind(&payload)
+	if errBindPayload != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed student import data", errBindPayload)
+		return
+	}
+
+	fileHeader, errGetFile := context.FormFile("file")
+	if errGetFile != nil {
+		respondAPIError(context, http.StatusBadRequest, "missing student import file", errGetFile)
+		return
+	}
+
+	file, errOpenFile := fileHeader.Open()
+	if errOpenFile != nil {
+		respondAPIError(context, http.StatusBadRequest, "cannot open student import file", errOpenFile)
+		return
+	}
+	defer file.Close()
+
+	studentImport, errImportStudents := samuel.ImportStudents(context, user, file, payload.Commit)
+	if errImportStudents != nil {
+		switch {
+		case errors.Is(errImportStudents, samuel.ErrUserNotAdministrator):
+			respondAPIError(context, http.StatusForbidden, "cannot import students", errImportStudents)
+		case errors.Is(errImportStudents, samuel.ErrStudentImportMalformed):
+			respondAPIError(context, http.StatusBadRequest, "malformed student import file", errImportStudents)
+		default:
+			respondAPIError(context, http.StatusInternalServerError, "cannot import students", errImportStudents)
+		}
+		return
+	}
+
+	status := http.StatusOK
+	if payload.Commit {
+		status = http.StatusCreated
+	}
+
+	respondAPISuccess(context, status, map[string]any{
+		"user":          user,
+		"session":       session,
+		"administrator": administrator,
+		"import":        studentImport,
+	})
+}
+
+func handleSwitchRole(context *gin.Context) {
+	user := context.MustGet("user").(*samuel.User)
+	session := context.MustGet("session").(*samuel.Session)
+
+	switchedUser, errSwitchActiveRole := samuel.SwitchActiveRole(context, user, session, context.Param("role"))
+	if errSwitchActiveRole != nil {
+		switch {
+		case errors.Is(errSwitchActiveRole, samuel.ErrUserRoleNotHeld):
+			respondAPIError(context, http.StatusForbidden, "role not held", errSwitchActiveRole)
+		default:
+			respondAPIError(context, http.StatusInternalServerError, "cannot switch role", errSwitchActiveRole)
+		}
+		return
+	}
+
+	respondAPISuccess(context, http.StatusOK, map[string]any{
+		"user":    switchedUser,
+		"session": session,
+	})
+}
+
+func handleGrantUserRole(context *gin.Context) {
+	user := context.MustGet("user").(*samuel.User)
+	session := context.MustGet("session").(*samuel.Session)
+	administrator := context.MustGet("administrator").(*samuel.Administrator)
+
+	userUUID, errParseUserUUID := uuid.Parse(context.Param("uuid"))
+	if errParseUserUUID != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed user uuid", errParseUserUUID)
+		return
+	}
+
+	updatedUser, errGrantUserRole := samuel.GrantUserRole(context, user, userUUID, context.Param("role"))
+	if errGrantUserRole != nil {
+		switch {
+		case errors.Is(errGrantUserRole, sql.ErrNoRows):
+			respondAPIError(context, http.StatusNotFound, "user or role not found", errGrantUserRole)
+		case errors.Is(errGrantUserRole, samuel.ErrUserNotAdministrator):
+			respondAPIError(context, http.StatusForbidden, "cannot grant role", errGrantUserRole)
+		case errors.Is(errGrantUserRole, samuel.ErrUserRoleHeld):
+			respondAPIError(context, http.StatusConflict, "role already held", errGrantUserRole)
+		case errors.Is(errGrantUserRole, samuel.ErrUserRoleIncompatible),
+			errors.Is(errGrantUserRole, samuel.ErrUserProfileMissing):
+			respondAPIError(context, http.StatusUnprocessableEntity, "cannot grant role to user", errGrantUserRole)
+		default:
+			respondAPIError(context, http.StatusInternalServerError, "cannot grant role", errGrantUserRole)
+		}
+		return
+	}
+
+	respondAPISuccess(context, http.StatusOK, map[string]any{
+		"user":          user,
+		"session":       session,
+		"administrator": administrator,
+		"updatedUser":   updatedUser,
+	})
+}
+
+func handleRevokeUserRole(context *gin.Context) {
+	user := context.MustGet("user").(*samuel.User)
+	session := context.MustGet("session").(*samuel.Session)
+	administrator := context.MustGet("administrator").(*samuel.Administrator)
+
+	userUUID, errParseUserUUID := uuid.Parse(context.Param("uuid"))
+	if errParseUserUUID != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed user uuid", errParseUserUUID)
+		return
+	}
+
+	updatedUser, errRevokeUserRole := samuel.RevokeUserRole(context, user, userUUID, context.Param("role"))
+	if errRevokeUserRole != nil {
+		switch {
+		case errors.Is(errRevokeUserRole, sql.ErrNoRows):
+			respondAPIError(context, http.StatusNotFound, "user not found", errRevokeUserRole)
+		case errors.Is(errRevokeUserRole, samuel.ErrUserNotAdministrator):
+			respondAPIError(context, http.StatusForbidden, "cannot revoke role", errRevokeUserRole)
+		case errors.Is(errRevokeUserRole, samuel.ErrUserRoleNotHeld):
+			respondAPIError(context, http.StatusNotFound, "role not held", errRevokeUserRole)
+		case errors.Is(errRevokeUserRole, samuel.ErrUserRolePrimary):
+			respondAPIError(context, http.StatusConflict, "cannot revoke primary role", errRevokeUserRole)
+		default:
+			respondAPIError(context, http.StatusInternalServerError, "cannot revoke role", errRevokeUserRole)
+		}
+		return
+	}
+
+	respondAPISuccess(context, http.StatusOK, map[string]any{
+		"user":          user,
+		"session":       session,
+		"administrator": administrator,
+		"updatedUser":   updatedUser,
+	})
+}
+
+func handleUnlockUser(context *gin.Context) {
+	user := context.MustGet("user").(*samuel.User)
+	session := context.MustGet("session").(*samuel.Session)
+	administrator := context.MustGet("administrator").(*samuel.Administrator)
+
+	userUUID, errParseUserUUID := uuid.Parse(context.Param("uuid"))
+	if errParseUserUUID != nil {
+		respondAPIError(context, http.StatusBadRequest, "malformed user uuid", errParseUserUUID)
+		return
+	}
+
+	unlockedUser, errUnlockUser := samuel.UnlockUser(context, user, userUUID)
+	if errUnlockUser != nil {
+		switch {
+		case errors.Is(errUnlockUser, sql.ErrNoRows):
+			respondAPIError(context, http.StatusNotFound, "user not found", errUnlockUser)
+		case errors.Is(errUnlockUser, samuel.ErrUserNotAdministrator):
+			respondAPIError(context, http.StatusForbidden, "cannot unlock user", errUnlockUser)
+		case errors.Is(errUnlockUser, samuel.ErrUserNotLocked):
+			respondAPIError(context, http.StatusConflict, "user not locked", errUnlockUser)
+		default:
+			respondAPIError(context, http.StatusInternalServerError, "cannot unlock user", errUnlockUser)
+		}
+		return
+	}
+
+	respondAPISuccess(context, http.StatusOK, map[string]any{
+		"user":          user,
+		"session":       session,
+		"administrator": administrator,
+		"updatedUser":   unlockedUser,
+	})
+}
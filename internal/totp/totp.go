@@ -0,0 +1,160 @@
+package totp
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	secretLength int           = 20
+	keyLength    int           = 32
+	digits       int           = 6
+	period       time.Duration = 30 * time.Second
+	skew         int64         = 1
+)
+
+var (
+	encoding *base32.Encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+)
+
+var (
+	ErrSecretInvalid error = errors.New("totp secret invalid")
+	ErrKeyInvalid    error = errors.New("totp key invalid")
+)
+
+func NewSecret() (string, error) {
+	secret := make([]byte, secretLength)
+	_, errRead := rand.Read(secret)
+	if errRead != nil {
+		return "", errRead
+	}
+
+	return encoding.EncodeToString(secret), nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	if len(key) != keyLength {
+		return nil, ErrKeyInvalid
+	}
+
+	block, errNewCipher := aes.NewCipher(key)
+	if errNewCipher != nil {
+		return nil, errors.Join(ErrKeyInvalid, errNewCipher)
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// Seal encrypts secret with key for storage. The account is bound to the
+// ciphertext so that a sealed secret cannot be moved to another account.
+func Seal(key []byte, secret string, account string) (string, error) {
+	aead, errNewAEAD := newAEAD(key)
+	if errNewAEAD != nil {
+		return "", errNewAEAD
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	_, errRead := rand.Read(nonce)
+	if errRead != nil {
+		return "", errRead
+	}
+
+	return base64.StdEncoding.EncodeToString(aead.Seal(nonce, nonce, []byte(secret), []byte(account))), nil
+}
+
+func Open(key []byte, sealed string, account string) (string, error) {
+	aead, errNewAEAD := newAEAD(key)
+	if errNewAEAD != nil {
+		return "", errNewAEAD
+	}
+
+	raw, errDecode := base64.StdEncoding.DecodeString(sealed)
+	if errDecode != nil {
+		return "", errors.Join(ErrSecretInvalid, errDecode)
+	}
+	if len(raw) < aead.NonceSize() {
+		return "", ErrSecretInvalid
+	}
+
+	secret, errOpen := aead.Open(nil, raw[:aead.NonceSize()], raw[aead.NonceSize():], []byte(account))
+	if errOpen != nil {
+		return "", errors.Join(ErrSecretInvalid, errOpen)
+	}
+
+	return string(secret), nil
+}
+
+func ProvisioningURI(issuer string, account string, secret string) string {
+	query := url.Values{}
+	query.Set("secret", secret)
+	query.Set("issuer", issuer)
+	query.Set("algorithm", "SHA1")
+	query.Set("digits", fmt.Sprint(digits))
+	query.Set("period", fmt.Sprint(int(period.Seconds())))
+
+	return (&url.URL{
+		Scheme:   "otpauth",
+		Host:     "totp",
+		Path:     "/" + issuer + ":" + account,
+		RawQuery: query.Encode(),
+	}).String()
+}
+
+func Step(moment time.Time) int64 {
+	return moment.Unix() / int64(period.Seconds())
+}
+
+func generate(key []byte, step int64) string {
+	message := make([]byte, 8)
+	binary.BigEndian.PutUint64(message, uint64(step))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(message)
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0F
+	truncated := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7FFFFFFF
+
+	modulus := uint32(1)
+	for range digits {
+		modulus *= 10
+	}
+
+	return fmt.Sprintf("%0*d", digits, truncated%modulus)
+}
+
+func Validate(secret string, code string, moment time.Time, lastStep int64) (int64, error) {
+	key, errDecode := encoding.DecodeString(strings.ToUpper(secret))
+	if errDecode != nil {
+		return 0, errors.Join(ErrSecretInvalid, errDecode)
+	}
+
+	code = strings.ReplaceAll(code, " ", "")
+	if len(code) != digits {
+		return 0, nil
+	}
+
+	current := Step(moment)
+	for step := current - skew; step <= current+skew; step++ {
+		if step <= lastStep {
+			continue
+		}
+		if subtle.ConstantTimeCompare([]byte(generate(key, step)), []byte(code)) == 1 {
+			return step, nil
+		}
+	}
+
+	return 0, nil
+}
@@ -0,0 +1,129 @@
+package totp
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// The SHA-1 vectors of RFC 6238 Appendix B, truncated to six digits.
+var rfc6238Key []byte = []byte("12345678901234567890")
+
+func TestGenerateMatchesRFC6238(t *testing.T) {
+	tests := []struct {
+		unix     int64
+		expected string
+	}{
+		{unix: 59, expected: "287082"},
+		{unix: 1111111109, expected: "081804"},
+		{unix: 1111111111, expected: "050471"},
+		{unix: 1234567890, expected: "005924"},
+		{unix: 2000000000, expected: "279037"},
+		{unix: 20000000000, expected: "353130"},
+	}
+
+	for _, test := range tests {
+		if code := generate(rfc6238Key, Step(time.Unix(test.unix, 0))); code != test.expected {
+			t.Errorf("time %d: expected %s, got %s", test.unix, test.expected, code)
+		}
+	}
+}
+
+func TestValidateAllowsSkew(t *testing.T) {
+	secret := encoding.EncodeToString(rfc6238Key)
+	moment := time.Unix(1111111111, 0)
+	current := Step(moment)
+
+	tests := []struct {
+		step     int64
+		accepted bool
+	}{
+		{step: current - 2, accepted: false},
+		{step: current - 1, accepted: true},
+		{step: current, accepted: true},
+		{step: current + 1, accepted: true},
+		{step: current + 2, accepted: false},
+	}
+
+	for _, test := range tests {
+		step, errValidate := Validate(secret, generate(rfc6238Key, test.step), moment, 0)
+		if errValidate != nil {
+			t.Fatal(errValidate)
+		}
+		if accepted := step == test.step; accepted != test.accepted {
+			t.Errorf("step %+d: expected accepted %v, got step %d", test.step-current, test.accepted, step)
+		}
+	}
+}
+
+func TestValidateRejectsReplay(t *testing.T) {
+	secret := encoding.EncodeToString(rfc6238Key)
+	moment := time.Unix(1111111111, 0)
+	current := Step(moment)
+	code := generate(rfc6238Key, current)
+
+	step, errValidate := Validate(secret, code, moment, 0)
+	if errValidate != nil {
+		t.Fatal(errValidate)
+	}
+	if step != current {
+		t.Fatalf("expected step %d, got %d", current, step)
+	}
+
+	step, errValidate = Validate(secret, code, moment, step)
+	if errValidate != nil {
+		t.Fatal(errValidate)
+	}
+	if step != 0 {
+		t.Fatalf("expected a replayed code to be rejected, got step %d", step)
+	}
+
+	step, errValidate = Validate(secret, generate(rfc6238Key, current-1), moment, current)
+	if errValidate != nil {
+		t.Fatal(errValidate)
+	}
+	if step != 0 {
+		t.Fatalf("expected a code older than the last step to be rejected, got step %d", step)
+	}
+}
+
+func TestSealBindsSecretToKeyAndAccount(t *testing.T) {
+	key := make([]byte, keyLength)
+	secret, errNewSecret := NewSecret()
+	if errNewSecret != nil {
+		t.Fatal(errNewSecret)
+	}
+
+	sealed, errSeal := Seal(key, secret, "jdoe")
+	if errSeal != nil {
+		t.Fatal(errSeal)
+	}
+	if sealed == secret {
+		t.Fatal("expected the sealed secret to differ from the secret")
+	}
+
+	opened, errOpen := Open(key, sealed, "jdoe")
+	if errOpen != nil {
+		t.Fatal(errOpen)
+	}
+	if opened != secret {
+		t.Fatalf("expected %s, got %s", secret, opened)
+	}
+
+	_, errOpen = Open(key, sealed, "mallory")
+	if !errors.Is(errOpen, ErrSecretInvalid) {
+		t.Fatalf("expected %v for another account, got %v", ErrSecretInvalid, errOpen)
+	}
+
+	otherKey := make([]byte, keyLength)
+	otherKey[0] = 1
+	_, errOpen = Open(otherKey, sealed, "jdoe")
+	if !errors.Is(errOpen, ErrSecretInvalid) {
+		t.Fatalf("expected %v for another key, got %v", ErrSecretInvalid, errOpen)
+	}
+
+	_, errSeal = Seal(key[:16], secret, "jdoe")
+	if !errors.Is(errSeal, ErrKeyInvalid) {
+		t.Fatalf("expected %v for a short key, got %v", ErrKeyInvalid, errSeal)
+	}
+}
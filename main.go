@@ -1,10 +1,14 @@
 package main
 
 import (
+	"os"
+
+	"github.com/sorucoder/samuel/internal/command"
 	"github.com/sorucoder/samuel/internal/configuration"
 	"github.com/sorucoder/samuel/internal/database"
 	"github.com/sorucoder/samuel/internal/email"
 	"github.com/sorucoder/samuel/internal/ldap"
+	"github.com/sorucoder/samuel/internal/oidc"
 	"github.com/sorucoder/samuel/internal/server"
 )
 
@@ -12,7 +16,13 @@ func main() {
 	configuration.Initialize()
 	database.Initialize()
 	ldap.Initialize()
+	oidc.Initialize()
 	email.Initialize()
 
+	if len(os.Args) > 1 {
+		command.Run(os.Args[1:])
+		return
+	}
+
 	server.Run()
 }